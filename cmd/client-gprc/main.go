@@ -8,8 +8,10 @@ import (
 	
 	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/certs"
 )
 
 const (
@@ -20,10 +22,24 @@ const (
 func main() {
 	// get configuration
 	address := flag.String("server", "", "gRPC servre in format host:port")
+	token := flag.String("token", "", "Bearer token sent in authorization metadata")
+	useTLS := flag.Bool("tls", false, "Connect with TLS, implied by the other TLS flags")
+	caFile := flag.String("ca-file", "", "PEM CA bundle the server certificate is verified with, the system roots if empty")
+	certFile := flag.String("cert-file", "", "PEM client certificate presented to the server")
+	keyFile := flag.String("key-file", "", "PEM private key of the client certificate")
+	serverName := flag.String("server-name", "", "Name the server certificate is verified for, the server host if empty")
 	flag.Parse()
 
 	// set up a conncetion to the server
-	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	creds := grpc.WithInsecure()
+	if *useTLS || len(*caFile) > 0 || len(*certFile) > 0 || len(*serverName) > 0 {
+		r, err := certs.NewReloader(certs.Files{Cert: *certFile, Key: *keyFile, CA: *caFile})
+		if err != nil {
+			log.Fatalf("failed to load TLS files: %v", err)
+		}
+		creds = grpc.WithTransportCredentials(certs.NewClientCredentials(r, *serverName))
+	}
+	conn, err := grpc.Dial(*address, creds)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -34,6 +50,10 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if len(*token) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
+
 	t := time.Now().In(time.UTC)
 	reminder, _ := ptypes.TimestampProto(t)
 	pfx := t.Format(time.RFC3339Nano)
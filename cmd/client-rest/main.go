@@ -11,11 +11,28 @@ import (
 	"flag"
 )
 
+// bearerTransport adds the bearer token to requests
+type bearerTransport struct {
+	token string
+}
+
+// RoundTrip sends the request with the Authorization header
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
 func main() {
 	// get configuration
 	address := flag.String("server", "http://localhost:8080", "Http gateway url, eg http://localhost:8080")
+	token := flag.String("token", "", "Bearer token sent in the Authorization header")
 	flag.Parse()
 
+	if len(*token) > 0 {
+		http.DefaultClient.Transport = &bearerTransport{token: *token}
+	}
+
 	t := time.Now().In(time.UTC)
 	pfx := t.Format(time.RFC3339Nano)
 
@@ -10,6 +10,8 @@ import (
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -27,6 +29,282 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+//*
+// Importance of a task
+type Priority int32
+
+const (
+	// Priority is not set
+	Priority_PRIORITY_NONE   Priority = 0
+	Priority_PRIORITY_LOW    Priority = 1
+	Priority_PRIORITY_MEDIUM Priority = 2
+	Priority_PRIORITY_HIGH   Priority = 3
+	Priority_PRIORITY_URGENT Priority = 4
+)
+
+var Priority_name = map[int32]string{
+	0: "PRIORITY_NONE",
+	1: "PRIORITY_LOW",
+	2: "PRIORITY_MEDIUM",
+	3: "PRIORITY_HIGH",
+	4: "PRIORITY_URGENT",
+}
+
+var Priority_value = map[string]int32{
+	"PRIORITY_NONE":   0,
+	"PRIORITY_LOW":    1,
+	"PRIORITY_MEDIUM": 2,
+	"PRIORITY_HIGH":   3,
+	"PRIORITY_URGENT": 4,
+}
+
+func (x Priority) String() string {
+	return proto.EnumName(Priority_name, int32(x))
+}
+
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{0}
+}
+
+//*
+// How tasks are matched against a set of tags
+type TagMatch int32
+
+const (
+	// Task has any of the tags
+	TagMatch_TAG_MATCH_ANY TagMatch = 0
+	// Task has all of the tags
+	TagMatch_TAG_MATCH_ALL TagMatch = 1
+)
+
+var TagMatch_name = map[int32]string{
+	0: "TAG_MATCH_ANY",
+	1: "TAG_MATCH_ALL",
+}
+
+var TagMatch_value = map[string]int32{
+	"TAG_MATCH_ANY": 0,
+	"TAG_MATCH_ALL": 1,
+}
+
+func (x TagMatch) String() string {
+	return proto.EnumName(TagMatch_name, int32(x))
+}
+
+func (TagMatch) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{1}
+}
+
+//*
+// Kind of change of a task
+type EventType int32
+
+const (
+	// Event type is not set
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	// Task was created
+	EventType_EVENT_TYPE_CREATED EventType = 1
+	// Task was changed or restored from trash
+	EventType_EVENT_TYPE_UPDATED EventType = 2
+	// Task was moved to trash
+	EventType_EVENT_TYPE_DELETED EventType = 3
+	// Reminder of the task is due
+	EventType_EVENT_TYPE_REMINDER EventType = 4
+)
+
+var EventType_name = map[int32]string{
+	0: "EVENT_TYPE_UNSPECIFIED",
+	1: "EVENT_TYPE_CREATED",
+	2: "EVENT_TYPE_UPDATED",
+	3: "EVENT_TYPE_DELETED",
+	4: "EVENT_TYPE_REMINDER",
+}
+
+var EventType_value = map[string]int32{
+	"EVENT_TYPE_UNSPECIFIED": 0,
+	"EVENT_TYPE_CREATED":     1,
+	"EVENT_TYPE_UPDATED":     2,
+	"EVENT_TYPE_DELETED":     3,
+	"EVENT_TYPE_REMINDER":    4,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{2}
+}
+
+//*
+// Kind of change recorded in task history
+type HistoryAction int32
+
+const (
+	// Action is not set
+	HistoryAction_HISTORY_ACTION_UNSPECIFIED HistoryAction = 0
+	// Task was created, including the next occurrence of a recurring task
+	HistoryAction_HISTORY_ACTION_CREATE HistoryAction = 1
+	// Task fields or tags were updated, the task was completed, reopened or restored from trash
+	HistoryAction_HISTORY_ACTION_UPDATE HistoryAction = 2
+	// Task was moved to trash
+	HistoryAction_HISTORY_ACTION_DELETE HistoryAction = 3
+)
+
+var HistoryAction_name = map[int32]string{
+	0: "HISTORY_ACTION_UNSPECIFIED",
+	1: "HISTORY_ACTION_CREATE",
+	2: "HISTORY_ACTION_UPDATE",
+	3: "HISTORY_ACTION_DELETE",
+}
+
+var HistoryAction_value = map[string]int32{
+	"HISTORY_ACTION_UNSPECIFIED": 0,
+	"HISTORY_ACTION_CREATE":      1,
+	"HISTORY_ACTION_UPDATE":      2,
+	"HISTORY_ACTION_DELETE":      3,
+}
+
+func (x HistoryAction) String() string {
+	return proto.EnumName(HistoryAction_name, int32(x))
+}
+
+func (HistoryAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{3}
+}
+
+//*
+// State of delivering an event to a webhook
+type DeliveryStatus int32
+
+const (
+	// Delivery is waiting for its first or next attempt
+	DeliveryStatus_DELIVERY_STATUS_PENDING DeliveryStatus = 0
+	// Webhook responded with 2xx status
+	DeliveryStatus_DELIVERY_STATUS_SUCCEEDED DeliveryStatus = 1
+	// All attempts failed, delivery is not retried
+	DeliveryStatus_DELIVERY_STATUS_FAILED DeliveryStatus = 2
+)
+
+var DeliveryStatus_name = map[int32]string{
+	0: "DELIVERY_STATUS_PENDING",
+	1: "DELIVERY_STATUS_SUCCEEDED",
+	2: "DELIVERY_STATUS_FAILED",
+}
+
+var DeliveryStatus_value = map[string]int32{
+	"DELIVERY_STATUS_PENDING":   0,
+	"DELIVERY_STATUS_SUCCEEDED": 1,
+	"DELIVERY_STATUS_FAILED":    2,
+}
+
+func (x DeliveryStatus) String() string {
+	return proto.EnumName(DeliveryStatus_name, int32(x))
+}
+
+func (DeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{4}
+}
+
+//*
+// What a caller may do with a task
+type AccessLevel int32
+
+const (
+	// Access is not set, e.g. authentication is disabled
+	AccessLevel_ACCESS_LEVEL_UNSPECIFIED AccessLevel = 0
+	// Task is shared with the caller to read it
+	AccessLevel_ACCESS_LEVEL_VIEWER AccessLevel = 1
+	// Task is shared with the caller to read and change it, but not to delete or share it
+	AccessLevel_ACCESS_LEVEL_EDITOR AccessLevel = 2
+	// Caller owns the task
+	AccessLevel_ACCESS_LEVEL_OWNER AccessLevel = 3
+)
+
+var AccessLevel_name = map[int32]string{
+	0: "ACCESS_LEVEL_UNSPECIFIED",
+	1: "ACCESS_LEVEL_VIEWER",
+	2: "ACCESS_LEVEL_EDITOR",
+	3: "ACCESS_LEVEL_OWNER",
+}
+
+var AccessLevel_value = map[string]int32{
+	"ACCESS_LEVEL_UNSPECIFIED": 0,
+	"ACCESS_LEVEL_VIEWER":      1,
+	"ACCESS_LEVEL_EDITOR":      2,
+	"ACCESS_LEVEL_OWNER":       3,
+}
+
+func (x AccessLevel) String() string {
+	return proto.EnumName(AccessLevel_name, int32(x))
+}
+
+func (AccessLevel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{5}
+}
+
+//*
+// What happens to the tasks of a deleted project
+type ProjectDeleteMode int32
+
+const (
+	// Project is archived, its tasks are kept in it and no tasks can be added to it
+	ProjectDeleteMode_PROJECT_DELETE_MODE_ARCHIVE ProjectDeleteMode = 0
+	// Project is deleted and its tasks are moved to trash, restored tasks belong to no project
+	ProjectDeleteMode_PROJECT_DELETE_MODE_CASCADE ProjectDeleteMode = 1
+)
+
+var ProjectDeleteMode_name = map[int32]string{
+	0: "PROJECT_DELETE_MODE_ARCHIVE",
+	1: "PROJECT_DELETE_MODE_CASCADE",
+}
+
+var ProjectDeleteMode_value = map[string]int32{
+	"PROJECT_DELETE_MODE_ARCHIVE": 0,
+	"PROJECT_DELETE_MODE_CASCADE": 1,
+}
+
+func (x ProjectDeleteMode) String() string {
+	return proto.EnumName(ProjectDeleteMode_name, int32(x))
+}
+
+func (ProjectDeleteMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{6}
+}
+
+//*
+// What calls an API key is allowed to make
+type ApiKeyScope int32
+
+const (
+	// Not a valid scope
+	ApiKeyScope_API_KEY_SCOPE_UNSPECIFIED ApiKeyScope = 0
+	// Only calls reading data, like Read, ReadAll, Search, Watch and List calls
+	ApiKeyScope_API_KEY_SCOPE_READ_ONLY ApiKeyScope = 1
+	// All calls
+	ApiKeyScope_API_KEY_SCOPE_READ_WRITE ApiKeyScope = 2
+)
+
+var ApiKeyScope_name = map[int32]string{
+	0: "API_KEY_SCOPE_UNSPECIFIED",
+	1: "API_KEY_SCOPE_READ_ONLY",
+	2: "API_KEY_SCOPE_READ_WRITE",
+}
+
+var ApiKeyScope_value = map[string]int32{
+	"API_KEY_SCOPE_UNSPECIFIED": 0,
+	"API_KEY_SCOPE_READ_ONLY":   1,
+	"API_KEY_SCOPE_READ_WRITE":  2,
+}
+
+func (x ApiKeyScope) String() string {
+	return proto.EnumName(ApiKeyScope_name, int32(x))
+}
+
+func (ApiKeyScope) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{7}
+}
+
 //*
 // tasks we will be doing
 type ToDo struct {
@@ -37,10 +315,47 @@ type ToDo struct {
 	// Detailed description of task
 	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	// Date and time of reminder for task
-	Reminder             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=reminder,proto3" json:"reminder,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Reminder *timestamp.Timestamp `protobuf:"bytes,4,opt,name=reminder,proto3" json:"reminder,omitempty"`
+	// Whether the task is done, set by Complete and Reopen
+	Completed bool `protobuf:"varint,5,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Date and time the task was completed, set by server
+	CompletedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// Date and time the task is due, not set if the task has no deadline
+	Due *timestamp.Timestamp `protobuf:"bytes,7,opt,name=due,proto3" json:"due,omitempty"`
+	// Importance of the task
+	Priority Priority `protobuf:"varint,8,opt,name=priority,proto3,enum=v1.Priority" json:"priority,omitempty"`
+	// Labels of the task, set by Create and changed with AddTags and RemoveTags
+	Tags []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// ID of the task this task is a subtask of, 0 for a top level task
+	ParentId int64 `protobuf:"varint,10,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Subtasks of the task, returned by Read up to the requested depth
+	Children []*ToDo `protobuf:"bytes,11,rep,name=children,proto3" json:"children,omitempty"`
+	// RFC 5545 RRULE the reminder of the task repeats by, for example
+	// "FREQ=WEEKLY;BYDAY=MO", empty for a task that does not repeat
+	Recurrence string `protobuf:"bytes,12,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// IANA time zone the recurrence is evaluated in, for example "Europe/Berlin", UTC if empty
+	TimeZone string `protobuf:"bytes,13,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Date and time the task was moved to trash by Delete, not set for a task that is not deleted
+	DeletedAt *timestamp.Timestamp `protobuf:"bytes,14,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Opaque version of the task, changed by server on every write.
+	// Pass it to Update and Delete to reject them if the task has changed since it was read.
+	// Returned in the ETag header by the HTTP gateway
+	Etag string `protobuf:"bytes,15,opt,name=etag,proto3" json:"etag,omitempty"`
+	// ID of the project the task belongs to, 0 for a task in no project.
+	// Subtasks belong to the project of their parent, use MoveTask to move a task to another project
+	ProjectId int64 `protobuf:"varint,16,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Sort key of the task in the custom order ReadAll lists tasks in by default, set by server.
+	// New tasks are placed last, use Reorder to move a task
+	Position string `protobuf:"bytes,17,opt,name=position,proto3" json:"position,omitempty"`
+	// Subject of the caller who created the task, set by server.
+	// Callers reach their own tasks and the ones shared with them, empty if the task was created without authentication
+	OwnerId string `protobuf:"bytes,18,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// Access of the caller to the task, set by server.
+	// Not set if authentication is disabled, nor for tasks an admin reaches with all_owners without them being shared
+	Access               AccessLevel `protobuf:"varint,19,opt,name=access,proto3,enum=v1.AccessLevel" json:"access,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *ToDo) Reset()         { *m = ToDo{} }
@@ -96,6 +411,111 @@ func (m *ToDo) GetReminder() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *ToDo) GetCompleted() bool {
+	if m != nil {
+		return m.Completed
+	}
+	return false
+}
+
+func (m *ToDo) GetCompletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CompletedAt
+	}
+	return nil
+}
+
+func (m *ToDo) GetDue() *timestamp.Timestamp {
+	if m != nil {
+		return m.Due
+	}
+	return nil
+}
+
+func (m *ToDo) GetPriority() Priority {
+	if m != nil {
+		return m.Priority
+	}
+	return Priority_PRIORITY_NONE
+}
+
+func (m *ToDo) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ToDo) GetParentId() int64 {
+	if m != nil {
+		return m.ParentId
+	}
+	return 0
+}
+
+func (m *ToDo) GetChildren() []*ToDo {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func (m *ToDo) GetRecurrence() string {
+	if m != nil {
+		return m.Recurrence
+	}
+	return ""
+}
+
+func (m *ToDo) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *ToDo) GetDeletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
+func (m *ToDo) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *ToDo) GetProjectId() int64 {
+	if m != nil {
+		return m.ProjectId
+	}
+	return 0
+}
+
+func (m *ToDo) GetPosition() string {
+	if m != nil {
+		return m.Position
+	}
+	return ""
+}
+
+func (m *ToDo) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *ToDo) GetAccess() AccessLevel {
+	if m != nil {
+		return m.Access
+	}
+	return AccessLevel_ACCESS_LEVEL_UNSPECIFIED
+}
+
 //*
 // Request data to create a new task
 type CreateRequest struct {
@@ -204,7 +624,13 @@ type ReadRequest struct {
 	// API versioning, specify version explicitly
 	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	// Unique identifier of the task
-	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Number of levels of subtasks to return in children, 0 returns none
+	Depth int32 `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Return the task and its subtasks even if they are in trash
+	ShowDeleted bool `protobuf:"varint,4,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	// Read the task even if it belongs to another caller, allowed to admins only
+	AllOwners            bool     `protobuf:"varint,5,opt,name=all_owners,json=allOwners,proto3" json:"all_owners,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -249,6 +675,27 @@ func (m *ReadRequest) GetId() int64 {
 	return 0
 }
 
+func (m *ReadRequest) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *ReadRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+func (m *ReadRequest) GetAllOwners() bool {
+	if m != nil {
+		return m.AllOwners
+	}
+	return false
+}
+
 //*
 // Contains task data specified by ID in Request
 type ReadResponse struct {
@@ -301,125 +748,273 @@ func (m *ReadResponse) GetToDo() *ToDo {
 }
 
 //*
-// Request Data to update task
-type UpdateRequest struct {
+// Request data to read subtasks of a task
+type ReadChildrenRequest struct {
 	// API versioning, specify version explicitly
 	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	// Task entity to be updated
-	ToDo                 *ToDo    `protobuf:"bytes,2,opt,name=toDo,proto3" json:"toDo,omitempty"`
+	// Unique identifier of the parent task
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
-func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateRequest) ProtoMessage()    {}
-func (*UpdateRequest) Descriptor() ([]byte, []int) {
+func (m *ReadChildrenRequest) Reset()         { *m = ReadChildrenRequest{} }
+func (m *ReadChildrenRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadChildrenRequest) ProtoMessage()    {}
+func (*ReadChildrenRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_80b701c7b1c502fe, []int{5}
 }
 
-func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+func (m *ReadChildrenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadChildrenRequest.Unmarshal(m, b)
 }
-func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+func (m *ReadChildrenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadChildrenRequest.Marshal(b, m, deterministic)
 }
-func (m *UpdateRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateRequest.Merge(m, src)
+func (m *ReadChildrenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadChildrenRequest.Merge(m, src)
 }
-func (m *UpdateRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateRequest.Size(m)
+func (m *ReadChildrenRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadChildrenRequest.Size(m)
 }
-func (m *UpdateRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+func (m *ReadChildrenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadChildrenRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReadChildrenRequest proto.InternalMessageInfo
 
-func (m *UpdateRequest) GetApi() string {
+func (m *ReadChildrenRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *UpdateRequest) GetToDo() *ToDo {
+func (m *ReadChildrenRequest) GetId() int64 {
 	if m != nil {
-		return m.ToDo
+		return m.Id
 	}
-	return nil
+	return 0
 }
 
 //*
-// Contains status of update opertation
-type UpdateResponse struct {
+// Contains direct subtasks of the task specified by ID in Request
+type ReadChildrenResponse struct {
 	// API versioning, specify version explicitly
 	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	// Contains number of entites that have been upadted
-	// Equals 1 if update was successful
-	Updated              int64    `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	// List of subtasks ordered by ID
+	ToDos                []*ToDo  `protobuf:"bytes,2,rep,name=toDos,proto3" json:"toDos,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
-func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
-func (*UpdateResponse) ProtoMessage()    {}
-func (*UpdateResponse) Descriptor() ([]byte, []int) {
+func (m *ReadChildrenResponse) Reset()         { *m = ReadChildrenResponse{} }
+func (m *ReadChildrenResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadChildrenResponse) ProtoMessage()    {}
+func (*ReadChildrenResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_80b701c7b1c502fe, []int{6}
 }
 
-func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
+func (m *ReadChildrenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadChildrenResponse.Unmarshal(m, b)
 }
-func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+func (m *ReadChildrenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadChildrenResponse.Marshal(b, m, deterministic)
 }
-func (m *UpdateResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateResponse.Merge(m, src)
+func (m *ReadChildrenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadChildrenResponse.Merge(m, src)
 }
-func (m *UpdateResponse) XXX_Size() int {
-	return xxx_messageInfo_UpdateResponse.Size(m)
+func (m *ReadChildrenResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadChildrenResponse.Size(m)
 }
-func (m *UpdateResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+func (m *ReadChildrenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadChildrenResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+var xxx_messageInfo_ReadChildrenResponse proto.InternalMessageInfo
 
-func (m *UpdateResponse) GetApi() string {
+func (m *ReadChildrenResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *UpdateResponse) GetUpdated() int64 {
+func (m *ReadChildrenResponse) GetToDos() []*ToDo {
 	if m != nil {
-		return m.Updated
+		return m.ToDos
 	}
-	return 0
+	return nil
 }
 
 //*
-// Request data to delete task
-type DeleteRequest struct {
+// Request Data to update task
+type UpdateRequest struct {
 	// API versioning, specify version explicitly
 	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	// Unique identifier of the task to be deleted
-	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Task entity to be updated
+	ToDo *ToDo `protobuf:"bytes,2,opt,name=toDo,proto3" json:"toDo,omitempty"`
+	// Fields of toDo to update: title, description, reminder, due, priority
+	// All fields are replaced if empty or "*"
+	// Filled from the JSON body keys by the PATCH HTTP binding
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Etag of the task the update is based on, the update is aborted if the task has changed since
+	// toDo.etag or the If-Match HTTP header are used if empty, the task is not checked if all are empty
+	Etag string `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Update the task even if it belongs to another caller, allowed to admins only
+	AllOwners            bool     `protobuf:"varint,5,opt,name=all_owners,json=allOwners,proto3" json:"all_owners,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
-func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteRequest) ProtoMessage()    {}
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{7}
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{7}
+}
+
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(m, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateRequest) GetToDo() *ToDo {
+	if m != nil {
+		return m.ToDo
+	}
+	return nil
+}
+
+func (m *UpdateRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdateRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *UpdateRequest) GetAllOwners() bool {
+	if m != nil {
+		return m.AllOwners
+	}
+	return false
+}
+
+//*
+// Contains status of update opertation
+type UpdateResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Contains number of entites that have been upadted
+	// Equals 1 if update was successful
+	Updated int64 `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Etag of the task after update
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
+func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateResponse) ProtoMessage()    {}
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{8}
+}
+
+func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
+}
+func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateResponse.Merge(m, src)
+}
+func (m *UpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateResponse.Size(m)
+}
+func (m *UpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+
+func (m *UpdateResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateResponse) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *UpdateResponse) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+//*
+// Request data to move a task to trash
+type DeleteRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task to be deleted
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Delete subtasks with the task, otherwise a task with subtasks is not deleted
+	Cascade bool `protobuf:"varint,3,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	// Etag of the task the delete is based on, the delete is aborted if the task has changed since
+	// The If-Match HTTP header is used if empty, the task is not checked if both are empty
+	Etag string `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Delete the task even if it belongs to another caller, allowed to admins only
+	AllOwners            bool     `protobuf:"varint,5,opt,name=all_owners,json=allOwners,proto3" json:"all_owners,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{9}
 }
 
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -454,6 +1049,27 @@ func (m *DeleteRequest) GetId() int64 {
 	return 0
 }
 
+func (m *DeleteRequest) GetCascade() bool {
+	if m != nil {
+		return m.Cascade
+	}
+	return false
+}
+
+func (m *DeleteRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetAllOwners() bool {
+	if m != nil {
+		return m.AllOwners
+	}
+	return false
+}
+
 //*
 // Contains status of delete operation
 type DeleteResponse struct {
@@ -471,7 +1087,7 @@ func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
 func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteResponse) ProtoMessage()    {}
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{8}
+	return fileDescriptor_80b701c7b1c502fe, []int{10}
 }
 
 func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
@@ -507,391 +1123,8211 @@ func (m *DeleteResponse) GetDeleted() int64 {
 }
 
 //*
-// Request Data to read all tasks
-type ReadAllRequest struct {
+// Request data to create tasks in one transaction
+type BatchCreateRequest struct {
 	// API versioning, specify version explicitly
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Tasks to create, none is created if any fails
+	Requests             []*CreateRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *ReadAllRequest) Reset()         { *m = ReadAllRequest{} }
-func (m *ReadAllRequest) String() string { return proto.CompactTextString(m) }
-func (*ReadAllRequest) ProtoMessage()    {}
-func (*ReadAllRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{9}
+func (m *BatchCreateRequest) Reset()         { *m = BatchCreateRequest{} }
+func (m *BatchCreateRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateRequest) ProtoMessage()    {}
+func (*BatchCreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{11}
 }
 
-func (m *ReadAllRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadAllRequest.Unmarshal(m, b)
+func (m *BatchCreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateRequest.Unmarshal(m, b)
 }
-func (m *ReadAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadAllRequest.Marshal(b, m, deterministic)
+func (m *BatchCreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateRequest.Marshal(b, m, deterministic)
 }
-func (m *ReadAllRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadAllRequest.Merge(m, src)
+func (m *BatchCreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateRequest.Merge(m, src)
 }
-func (m *ReadAllRequest) XXX_Size() int {
-	return xxx_messageInfo_ReadAllRequest.Size(m)
+func (m *BatchCreateRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateRequest.Size(m)
 }
-func (m *ReadAllRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadAllRequest.DiscardUnknown(m)
+func (m *BatchCreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadAllRequest proto.InternalMessageInfo
+var xxx_messageInfo_BatchCreateRequest proto.InternalMessageInfo
 
-func (m *ReadAllRequest) GetApi() string {
+func (m *BatchCreateRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
+func (m *BatchCreateRequest) GetRequests() []*CreateRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
 //*
-// Contains a list of all tasks
-type ReadAllResponse struct {
+// Contains results of creating tasks in the order of requests
+type BatchCreateResponse struct {
 	// API versioning, specify version explicitly
 	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	// List of all tasks
-	ToDos                []*ToDo  `protobuf:"bytes,2,rep,name=toDos,proto3" json:"toDos,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Result of each create request
+	Responses            []*CreateResponse `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *ReadAllResponse) Reset()         { *m = ReadAllResponse{} }
-func (m *ReadAllResponse) String() string { return proto.CompactTextString(m) }
-func (*ReadAllResponse) ProtoMessage()    {}
-func (*ReadAllResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80b701c7b1c502fe, []int{10}
+func (m *BatchCreateResponse) Reset()         { *m = BatchCreateResponse{} }
+func (m *BatchCreateResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateResponse) ProtoMessage()    {}
+func (*BatchCreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{12}
 }
 
-func (m *ReadAllResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadAllResponse.Unmarshal(m, b)
+func (m *BatchCreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateResponse.Unmarshal(m, b)
 }
-func (m *ReadAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadAllResponse.Marshal(b, m, deterministic)
+func (m *BatchCreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateResponse.Marshal(b, m, deterministic)
 }
-func (m *ReadAllResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadAllResponse.Merge(m, src)
+func (m *BatchCreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateResponse.Merge(m, src)
 }
-func (m *ReadAllResponse) XXX_Size() int {
-	return xxx_messageInfo_ReadAllResponse.Size(m)
+func (m *BatchCreateResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateResponse.Size(m)
 }
-func (m *ReadAllResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadAllResponse.DiscardUnknown(m)
+func (m *BatchCreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadAllResponse proto.InternalMessageInfo
+var xxx_messageInfo_BatchCreateResponse proto.InternalMessageInfo
 
-func (m *ReadAllResponse) GetApi() string {
+func (m *BatchCreateResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *ReadAllResponse) GetToDos() []*ToDo {
+func (m *BatchCreateResponse) GetResponses() []*CreateResponse {
 	if m != nil {
-		return m.ToDos
+		return m.Responses
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*ToDo)(nil), "v1.ToDo")
-	proto.RegisterType((*CreateRequest)(nil), "v1.CreateRequest")
-	proto.RegisterType((*CreateResponse)(nil), "v1.CreateResponse")
-	proto.RegisterType((*ReadRequest)(nil), "v1.ReadRequest")
-	proto.RegisterType((*ReadResponse)(nil), "v1.ReadResponse")
-	proto.RegisterType((*UpdateRequest)(nil), "v1.UpdateRequest")
-	proto.RegisterType((*UpdateResponse)(nil), "v1.UpdateResponse")
-	proto.RegisterType((*DeleteRequest)(nil), "v1.DeleteRequest")
-	proto.RegisterType((*DeleteResponse)(nil), "v1.DeleteResponse")
-	proto.RegisterType((*ReadAllRequest)(nil), "v1.ReadAllRequest")
-	proto.RegisterType((*ReadAllResponse)(nil), "v1.ReadAllResponse")
+//*
+// Request data to update tasks in one transaction
+type BatchUpdateRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Task updates, none is applied if any fails
+	// The If-Match HTTP header is not used for batch items
+	Requests             []*UpdateRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("todo-service.proto", fileDescriptor_80b701c7b1c502fe)
+func (m *BatchUpdateRequest) Reset()         { *m = BatchUpdateRequest{} }
+func (m *BatchUpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateRequest) ProtoMessage()    {}
+func (*BatchUpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{13}
 }
 
-var fileDescriptor_80b701c7b1c502fe = []byte{
-	// 686 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xc1, 0x4e, 0xdb, 0x4a,
-	0x14, 0x95, 0x9d, 0x10, 0xe0, 0x86, 0x04, 0xde, 0xc0, 0xd3, 0x8b, 0x22, 0xc4, 0xb3, 0xbc, 0x42,
-	0xd1, 0xb3, 0x4d, 0xf2, 0x10, 0x8b, 0x14, 0x15, 0x28, 0x51, 0xd5, 0xb5, 0x4b, 0x37, 0xdd, 0x19,
-	0xfb, 0xd6, 0x99, 0xca, 0xf1, 0xb8, 0x9e, 0x49, 0x28, 0xaa, 0xd8, 0x54, 0x6a, 0xd7, 0x55, 0xbb,
-	0xeb, 0x6f, 0xf5, 0x17, 0xba, 0xec, 0xa2, 0x9f, 0x50, 0xcd, 0x8c, 0x1d, 0x30, 0x25, 0xd9, 0x74,
-	0x95, 0xcc, 0x99, 0x73, 0xcf, 0x39, 0x77, 0xe6, 0x7a, 0x80, 0x08, 0x16, 0x31, 0x87, 0x63, 0x3e,
-	0xa3, 0x21, 0xba, 0x59, 0xce, 0x04, 0x23, 0xe6, 0xac, 0xdf, 0xfd, 0x37, 0x66, 0x2c, 0x4e, 0xd0,
-	0x53, 0xc8, 0xe5, 0xf4, 0x95, 0x27, 0xe8, 0x04, 0xb9, 0x08, 0x26, 0x99, 0x26, 0x75, 0x77, 0x0b,
-	0x42, 0x90, 0x51, 0x2f, 0x48, 0x53, 0x26, 0x02, 0x41, 0x59, 0xca, 0x8b, 0xdd, 0xff, 0xd4, 0x4f,
-	0xe8, 0xc4, 0x98, 0x3a, 0xfc, 0x2a, 0x88, 0x63, 0xcc, 0x3d, 0x96, 0x29, 0xc6, 0xef, 0x6c, 0xfb,
-	0xa3, 0x01, 0xf5, 0x0b, 0x36, 0x62, 0xa4, 0x0d, 0x26, 0x8d, 0x3a, 0x86, 0x65, 0xec, 0xd7, 0x7c,
-	0x93, 0x46, 0x64, 0x07, 0x56, 0x04, 0x15, 0x09, 0x76, 0x4c, 0xcb, 0xd8, 0x5f, 0xf7, 0xf5, 0x82,
-	0x58, 0xd0, 0x8c, 0x90, 0x87, 0x39, 0x55, 0x82, 0x9d, 0x9a, 0xda, 0xbb, 0x0b, 0x91, 0x23, 0x58,
-	0xcb, 0x71, 0x42, 0xd3, 0x08, 0xf3, 0x4e, 0xdd, 0x32, 0xf6, 0x9b, 0x83, 0xae, 0xab, 0xf3, 0xba,
-	0x65, 0x43, 0xee, 0x45, 0xd9, 0x90, 0x3f, 0xe7, 0xda, 0x27, 0xd0, 0x3a, 0xcf, 0x31, 0x10, 0xe8,
-	0xe3, 0x9b, 0x29, 0x72, 0x41, 0xb6, 0xa0, 0x16, 0x64, 0x54, 0x25, 0x5a, 0xf7, 0xe5, 0x5f, 0xb2,
-	0x0b, 0x75, 0xc1, 0x46, 0x4c, 0x25, 0x6a, 0x0e, 0xd6, 0xdc, 0x59, 0xdf, 0x95, 0xd1, 0x7d, 0x85,
-	0xda, 0x03, 0x68, 0x97, 0x02, 0x3c, 0x63, 0x29, 0xc7, 0x07, 0x14, 0x74, 0x93, 0x66, 0xd9, 0xa4,
-	0xed, 0x41, 0xd3, 0xc7, 0x20, 0x5a, 0x6c, 0x79, 0xbf, 0xe0, 0x31, 0x6c, 0xe8, 0x82, 0x85, 0x16,
-	0xcb, 0x43, 0x9e, 0x40, 0xeb, 0x45, 0x16, 0xfd, 0x41, 0x97, 0xc7, 0xd0, 0x2e, 0x05, 0x16, 0x46,
-	0xe8, 0xc0, 0xea, 0x54, 0x71, 0xca, 0xe4, 0xe5, 0xd2, 0xee, 0x43, 0x6b, 0x84, 0x09, 0x2e, 0xb3,
-	0xbf, 0xdf, 0xf1, 0x31, 0xb4, 0xcb, 0x92, 0x65, 0x86, 0x91, 0xe2, 0xcc, 0x0d, 0x8b, 0xa5, 0x6d,
-	0x43, 0x5b, 0x9e, 0xd7, 0x59, 0x92, 0x2c, 0x74, 0xb4, 0xcf, 0x61, 0x73, 0xce, 0x59, 0x68, 0xb1,
-	0x07, 0x2b, 0xb2, 0x7f, 0xde, 0x31, 0xad, 0x5a, 0xe5, 0x58, 0x34, 0x3c, 0xf8, 0x54, 0x83, 0xa6,
-	0x5c, 0x3f, 0xd7, 0x9f, 0x13, 0x79, 0x06, 0xab, 0x85, 0x28, 0x21, 0x92, 0x5b, 0x4d, 0xd1, 0xdd,
-	0xae, 0x60, 0xda, 0xd5, 0xde, 0x79, 0xff, 0xed, 0xfb, 0x17, 0xb3, 0x4d, 0x36, 0xbc, 0x59, 0xdf,
-	0x93, 0x1f, 0xa7, 0x17, 0x24, 0x09, 0x19, 0x41, 0x43, 0xcf, 0x15, 0xf9, 0x4b, 0x16, 0x55, 0x86,
-	0xb4, 0x4b, 0xee, 0x42, 0x85, 0xcc, 0xb6, 0x92, 0x69, 0xd9, 0x6b, 0xa5, 0xcc, 0xd0, 0xe8, 0x91,
-	0x53, 0xa8, 0x4b, 0x3b, 0xb2, 0x59, 0x1a, 0x97, 0x0a, 0x5b, 0xb7, 0x40, 0x51, 0xff, 0xb7, 0xaa,
-	0xdf, 0x24, 0xad, 0x79, 0x8c, 0x77, 0x34, 0xba, 0x21, 0x31, 0x34, 0xf4, 0xcd, 0xeb, 0x1c, 0x95,
-	0x31, 0xd2, 0x39, 0xaa, 0x83, 0x61, 0x1f, 0x29, 0x9d, 0x83, 0x2e, 0xb9, 0xd5, 0x91, 0x67, 0xe5,
-	0xd2, 0xe8, 0x66, 0x68, 0xf4, 0x5e, 0xfe, 0x33, 0x78, 0x78, 0x83, 0x3c, 0x85, 0x86, 0xbe, 0x71,
-	0x6d, 0x54, 0x19, 0x18, 0x6d, 0x54, 0x1d, 0x88, 0x32, 0x70, 0xaf, 0x1a, 0xf8, 0xc9, 0x4f, 0xe3,
-	0xf3, 0xd9, 0x0f, 0x83, 0x7c, 0x30, 0x60, 0x43, 0xde, 0x8c, 0x55, 0xbc, 0x74, 0x76, 0x06, 0x7b,
-	0x31, 0x73, 0xe2, 0x3c, 0x0b, 0x9d, 0xb1, 0x10, 0x99, 0x93, 0x23, 0x17, 0xce, 0x84, 0x86, 0x39,
-	0x2b, 0x18, 0x64, 0x28, 0x71, 0x3e, 0xf4, 0xbc, 0x98, 0x8a, 0xf1, 0xf4, 0xd2, 0x0d, 0xd9, 0xc4,
-	0xc3, 0x6b, 0xe6, 0xb0, 0x49, 0x20, 0xbc, 0xe5, 0xb5, 0x5d, 0x82, 0xd7, 0xcc, 0x95, 0xc4, 0xd3,
-	0x78, 0x12, 0xd0, 0x44, 0xd6, 0x0e, 0x6a, 0x7d, 0xf7, 0xa0, 0x67, 0x18, 0x83, 0xad, 0x20, 0xcb,
-	0x12, 0x1a, 0xaa, 0x07, 0xd0, 0x7b, 0xcd, 0x59, 0x3a, 0x2c, 0x11, 0x2a, 0x0a, 0xc4, 0x7f, 0x04,
-	0xb5, 0xc3, 0x83, 0x43, 0x72, 0x08, 0x3d, 0x1f, 0xc5, 0x34, 0x4f, 0x31, 0xb2, 0xae, 0xc6, 0x98,
-	0x5a, 0x62, 0x8c, 0x56, 0x8e, 0x9c, 0x4d, 0xf3, 0x10, 0xad, 0x88, 0x21, 0xb7, 0x52, 0x26, 0x2c,
-	0x7c, 0x4b, 0xb9, 0x70, 0x49, 0x03, 0xea, 0x5f, 0x4d, 0x63, 0xf5, 0xb2, 0xa1, 0x9e, 0xb8, 0xff,
-	0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0xf8, 0xf6, 0x63, 0xb7, 0xdb, 0x05, 0x00, 0x00,
+func (m *BatchUpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateRequest.Unmarshal(m, b)
+}
+func (m *BatchUpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateRequest.Merge(m, src)
+}
+func (m *BatchUpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateRequest.Size(m)
+}
+func (m *BatchUpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+var xxx_messageInfo_BatchUpdateRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (m *BatchUpdateRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
 
-// ToDoServiceClient is the client API for ToDoService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ToDoServiceClient interface {
-	// Read all Tasks
-	ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (*ReadAllResponse, error)
-	// Create a new task
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
-	// Read a task
-	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
-	// Update a task
-	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
-	// Delete a task
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+func (m *BatchUpdateRequest) GetRequests() []*UpdateRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
 }
 
-type toDoServiceClient struct {
-	cc grpc.ClientConnInterface
+//*
+// Contains results of updating tasks in the order of requests
+type BatchUpdateResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Result of each update request
+	Responses            []*UpdateResponse `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func NewToDoServiceClient(cc grpc.ClientConnInterface) ToDoServiceClient {
-	return &toDoServiceClient{cc}
+func (m *BatchUpdateResponse) Reset()         { *m = BatchUpdateResponse{} }
+func (m *BatchUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateResponse) ProtoMessage()    {}
+func (*BatchUpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{14}
 }
 
-func (c *toDoServiceClient) ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (*ReadAllResponse, error) {
-	out := new(ReadAllResponse)
-	err := c.cc.Invoke(ctx, "/v1.ToDoService/ReadAll", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
+func (m *BatchUpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateResponse.Unmarshal(m, b)
+}
+func (m *BatchUpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateResponse.Merge(m, src)
+}
+func (m *BatchUpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateResponse.Size(m)
+}
+func (m *BatchUpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateResponse proto.InternalMessageInfo
+
+func (m *BatchUpdateResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchUpdateResponse) GetResponses() []*UpdateResponse {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+//*
+// Request data to move tasks to trash in one transaction
+type BatchDeleteRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Tasks to delete, none is deleted if any fails
+	// The If-Match HTTP header is not used for batch items
+	Requests             []*DeleteRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BatchDeleteRequest) Reset()         { *m = BatchDeleteRequest{} }
+func (m *BatchDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteRequest) ProtoMessage()    {}
+func (*BatchDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{15}
+}
+
+func (m *BatchDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteRequest.Unmarshal(m, b)
+}
+func (m *BatchDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteRequest.Merge(m, src)
+}
+func (m *BatchDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteRequest.Size(m)
+}
+func (m *BatchDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteRequest proto.InternalMessageInfo
+
+func (m *BatchDeleteRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchDeleteRequest) GetRequests() []*DeleteRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+//*
+// Contains results of deleting tasks in the order of requests
+type BatchDeleteResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Result of each delete request
+	Responses            []*DeleteResponse `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BatchDeleteResponse) Reset()         { *m = BatchDeleteResponse{} }
+func (m *BatchDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteResponse) ProtoMessage()    {}
+func (*BatchDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{16}
+}
+
+func (m *BatchDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteResponse.Unmarshal(m, b)
+}
+func (m *BatchDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteResponse.Merge(m, src)
+}
+func (m *BatchDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteResponse.Size(m)
+}
+func (m *BatchDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteResponse proto.InternalMessageInfo
+
+func (m *BatchDeleteResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BatchDeleteResponse) GetResponses() []*DeleteResponse {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+//*
+// Request Data to read all tasks
+type ReadAllRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Maximum number of tasks to return in a page
+	// Server default is used if 0
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token of the page to return, as returned by a previous call
+	// Empty for the first page
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Count all tasks matching filter and return it in total_size
+	IncludeTotalSize bool `protobuf:"varint,4,opt,name=include_total_size,json=includeTotalSize,proto3" json:"include_total_size,omitempty"`
+	// Filter expression, comparisons of task fields joined with AND
+	// Fields: id, title, description, reminder, completed, completed_at, due, priority
+	// Use completed=false to exclude completed tasks, priority>=HIGH for important ones
+	// Operators: =, !=, <, <=, >, >= and : (has substring)
+	// Example: title:"report" AND reminder>="2020-01-01T00:00:00Z"
+	Filter string `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Comma separated list of fields to sort by, each optionally followed by asc or desc
+	// Example: "priority desc, due"
+	// Tasks without a date come first in ascending order
+	// Tasks are sorted by position, the custom order set by Reorder, if empty
+	OrderBy string `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Return only tasks with these tags, as selected by tag_match
+	Tags []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Whether tasks must have any or all of tags
+	TagMatch TagMatch `protobuf:"varint,8,opt,name=tag_match,json=tagMatch,proto3,enum=v1.TagMatch" json:"tag_match,omitempty"`
+	// Return tasks in trash together with the ones not deleted
+	ShowDeleted bool `protobuf:"varint,9,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	// Return only tasks of the project, tasks of all projects are returned if 0
+	ProjectId int64 `protobuf:"varint,10,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Return tasks of all callers instead of only the caller's own ones, allowed to admins only
+	AllOwners            bool     `protobuf:"varint,11,opt,name=all_owners,json=allOwners,proto3" json:"all_owners,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadAllRequest) Reset()         { *m = ReadAllRequest{} }
+func (m *ReadAllRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadAllRequest) ProtoMessage()    {}
+func (*ReadAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{17}
+}
+
+func (m *ReadAllRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadAllRequest.Unmarshal(m, b)
+}
+func (m *ReadAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadAllRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadAllRequest.Merge(m, src)
+}
+func (m *ReadAllRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadAllRequest.Size(m)
+}
+func (m *ReadAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadAllRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadAllRequest proto.InternalMessageInfo
+
+func (m *ReadAllRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReadAllRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ReadAllRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ReadAllRequest) GetIncludeTotalSize() bool {
+	if m != nil {
+		return m.IncludeTotalSize
+	}
+	return false
+}
+
+func (m *ReadAllRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *ReadAllRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ReadAllRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ReadAllRequest) GetTagMatch() TagMatch {
+	if m != nil {
+		return m.TagMatch
+	}
+	return TagMatch_TAG_MATCH_ANY
+}
+
+func (m *ReadAllRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
+func (m *ReadAllRequest) GetProjectId() int64 {
+	if m != nil {
+		return m.ProjectId
+	}
+	return 0
+}
+
+func (m *ReadAllRequest) GetAllOwners() bool {
+	if m != nil {
+		return m.AllOwners
+	}
+	return false
+}
+
+//*
+// Contains a list of all tasks
+type ReadAllResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// List of all tasks
+	ToDos []*ToDo `protobuf:"bytes,2,rep,name=toDos,proto3" json:"toDos,omitempty"`
+	// Token to pass as page_token to get the next page
+	// Empty if this is the last page
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Total number of tasks, only set if include_total_size was requested
+	TotalSize            int64    `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadAllResponse) Reset()         { *m = ReadAllResponse{} }
+func (m *ReadAllResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadAllResponse) ProtoMessage()    {}
+func (*ReadAllResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{18}
+}
+
+func (m *ReadAllResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadAllResponse.Unmarshal(m, b)
+}
+func (m *ReadAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadAllResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadAllResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadAllResponse.Merge(m, src)
+}
+func (m *ReadAllResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadAllResponse.Size(m)
+}
+func (m *ReadAllResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadAllResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadAllResponse proto.InternalMessageInfo
+
+func (m *ReadAllResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReadAllResponse) GetToDos() []*ToDo {
+	if m != nil {
+		return m.ToDos
+	}
+	return nil
+}
+
+func (m *ReadAllResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *ReadAllResponse) GetTotalSize() int64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+//*
+// Request data to list tasks in trash
+type ListDeletedRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Maximum number of tasks to return in a page
+	// Server default is used if 0
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token of the page to return, as returned by a previous call
+	// Empty for the first page
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeletedRequest) Reset()         { *m = ListDeletedRequest{} }
+func (m *ListDeletedRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeletedRequest) ProtoMessage()    {}
+func (*ListDeletedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{19}
+}
+
+func (m *ListDeletedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeletedRequest.Unmarshal(m, b)
+}
+func (m *ListDeletedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeletedRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDeletedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeletedRequest.Merge(m, src)
+}
+func (m *ListDeletedRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeletedRequest.Size(m)
+}
+func (m *ListDeletedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeletedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeletedRequest proto.InternalMessageInfo
+
+func (m *ListDeletedRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListDeletedRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDeletedRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+//*
+// Contains tasks in trash, most recently deleted first
+type ListDeletedResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// List of deleted tasks
+	ToDos []*ToDo `protobuf:"bytes,2,rep,name=toDos,proto3" json:"toDos,omitempty"`
+	// Token to pass as page_token to get the next page
+	// Empty if this is the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeletedResponse) Reset()         { *m = ListDeletedResponse{} }
+func (m *ListDeletedResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeletedResponse) ProtoMessage()    {}
+func (*ListDeletedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{20}
+}
+
+func (m *ListDeletedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeletedResponse.Unmarshal(m, b)
+}
+func (m *ListDeletedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeletedResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDeletedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeletedResponse.Merge(m, src)
+}
+func (m *ListDeletedResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeletedResponse.Size(m)
+}
+func (m *ListDeletedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeletedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeletedResponse proto.InternalMessageInfo
+
+func (m *ListDeletedResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListDeletedResponse) GetToDos() []*ToDo {
+	if m != nil {
+		return m.ToDos
+	}
+	return nil
+}
+
+func (m *ListDeletedResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+//*
+// Request data to restore a task from trash
+type RestoreRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task to restore
+	// Subtasks deleted together with the task are restored with it
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{21}
+}
+
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//*
+// Contains status of restore operation
+type RestoreResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Contains number of entities that have been restored
+	Restored             int64    `protobuf:"varint,2,opt,name=restored,proto3" json:"restored,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
+func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreResponse) ProtoMessage()    {}
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{22}
+}
+
+func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreResponse.Unmarshal(m, b)
+}
+func (m *RestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreResponse.Merge(m, src)
+}
+func (m *RestoreResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreResponse.Size(m)
+}
+func (m *RestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
+
+func (m *RestoreResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RestoreResponse) GetRestored() int64 {
+	if m != nil {
+		return m.Restored
+	}
+	return 0
+}
+
+//*
+// Request data to permanently delete tasks in trash
+type PurgeRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task to purge with its subtasks
+	// All tasks in trash are purged if 0
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Purge only tasks deleted before this time, not set to purge regardless of deletion time
+	DeletedBefore        *timestamp.Timestamp `protobuf:"bytes,3,opt,name=deleted_before,json=deletedBefore,proto3" json:"deleted_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PurgeRequest) Reset()         { *m = PurgeRequest{} }
+func (m *PurgeRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeRequest) ProtoMessage()    {}
+func (*PurgeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{23}
+}
+
+func (m *PurgeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeRequest.Unmarshal(m, b)
+}
+func (m *PurgeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeRequest.Marshal(b, m, deterministic)
+}
+func (m *PurgeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeRequest.Merge(m, src)
+}
+func (m *PurgeRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeRequest.Size(m)
+}
+func (m *PurgeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeRequest proto.InternalMessageInfo
+
+func (m *PurgeRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *PurgeRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *PurgeRequest) GetDeletedBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedBefore
+	}
+	return nil
+}
+
+//*
+// Contains status of purge operation
+type PurgeResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Contains number of entities that have been permanently deleted
+	Purged               int64    `protobuf:"varint,2,opt,name=purged,proto3" json:"purged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeResponse) Reset()         { *m = PurgeResponse{} }
+func (m *PurgeResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeResponse) ProtoMessage()    {}
+func (*PurgeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{24}
+}
+
+func (m *PurgeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeResponse.Unmarshal(m, b)
+}
+func (m *PurgeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeResponse.Marshal(b, m, deterministic)
+}
+func (m *PurgeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeResponse.Merge(m, src)
+}
+func (m *PurgeResponse) XXX_Size() int {
+	return xxx_messageInfo_PurgeResponse.Size(m)
+}
+func (m *PurgeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeResponse proto.InternalMessageInfo
+
+func (m *PurgeResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *PurgeResponse) GetPurged() int64 {
+	if m != nil {
+		return m.Purged
+	}
+	return 0
+}
+
+//*
+// Request data to mark a task as completed
+type CompleteRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task to complete
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Also complete the parent task once all its subtasks are completed,
+	// repeated up the hierarchy
+	CompleteParent       bool     `protobuf:"varint,3,opt,name=complete_parent,json=completeParent,proto3" json:"complete_parent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteRequest) Reset()         { *m = CompleteRequest{} }
+func (m *CompleteRequest) String() string { return proto.CompactTextString(m) }
+func (*CompleteRequest) ProtoMessage()    {}
+func (*CompleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{25}
+}
+
+func (m *CompleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteRequest.Unmarshal(m, b)
+}
+func (m *CompleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteRequest.Marshal(b, m, deterministic)
+}
+func (m *CompleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteRequest.Merge(m, src)
+}
+func (m *CompleteRequest) XXX_Size() int {
+	return xxx_messageInfo_CompleteRequest.Size(m)
+}
+func (m *CompleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteRequest proto.InternalMessageInfo
+
+func (m *CompleteRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CompleteRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *CompleteRequest) GetCompleteParent() bool {
+	if m != nil {
+		return m.CompleteParent
+	}
+	return false
+}
+
+//*
+// Contains the completed task
+type CompleteResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Task entity after completion
+	ToDo *ToDo `protobuf:"bytes,2,opt,name=toDo,proto3" json:"toDo,omitempty"`
+	// Next occurrence created when a recurring task is completed,
+	// not set if the task does not repeat or its recurrence has ended
+	Next                 *ToDo    `protobuf:"bytes,3,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompleteResponse) Reset()         { *m = CompleteResponse{} }
+func (m *CompleteResponse) String() string { return proto.CompactTextString(m) }
+func (*CompleteResponse) ProtoMessage()    {}
+func (*CompleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{26}
+}
+
+func (m *CompleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompleteResponse.Unmarshal(m, b)
+}
+func (m *CompleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompleteResponse.Marshal(b, m, deterministic)
+}
+func (m *CompleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompleteResponse.Merge(m, src)
+}
+func (m *CompleteResponse) XXX_Size() int {
+	return xxx_messageInfo_CompleteResponse.Size(m)
+}
+func (m *CompleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompleteResponse proto.InternalMessageInfo
+
+func (m *CompleteResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CompleteResponse) GetToDo() *ToDo {
+	if m != nil {
+		return m.ToDo
+	}
+	return nil
+}
+
+func (m *CompleteResponse) GetNext() *ToDo {
+	if m != nil {
+		return m.Next
+	}
+	return nil
+}
+
+//*
+// Request data to list occurrences of a recurring task in a time window
+type ListOccurrencesRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the recurring task
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Start of the time window, inclusive
+	StartTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End of the time window, exclusive
+	EndTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Maximum number of occurrences to return in a page
+	// Server default is used if 0
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token of the page to return, as returned by a previous call
+	// Empty for the first page
+	PageToken            string   `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOccurrencesRequest) Reset()         { *m = ListOccurrencesRequest{} }
+func (m *ListOccurrencesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOccurrencesRequest) ProtoMessage()    {}
+func (*ListOccurrencesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{27}
+}
+
+func (m *ListOccurrencesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOccurrencesRequest.Unmarshal(m, b)
+}
+func (m *ListOccurrencesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOccurrencesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOccurrencesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOccurrencesRequest.Merge(m, src)
+}
+func (m *ListOccurrencesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOccurrencesRequest.Size(m)
+}
+func (m *ListOccurrencesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOccurrencesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOccurrencesRequest proto.InternalMessageInfo
+
+func (m *ListOccurrencesRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListOccurrencesRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ListOccurrencesRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *ListOccurrencesRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *ListOccurrencesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListOccurrencesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+//*
+// Contains reminder times of the occurrences in the time window
+type ListOccurrencesResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Reminder times of occurrences in time order
+	Occurrences []*timestamp.Timestamp `protobuf:"bytes,2,rep,name=occurrences,proto3" json:"occurrences,omitempty"`
+	// Token to pass as page_token to get the next page
+	// Empty if this is the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOccurrencesResponse) Reset()         { *m = ListOccurrencesResponse{} }
+func (m *ListOccurrencesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOccurrencesResponse) ProtoMessage()    {}
+func (*ListOccurrencesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{28}
+}
+
+func (m *ListOccurrencesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOccurrencesResponse.Unmarshal(m, b)
+}
+func (m *ListOccurrencesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOccurrencesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOccurrencesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOccurrencesResponse.Merge(m, src)
+}
+func (m *ListOccurrencesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOccurrencesResponse.Size(m)
+}
+func (m *ListOccurrencesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOccurrencesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOccurrencesResponse proto.InternalMessageInfo
+
+func (m *ListOccurrencesResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListOccurrencesResponse) GetOccurrences() []*timestamp.Timestamp {
+	if m != nil {
+		return m.Occurrences
+	}
+	return nil
+}
+
+func (m *ListOccurrencesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+//*
+// Request data to mark a completed task as not done
+type ReopenRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task to reopen
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReopenRequest) Reset()         { *m = ReopenRequest{} }
+func (m *ReopenRequest) String() string { return proto.CompactTextString(m) }
+func (*ReopenRequest) ProtoMessage()    {}
+func (*ReopenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{29}
+}
+
+func (m *ReopenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReopenRequest.Unmarshal(m, b)
+}
+func (m *ReopenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReopenRequest.Marshal(b, m, deterministic)
+}
+func (m *ReopenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReopenRequest.Merge(m, src)
+}
+func (m *ReopenRequest) XXX_Size() int {
+	return xxx_messageInfo_ReopenRequest.Size(m)
+}
+func (m *ReopenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReopenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReopenRequest proto.InternalMessageInfo
+
+func (m *ReopenRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReopenRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//*
+// Contains the reopened task
+type ReopenResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Task entity after reopening
+	ToDo                 *ToDo    `protobuf:"bytes,2,opt,name=toDo,proto3" json:"toDo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReopenResponse) Reset()         { *m = ReopenResponse{} }
+func (m *ReopenResponse) String() string { return proto.CompactTextString(m) }
+func (*ReopenResponse) ProtoMessage()    {}
+func (*ReopenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{30}
+}
+
+func (m *ReopenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReopenResponse.Unmarshal(m, b)
+}
+func (m *ReopenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReopenResponse.Marshal(b, m, deterministic)
+}
+func (m *ReopenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReopenResponse.Merge(m, src)
+}
+func (m *ReopenResponse) XXX_Size() int {
+	return xxx_messageInfo_ReopenResponse.Size(m)
+}
+func (m *ReopenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReopenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReopenResponse proto.InternalMessageInfo
+
+func (m *ReopenResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReopenResponse) GetToDo() *ToDo {
+	if m != nil {
+		return m.ToDo
+	}
+	return nil
+}
+
+//*
+// Label used on tasks
+type Tag struct {
+	// Unique name of the tag
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Number of tasks with the tag
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tag) Reset()         { *m = Tag{} }
+func (m *Tag) String() string { return proto.CompactTextString(m) }
+func (*Tag) ProtoMessage()    {}
+func (*Tag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{31}
+}
+
+func (m *Tag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tag.Unmarshal(m, b)
+}
+func (m *Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tag.Marshal(b, m, deterministic)
+}
+func (m *Tag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tag.Merge(m, src)
+}
+func (m *Tag) XXX_Size() int {
+	return xxx_messageInfo_Tag.Size(m)
+}
+func (m *Tag) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tag proto.InternalMessageInfo
+
+func (m *Tag) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Tag) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+//*
+// Request data to list all tags
+type ListTagsRequest struct {
+	// API versioning, specify version explicitly
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTagsRequest) Reset()         { *m = ListTagsRequest{} }
+func (m *ListTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTagsRequest) ProtoMessage()    {}
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{32}
+}
+
+func (m *ListTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTagsRequest.Unmarshal(m, b)
+}
+func (m *ListTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsRequest.Merge(m, src)
+}
+func (m *ListTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTagsRequest.Size(m)
+}
+func (m *ListTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTagsRequest proto.InternalMessageInfo
+
+func (m *ListTagsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+//*
+// Contains all tags sorted by name
+type ListTagsResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// List of all tags
+	Tags                 []*Tag   `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTagsResponse) Reset()         { *m = ListTagsResponse{} }
+func (m *ListTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTagsResponse) ProtoMessage()    {}
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{33}
+}
+
+func (m *ListTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTagsResponse.Unmarshal(m, b)
+}
+func (m *ListTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsResponse.Merge(m, src)
+}
+func (m *ListTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTagsResponse.Size(m)
+}
+func (m *ListTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTagsResponse proto.InternalMessageInfo
+
+func (m *ListTagsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListTagsResponse) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+//*
+// Request data to rename a tag on all tasks
+type RenameTagRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Current name of the tag
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// New name of the tag, must not be used by another tag
+	NewName              string   `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameTagRequest) Reset()         { *m = RenameTagRequest{} }
+func (m *RenameTagRequest) String() string { return proto.CompactTextString(m) }
+func (*RenameTagRequest) ProtoMessage()    {}
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{34}
+}
+
+func (m *RenameTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameTagRequest.Unmarshal(m, b)
+}
+func (m *RenameTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameTagRequest.Marshal(b, m, deterministic)
+}
+func (m *RenameTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameTagRequest.Merge(m, src)
+}
+func (m *RenameTagRequest) XXX_Size() int {
+	return xxx_messageInfo_RenameTagRequest.Size(m)
+}
+func (m *RenameTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameTagRequest proto.InternalMessageInfo
+
+func (m *RenameTagRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RenameTagRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RenameTagRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+//*
+// Contains the renamed tag
+type RenameTagResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Tag after rename
+	Tag                  *Tag     `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameTagResponse) Reset()         { *m = RenameTagResponse{} }
+func (m *RenameTagResponse) String() string { return proto.CompactTextString(m) }
+func (*RenameTagResponse) ProtoMessage()    {}
+func (*RenameTagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{35}
+}
+
+func (m *RenameTagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameTagResponse.Unmarshal(m, b)
+}
+func (m *RenameTagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameTagResponse.Marshal(b, m, deterministic)
+}
+func (m *RenameTagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameTagResponse.Merge(m, src)
+}
+func (m *RenameTagResponse) XXX_Size() int {
+	return xxx_messageInfo_RenameTagResponse.Size(m)
+}
+func (m *RenameTagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameTagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameTagResponse proto.InternalMessageInfo
+
+func (m *RenameTagResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RenameTagResponse) GetTag() *Tag {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+//*
+// Request data to delete a tag from all tasks
+type DeleteTagRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Name of the tag to delete
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTagRequest) Reset()         { *m = DeleteTagRequest{} }
+func (m *DeleteTagRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteTagRequest) ProtoMessage()    {}
+func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{36}
+}
+
+func (m *DeleteTagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTagRequest.Unmarshal(m, b)
+}
+func (m *DeleteTagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTagRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteTagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTagRequest.Merge(m, src)
+}
+func (m *DeleteTagRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteTagRequest.Size(m)
+}
+func (m *DeleteTagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTagRequest proto.InternalMessageInfo
+
+func (m *DeleteTagRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteTagRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+//*
+// Contains status of delete tag operation
+type DeleteTagResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Contains number of tags that have been deleted
+	// Equals 1 if delete was successful
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTagResponse) Reset()         { *m = DeleteTagResponse{} }
+func (m *DeleteTagResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteTagResponse) ProtoMessage()    {}
+func (*DeleteTagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{37}
+}
+
+func (m *DeleteTagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTagResponse.Unmarshal(m, b)
+}
+func (m *DeleteTagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTagResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteTagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTagResponse.Merge(m, src)
+}
+func (m *DeleteTagResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteTagResponse.Size(m)
+}
+func (m *DeleteTagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTagResponse proto.InternalMessageInfo
+
+func (m *DeleteTagResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteTagResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+//*
+// Request data to add tags to a task
+type AddTagsRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Tags to add, new tags are created
+	Tags                 []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddTagsRequest) Reset()         { *m = AddTagsRequest{} }
+func (m *AddTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*AddTagsRequest) ProtoMessage()    {}
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{38}
+}
+
+func (m *AddTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddTagsRequest.Unmarshal(m, b)
+}
+func (m *AddTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *AddTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTagsRequest.Merge(m, src)
+}
+func (m *AddTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_AddTagsRequest.Size(m)
+}
+func (m *AddTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddTagsRequest proto.InternalMessageInfo
+
+func (m *AddTagsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *AddTagsRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *AddTagsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+//*
+// Contains the task with added tags
+type AddTagsResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Task entity after adding tags
+	ToDo                 *ToDo    `protobuf:"bytes,2,opt,name=toDo,proto3" json:"toDo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddTagsResponse) Reset()         { *m = AddTagsResponse{} }
+func (m *AddTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*AddTagsResponse) ProtoMessage()    {}
+func (*AddTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{39}
+}
+
+func (m *AddTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddTagsResponse.Unmarshal(m, b)
+}
+func (m *AddTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *AddTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTagsResponse.Merge(m, src)
+}
+func (m *AddTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_AddTagsResponse.Size(m)
+}
+func (m *AddTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddTagsResponse proto.InternalMessageInfo
+
+func (m *AddTagsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *AddTagsResponse) GetToDo() *ToDo {
+	if m != nil {
+		return m.ToDo
+	}
+	return nil
+}
+
+//*
+// Request data to remove tags from a task
+type RemoveTagsRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Tags to remove
+	Tags                 []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveTagsRequest) Reset()         { *m = RemoveTagsRequest{} }
+func (m *RemoveTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveTagsRequest) ProtoMessage()    {}
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{40}
+}
+
+func (m *RemoveTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveTagsRequest.Unmarshal(m, b)
+}
+func (m *RemoveTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveTagsRequest.Merge(m, src)
+}
+func (m *RemoveTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveTagsRequest.Size(m)
+}
+func (m *RemoveTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveTagsRequest proto.InternalMessageInfo
+
+func (m *RemoveTagsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RemoveTagsRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RemoveTagsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+//*
+// Contains the task with removed tags
+type RemoveTagsResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Task entity after removing tags
+	ToDo                 *ToDo    `protobuf:"bytes,2,opt,name=toDo,proto3" json:"toDo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveTagsResponse) Reset()         { *m = RemoveTagsResponse{} }
+func (m *RemoveTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveTagsResponse) ProtoMessage()    {}
+func (*RemoveTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{41}
+}
+
+func (m *RemoveTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveTagsResponse.Unmarshal(m, b)
+}
+func (m *RemoveTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveTagsResponse.Merge(m, src)
+}
+func (m *RemoveTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveTagsResponse.Size(m)
+}
+func (m *RemoveTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveTagsResponse proto.InternalMessageInfo
+
+func (m *RemoveTagsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RemoveTagsResponse) GetToDo() *ToDo {
+	if m != nil {
+		return m.ToDo
+	}
+	return nil
+}
+
+//*
+// Request data to search tasks by keywords
+type SearchRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Keywords to find in title and description of tasks
+	Q string `protobuf:"bytes,2,opt,name=q,proto3" json:"q,omitempty"`
+	// Maximum number of results to return in a page
+	// Server default is used if 0
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token of the page to return, as returned by a previous call
+	// Empty for the first page
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{42}
+}
+
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetQ() string {
+	if m != nil {
+		return m.Q
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+//*
+// Task matching a search query
+type SearchResult struct {
+	// Task entity found
+	ToDo *ToDo `protobuf:"bytes,1,opt,name=toDo,proto3" json:"toDo,omitempty"`
+	// Relevance of the task to the query, higher is better
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// HTML-escaped fragment of title with matched keywords wrapped in <em></em>
+	// Empty if title does not match
+	TitleSnippet string `protobuf:"bytes,3,opt,name=title_snippet,json=titleSnippet,proto3" json:"title_snippet,omitempty"`
+	// HTML-escaped fragment of description with matched keywords wrapped in <em></em>
+	// Empty if description does not match
+	DescriptionSnippet   string   `protobuf:"bytes,4,opt,name=description_snippet,json=descriptionSnippet,proto3" json:"description_snippet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResult) Reset()         { *m = SearchResult{} }
+func (m *SearchResult) String() string { return proto.CompactTextString(m) }
+func (*SearchResult) ProtoMessage()    {}
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{43}
+}
+
+func (m *SearchResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResult.Unmarshal(m, b)
+}
+func (m *SearchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResult.Marshal(b, m, deterministic)
+}
+func (m *SearchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResult.Merge(m, src)
+}
+func (m *SearchResult) XXX_Size() int {
+	return xxx_messageInfo_SearchResult.Size(m)
+}
+func (m *SearchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResult proto.InternalMessageInfo
+
+func (m *SearchResult) GetToDo() *ToDo {
+	if m != nil {
+		return m.ToDo
+	}
+	return nil
+}
+
+func (m *SearchResult) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchResult) GetTitleSnippet() string {
+	if m != nil {
+		return m.TitleSnippet
+	}
+	return ""
+}
+
+func (m *SearchResult) GetDescriptionSnippet() string {
+	if m != nil {
+		return m.DescriptionSnippet
+	}
+	return ""
+}
+
+//*
+// Contains tasks matching search query, most relevant first
+type SearchResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// List of matching tasks
+	Results []*SearchResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	// Token to pass as page_token to get the next page
+	// Empty if this is the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{44}
+}
+
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchResponse) GetResults() []*SearchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *SearchResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+//*
+// Request data to watch task changes
+type WatchRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// resume_token of the last event received, to get the events after it
+	// Only new events are returned if empty
+	ResumeToken          string   `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{45}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+//*
+// Contains a task change event
+type WatchResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Kind of change
+	Type EventType `protobuf:"varint,2,opt,name=type,proto3,enum=v1.EventType" json:"type,omitempty"`
+	// Changed task as it is when the event is sent, only id is set if the task has been purged
+	ToDo *ToDo `protobuf:"bytes,3,opt,name=toDo,proto3" json:"toDo,omitempty"`
+	// Time of the change
+	Time *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// Token to pass as resume_token to continue after this event
+	ResumeToken          string   `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchResponse) Reset()         { *m = WatchResponse{} }
+func (m *WatchResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchResponse) ProtoMessage()    {}
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{46}
+}
+
+func (m *WatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchResponse.Unmarshal(m, b)
+}
+func (m *WatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchResponse.Merge(m, src)
+}
+func (m *WatchResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchResponse.Size(m)
+}
+func (m *WatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchResponse proto.InternalMessageInfo
+
+func (m *WatchResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *WatchResponse) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (m *WatchResponse) GetToDo() *ToDo {
+	if m != nil {
+		return m.ToDo
+	}
+	return nil
+}
+
+func (m *WatchResponse) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *WatchResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+//*
+// Recorded change of a task
+type TaskHistoryEntry struct {
+	// Unique identifier of the entry
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unique identifier of the changed task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Kind of change
+	Action HistoryAction `protobuf:"varint,3,opt,name=action,proto3,enum=v1.HistoryAction" json:"action,omitempty"`
+	// Who made the change, empty if unknown
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Task before the change, not set for HISTORY_ACTION_CREATE
+	Before *ToDo `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	// Task after the change
+	After *ToDo `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	// Time of the change
+	Time                 *timestamp.Timestamp `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TaskHistoryEntry) Reset()         { *m = TaskHistoryEntry{} }
+func (m *TaskHistoryEntry) String() string { return proto.CompactTextString(m) }
+func (*TaskHistoryEntry) ProtoMessage()    {}
+func (*TaskHistoryEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{47}
+}
+
+func (m *TaskHistoryEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskHistoryEntry.Unmarshal(m, b)
+}
+func (m *TaskHistoryEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskHistoryEntry.Marshal(b, m, deterministic)
+}
+func (m *TaskHistoryEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskHistoryEntry.Merge(m, src)
+}
+func (m *TaskHistoryEntry) XXX_Size() int {
+	return xxx_messageInfo_TaskHistoryEntry.Size(m)
+}
+func (m *TaskHistoryEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskHistoryEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskHistoryEntry proto.InternalMessageInfo
+
+func (m *TaskHistoryEntry) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *TaskHistoryEntry) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *TaskHistoryEntry) GetAction() HistoryAction {
+	if m != nil {
+		return m.Action
+	}
+	return HistoryAction_HISTORY_ACTION_UNSPECIFIED
+}
+
+func (m *TaskHistoryEntry) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *TaskHistoryEntry) GetBefore() *ToDo {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *TaskHistoryEntry) GetAfter() *ToDo {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *TaskHistoryEntry) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+//*
+// Request data to list the history of a task
+type ListTaskHistoryRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Maximum number of entries to return in a page
+	// Server default is used if 0
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token of the page to return, as returned by a previous call
+	// Empty for the first page
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTaskHistoryRequest) Reset()         { *m = ListTaskHistoryRequest{} }
+func (m *ListTaskHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTaskHistoryRequest) ProtoMessage()    {}
+func (*ListTaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{48}
+}
+
+func (m *ListTaskHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTaskHistoryRequest.Unmarshal(m, b)
+}
+func (m *ListTaskHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTaskHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTaskHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTaskHistoryRequest.Merge(m, src)
+}
+func (m *ListTaskHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTaskHistoryRequest.Size(m)
+}
+func (m *ListTaskHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTaskHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTaskHistoryRequest proto.InternalMessageInfo
+
+func (m *ListTaskHistoryRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListTaskHistoryRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ListTaskHistoryRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListTaskHistoryRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+//*
+// Contains history of a task, latest change first
+type ListTaskHistoryResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// History entries
+	Entries []*TaskHistoryEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Token to pass as page_token to get the next page
+	// Empty if this is the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTaskHistoryResponse) Reset()         { *m = ListTaskHistoryResponse{} }
+func (m *ListTaskHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTaskHistoryResponse) ProtoMessage()    {}
+func (*ListTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{49}
+}
+
+func (m *ListTaskHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTaskHistoryResponse.Unmarshal(m, b)
+}
+func (m *ListTaskHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTaskHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTaskHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTaskHistoryResponse.Merge(m, src)
+}
+func (m *ListTaskHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTaskHistoryResponse.Size(m)
+}
+func (m *ListTaskHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTaskHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTaskHistoryResponse proto.InternalMessageInfo
+
+func (m *ListTaskHistoryResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListTaskHistoryResponse) GetEntries() []*TaskHistoryEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *ListTaskHistoryResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+//*
+// Comment on a task
+type Comment struct {
+	// Unique identifier of the comment
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unique identifier of the commented task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Who wrote the comment, set by server
+	Author string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	// Text of the comment
+	Body string `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	// Time the comment was created
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Time the comment was last edited, not set if it has not been edited
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Comment) Reset()         { *m = Comment{} }
+func (m *Comment) String() string { return proto.CompactTextString(m) }
+func (*Comment) ProtoMessage()    {}
+func (*Comment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{50}
+}
+
+func (m *Comment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Comment.Unmarshal(m, b)
+}
+func (m *Comment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Comment.Marshal(b, m, deterministic)
+}
+func (m *Comment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Comment.Merge(m, src)
+}
+func (m *Comment) XXX_Size() int {
+	return xxx_messageInfo_Comment.Size(m)
+}
+func (m *Comment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Comment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Comment proto.InternalMessageInfo
+
+func (m *Comment) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Comment) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *Comment) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Comment) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Comment) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Comment) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+//*
+// Request data to comment on a task
+type CreateCommentRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Comment to create, only body is used
+	Comment              *Comment `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateCommentRequest) Reset()         { *m = CreateCommentRequest{} }
+func (m *CreateCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateCommentRequest) ProtoMessage()    {}
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{51}
+}
+
+func (m *CreateCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCommentRequest.Unmarshal(m, b)
+}
+func (m *CreateCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCommentRequest.Merge(m, src)
+}
+func (m *CreateCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateCommentRequest.Size(m)
+}
+func (m *CreateCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCommentRequest proto.InternalMessageInfo
+
+func (m *CreateCommentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateCommentRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *CreateCommentRequest) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+//*
+// Contains created comment
+type CreateCommentResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Created comment
+	Comment              *Comment `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateCommentResponse) Reset()         { *m = CreateCommentResponse{} }
+func (m *CreateCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateCommentResponse) ProtoMessage()    {}
+func (*CreateCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{52}
+}
+
+func (m *CreateCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCommentResponse.Unmarshal(m, b)
+}
+func (m *CreateCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCommentResponse.Merge(m, src)
+}
+func (m *CreateCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateCommentResponse.Size(m)
+}
+func (m *CreateCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCommentResponse proto.InternalMessageInfo
+
+func (m *CreateCommentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateCommentResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+//*
+// Request data to list comments on a task
+type ListCommentsRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Maximum number of comments to return in a page
+	// Server default is used if 0
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token of the page to return, as returned by a previous call
+	// Empty for the first page
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCommentsRequest) Reset()         { *m = ListCommentsRequest{} }
+func (m *ListCommentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCommentsRequest) ProtoMessage()    {}
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{53}
+}
+
+func (m *ListCommentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommentsRequest.Unmarshal(m, b)
+}
+func (m *ListCommentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCommentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommentsRequest.Merge(m, src)
+}
+func (m *ListCommentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCommentsRequest.Size(m)
+}
+func (m *ListCommentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommentsRequest proto.InternalMessageInfo
+
+func (m *ListCommentsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListCommentsRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *ListCommentsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListCommentsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+//*
+// Contains comments on a task, oldest first
+type ListCommentsResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Comments
+	Comments []*Comment `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
+	// Token to pass as page_token to get the next page
+	// Empty if this is the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCommentsResponse) Reset()         { *m = ListCommentsResponse{} }
+func (m *ListCommentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCommentsResponse) ProtoMessage()    {}
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{54}
+}
+
+func (m *ListCommentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCommentsResponse.Unmarshal(m, b)
+}
+func (m *ListCommentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCommentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCommentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommentsResponse.Merge(m, src)
+}
+func (m *ListCommentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCommentsResponse.Size(m)
+}
+func (m *ListCommentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCommentsResponse proto.InternalMessageInfo
+
+func (m *ListCommentsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListCommentsResponse) GetComments() []*Comment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *ListCommentsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+//*
+// Request data to edit a comment
+type UpdateCommentRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Unique identifier of the comment
+	Id int64 `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	// New content of the comment, only body is used
+	Comment              *Comment `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateCommentRequest) Reset()         { *m = UpdateCommentRequest{} }
+func (m *UpdateCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateCommentRequest) ProtoMessage()    {}
+func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{55}
+}
+
+func (m *UpdateCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCommentRequest.Unmarshal(m, b)
+}
+func (m *UpdateCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCommentRequest.Merge(m, src)
+}
+func (m *UpdateCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateCommentRequest.Size(m)
+}
+func (m *UpdateCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCommentRequest proto.InternalMessageInfo
+
+func (m *UpdateCommentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateCommentRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *UpdateCommentRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *UpdateCommentRequest) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+//*
+// Contains edited comment
+type UpdateCommentResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Edited comment
+	Comment              *Comment `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateCommentResponse) Reset()         { *m = UpdateCommentResponse{} }
+func (m *UpdateCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateCommentResponse) ProtoMessage()    {}
+func (*UpdateCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{56}
+}
+
+func (m *UpdateCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCommentResponse.Unmarshal(m, b)
+}
+func (m *UpdateCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCommentResponse.Merge(m, src)
+}
+func (m *UpdateCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateCommentResponse.Size(m)
+}
+func (m *UpdateCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCommentResponse proto.InternalMessageInfo
+
+func (m *UpdateCommentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateCommentResponse) GetComment() *Comment {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+//*
+// Request data to delete a comment
+type DeleteCommentRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Unique identifier of the comment
+	Id                   int64    `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentRequest) Reset()         { *m = DeleteCommentRequest{} }
+func (m *DeleteCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentRequest) ProtoMessage()    {}
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{57}
+}
+
+func (m *DeleteCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentRequest.Unmarshal(m, b)
+}
+func (m *DeleteCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentRequest.Merge(m, src)
+}
+func (m *DeleteCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentRequest.Size(m)
+}
+func (m *DeleteCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentRequest proto.InternalMessageInfo
+
+func (m *DeleteCommentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteCommentRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *DeleteCommentRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//*
+// Contains status of delete operation
+type DeleteCommentResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Contains number of entities have beed deleted
+	// Equals 1 in case of succesfull delete
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCommentResponse) Reset()         { *m = DeleteCommentResponse{} }
+func (m *DeleteCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommentResponse) ProtoMessage()    {}
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{58}
+}
+
+func (m *DeleteCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCommentResponse.Unmarshal(m, b)
+}
+func (m *DeleteCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommentResponse.Merge(m, src)
+}
+func (m *DeleteCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteCommentResponse.Size(m)
+}
+func (m *DeleteCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCommentResponse proto.InternalMessageInfo
+
+func (m *DeleteCommentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteCommentResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+//*
+// File attached to a task
+type Attachment struct {
+	// Unique identifier of the attachment
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Name of the uploaded file
+	FileName string `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// MIME type of the content
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Size of the content in bytes
+	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// Time the attachment was uploaded
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Attachment) Reset()         { *m = Attachment{} }
+func (m *Attachment) String() string { return proto.CompactTextString(m) }
+func (*Attachment) ProtoMessage()    {}
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{59}
+}
+
+func (m *Attachment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attachment.Unmarshal(m, b)
+}
+func (m *Attachment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attachment.Marshal(b, m, deterministic)
+}
+func (m *Attachment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attachment.Merge(m, src)
+}
+func (m *Attachment) XXX_Size() int {
+	return xxx_messageInfo_Attachment.Size(m)
+}
+func (m *Attachment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attachment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attachment proto.InternalMessageInfo
+
+func (m *Attachment) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Attachment) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *Attachment) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *Attachment) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *Attachment) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Attachment) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+//*
+// Request data to attach a file to a task
+type UploadAttachmentRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Name of the file
+	FileName string `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// Content of the file with its MIME type
+	Body                 *httpbody.HttpBody `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UploadAttachmentRequest) Reset()         { *m = UploadAttachmentRequest{} }
+func (m *UploadAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadAttachmentRequest) ProtoMessage()    {}
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{60}
+}
+
+func (m *UploadAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadAttachmentRequest.Unmarshal(m, b)
+}
+func (m *UploadAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadAttachmentRequest.Merge(m, src)
+}
+func (m *UploadAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadAttachmentRequest.Size(m)
+}
+func (m *UploadAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadAttachmentRequest proto.InternalMessageInfo
+
+func (m *UploadAttachmentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UploadAttachmentRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *UploadAttachmentRequest) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *UploadAttachmentRequest) GetBody() *httpbody.HttpBody {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+//*
+// Contains uploaded attachment
+type UploadAttachmentResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Uploaded attachment
+	Attachment           *Attachment `protobuf:"bytes,2,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *UploadAttachmentResponse) Reset()         { *m = UploadAttachmentResponse{} }
+func (m *UploadAttachmentResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadAttachmentResponse) ProtoMessage()    {}
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{61}
+}
+
+func (m *UploadAttachmentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadAttachmentResponse.Unmarshal(m, b)
+}
+func (m *UploadAttachmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadAttachmentResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadAttachmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadAttachmentResponse.Merge(m, src)
+}
+func (m *UploadAttachmentResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadAttachmentResponse.Size(m)
+}
+func (m *UploadAttachmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadAttachmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadAttachmentResponse proto.InternalMessageInfo
+
+func (m *UploadAttachmentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UploadAttachmentResponse) GetAttachment() *Attachment {
+	if m != nil {
+		return m.Attachment
+	}
+	return nil
+}
+
+//*
+// Request data to list attachments of a task
+type ListAttachmentsRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId               int64    `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAttachmentsRequest) Reset()         { *m = ListAttachmentsRequest{} }
+func (m *ListAttachmentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAttachmentsRequest) ProtoMessage()    {}
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{62}
+}
+
+func (m *ListAttachmentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAttachmentsRequest.Unmarshal(m, b)
+}
+func (m *ListAttachmentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAttachmentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAttachmentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttachmentsRequest.Merge(m, src)
+}
+func (m *ListAttachmentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAttachmentsRequest.Size(m)
+}
+func (m *ListAttachmentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttachmentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttachmentsRequest proto.InternalMessageInfo
+
+func (m *ListAttachmentsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListAttachmentsRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+//*
+// Contains attachments of a task, oldest first
+type ListAttachmentsResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Attachments
+	Attachments          []*Attachment `protobuf:"bytes,2,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListAttachmentsResponse) Reset()         { *m = ListAttachmentsResponse{} }
+func (m *ListAttachmentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAttachmentsResponse) ProtoMessage()    {}
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{63}
+}
+
+func (m *ListAttachmentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAttachmentsResponse.Unmarshal(m, b)
+}
+func (m *ListAttachmentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAttachmentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAttachmentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttachmentsResponse.Merge(m, src)
+}
+func (m *ListAttachmentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAttachmentsResponse.Size(m)
+}
+func (m *ListAttachmentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttachmentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttachmentsResponse proto.InternalMessageInfo
+
+func (m *ListAttachmentsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if m != nil {
+		return m.Attachments
+	}
+	return nil
+}
+
+//*
+// Request data to download an attachment
+type DownloadAttachmentRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Unique identifier of the attachment
+	Id                   int64    `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownloadAttachmentRequest) Reset()         { *m = DownloadAttachmentRequest{} }
+func (m *DownloadAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadAttachmentRequest) ProtoMessage()    {}
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{64}
+}
+
+func (m *DownloadAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadAttachmentRequest.Unmarshal(m, b)
+}
+func (m *DownloadAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (m *DownloadAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadAttachmentRequest.Merge(m, src)
+}
+func (m *DownloadAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_DownloadAttachmentRequest.Size(m)
+}
+func (m *DownloadAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadAttachmentRequest proto.InternalMessageInfo
+
+func (m *DownloadAttachmentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DownloadAttachmentRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *DownloadAttachmentRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//*
+// Request data to delete an attachment
+type DeleteAttachmentRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Unique identifier of the attachment
+	Id                   int64    `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAttachmentRequest) Reset()         { *m = DeleteAttachmentRequest{} }
+func (m *DeleteAttachmentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteAttachmentRequest) ProtoMessage()    {}
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{65}
+}
+
+func (m *DeleteAttachmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAttachmentRequest.Unmarshal(m, b)
+}
+func (m *DeleteAttachmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAttachmentRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteAttachmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAttachmentRequest.Merge(m, src)
+}
+func (m *DeleteAttachmentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteAttachmentRequest.Size(m)
+}
+func (m *DeleteAttachmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAttachmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAttachmentRequest proto.InternalMessageInfo
+
+func (m *DeleteAttachmentRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteAttachmentRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *DeleteAttachmentRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//*
+// Contains status of delete operation
+type DeleteAttachmentResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Contains number of entities have beed deleted
+	// Equals 1 in case of succesfull delete
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAttachmentResponse) Reset()         { *m = DeleteAttachmentResponse{} }
+func (m *DeleteAttachmentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteAttachmentResponse) ProtoMessage()    {}
+func (*DeleteAttachmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{66}
+}
+
+func (m *DeleteAttachmentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAttachmentResponse.Unmarshal(m, b)
+}
+func (m *DeleteAttachmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAttachmentResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteAttachmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAttachmentResponse.Merge(m, src)
+}
+func (m *DeleteAttachmentResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteAttachmentResponse.Size(m)
+}
+func (m *DeleteAttachmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAttachmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAttachmentResponse proto.InternalMessageInfo
+
+func (m *DeleteAttachmentResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteAttachmentResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+//*
+// Project is a list grouping tasks
+type Project struct {
+	// Unique identifier of the project
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the project
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Detailed description of the project
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Time the project was archived by DeleteProject, not set for an active project
+	ArchivedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	// Time the project was created
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Subject of the caller who created the project, set by server.
+	// Projects are reached by their owner only, empty if the project was created without authentication
+	OwnerId              string   `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Project) Reset()         { *m = Project{} }
+func (m *Project) String() string { return proto.CompactTextString(m) }
+func (*Project) ProtoMessage()    {}
+func (*Project) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{67}
+}
+
+func (m *Project) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Project.Unmarshal(m, b)
+}
+func (m *Project) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Project.Marshal(b, m, deterministic)
+}
+func (m *Project) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Project.Merge(m, src)
+}
+func (m *Project) XXX_Size() int {
+	return xxx_messageInfo_Project.Size(m)
+}
+func (m *Project) XXX_DiscardUnknown() {
+	xxx_messageInfo_Project.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Project proto.InternalMessageInfo
+
+func (m *Project) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Project) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Project) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Project) GetArchivedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ArchivedAt
+	}
+	return nil
+}
+
+func (m *Project) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Project) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+//*
+// Request data to create a project
+type CreateProjectRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Project to create, name and description are used
+	Project              *Project `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateProjectRequest) Reset()         { *m = CreateProjectRequest{} }
+func (m *CreateProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateProjectRequest) ProtoMessage()    {}
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{68}
+}
+
+func (m *CreateProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateProjectRequest.Unmarshal(m, b)
+}
+func (m *CreateProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateProjectRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateProjectRequest.Merge(m, src)
+}
+func (m *CreateProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateProjectRequest.Size(m)
+}
+func (m *CreateProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateProjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateProjectRequest proto.InternalMessageInfo
+
+func (m *CreateProjectRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateProjectRequest) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+//*
+// Contains created project
+type CreateProjectResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Created project
+	Project              *Project `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateProjectResponse) Reset()         { *m = CreateProjectResponse{} }
+func (m *CreateProjectResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateProjectResponse) ProtoMessage()    {}
+func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{69}
+}
+
+func (m *CreateProjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateProjectResponse.Unmarshal(m, b)
+}
+func (m *CreateProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateProjectResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateProjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateProjectResponse.Merge(m, src)
+}
+func (m *CreateProjectResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateProjectResponse.Size(m)
+}
+func (m *CreateProjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateProjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateProjectResponse proto.InternalMessageInfo
+
+func (m *CreateProjectResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateProjectResponse) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+//*
+// Request data to read a project
+type ReadProjectRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the project
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadProjectRequest) Reset()         { *m = ReadProjectRequest{} }
+func (m *ReadProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadProjectRequest) ProtoMessage()    {}
+func (*ReadProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{70}
+}
+
+func (m *ReadProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadProjectRequest.Unmarshal(m, b)
+}
+func (m *ReadProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadProjectRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadProjectRequest.Merge(m, src)
+}
+func (m *ReadProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadProjectRequest.Size(m)
+}
+func (m *ReadProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadProjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadProjectRequest proto.InternalMessageInfo
+
+func (m *ReadProjectRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReadProjectRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//*
+// Contains project data specified in by ID request
+type ReadProjectResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Project entity read by ID
+	Project              *Project `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadProjectResponse) Reset()         { *m = ReadProjectResponse{} }
+func (m *ReadProjectResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadProjectResponse) ProtoMessage()    {}
+func (*ReadProjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{71}
+}
+
+func (m *ReadProjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadProjectResponse.Unmarshal(m, b)
+}
+func (m *ReadProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadProjectResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadProjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadProjectResponse.Merge(m, src)
+}
+func (m *ReadProjectResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadProjectResponse.Size(m)
+}
+func (m *ReadProjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadProjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadProjectResponse proto.InternalMessageInfo
+
+func (m *ReadProjectResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReadProjectResponse) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+//*
+// Request data to list projects
+type ListProjectsRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Maximum number of projects to return in a page
+	// Server default is used if 0
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token of the page to return, as returned by a previous call
+	// Empty for the first page
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Return archived projects together with the active ones
+	ShowArchived         bool     `protobuf:"varint,4,opt,name=show_archived,json=showArchived,proto3" json:"show_archived,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListProjectsRequest) Reset()         { *m = ListProjectsRequest{} }
+func (m *ListProjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListProjectsRequest) ProtoMessage()    {}
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{72}
+}
+
+func (m *ListProjectsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListProjectsRequest.Unmarshal(m, b)
+}
+func (m *ListProjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListProjectsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListProjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListProjectsRequest.Merge(m, src)
+}
+func (m *ListProjectsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListProjectsRequest.Size(m)
+}
+func (m *ListProjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListProjectsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListProjectsRequest proto.InternalMessageInfo
+
+func (m *ListProjectsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListProjectsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListProjectsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListProjectsRequest) GetShowArchived() bool {
+	if m != nil {
+		return m.ShowArchived
+	}
+	return false
+}
+
+//*
+// Contains projects, oldest first
+type ListProjectsResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Projects
+	Projects []*Project `protobuf:"bytes,2,rep,name=projects,proto3" json:"projects,omitempty"`
+	// Token to pass as page_token to get the next page
+	// Empty if this is the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListProjectsResponse) Reset()         { *m = ListProjectsResponse{} }
+func (m *ListProjectsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListProjectsResponse) ProtoMessage()    {}
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{73}
+}
+
+func (m *ListProjectsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListProjectsResponse.Unmarshal(m, b)
+}
+func (m *ListProjectsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListProjectsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListProjectsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListProjectsResponse.Merge(m, src)
+}
+func (m *ListProjectsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListProjectsResponse.Size(m)
+}
+func (m *ListProjectsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListProjectsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListProjectsResponse proto.InternalMessageInfo
+
+func (m *ListProjectsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListProjectsResponse) GetProjects() []*Project {
+	if m != nil {
+		return m.Projects
+	}
+	return nil
+}
+
+func (m *ListProjectsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+//*
+// Request data to update a project
+type UpdateProjectRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Project to update
+	Project *Project `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	// Fields of project to update: name, description
+	// All fields are replaced if empty or "*"
+	// Filled from the JSON body keys by the PATCH HTTP binding
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateProjectRequest) Reset()         { *m = UpdateProjectRequest{} }
+func (m *UpdateProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateProjectRequest) ProtoMessage()    {}
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{74}
+}
+
+func (m *UpdateProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProjectRequest.Unmarshal(m, b)
+}
+func (m *UpdateProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProjectRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProjectRequest.Merge(m, src)
+}
+func (m *UpdateProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateProjectRequest.Size(m)
+}
+func (m *UpdateProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateProjectRequest proto.InternalMessageInfo
+
+func (m *UpdateProjectRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateProjectRequest) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *UpdateProjectRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+//*
+// Contains updated project
+type UpdateProjectResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Updated project
+	Project              *Project `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateProjectResponse) Reset()         { *m = UpdateProjectResponse{} }
+func (m *UpdateProjectResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateProjectResponse) ProtoMessage()    {}
+func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{75}
+}
+
+func (m *UpdateProjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProjectResponse.Unmarshal(m, b)
+}
+func (m *UpdateProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProjectResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateProjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProjectResponse.Merge(m, src)
+}
+func (m *UpdateProjectResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateProjectResponse.Size(m)
+}
+func (m *UpdateProjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateProjectResponse proto.InternalMessageInfo
+
+func (m *UpdateProjectResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UpdateProjectResponse) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+//*
+// Request data to delete a project
+type DeleteProjectRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the project
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Whether the project is archived or deleted with its tasks
+	Mode                 ProjectDeleteMode `protobuf:"varint,3,opt,name=mode,proto3,enum=v1.ProjectDeleteMode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DeleteProjectRequest) Reset()         { *m = DeleteProjectRequest{} }
+func (m *DeleteProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteProjectRequest) ProtoMessage()    {}
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{76}
+}
+
+func (m *DeleteProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteProjectRequest.Unmarshal(m, b)
+}
+func (m *DeleteProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteProjectRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteProjectRequest.Merge(m, src)
+}
+func (m *DeleteProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteProjectRequest.Size(m)
+}
+func (m *DeleteProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteProjectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteProjectRequest proto.InternalMessageInfo
+
+func (m *DeleteProjectRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteProjectRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeleteProjectRequest) GetMode() ProjectDeleteMode {
+	if m != nil {
+		return m.Mode
+	}
+	return ProjectDeleteMode_PROJECT_DELETE_MODE_ARCHIVE
+}
+
+//*
+// Contains status of delete operation
+type DeleteProjectResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Contains number of projects archived or deleted
+	// Equals 1 in case of succesfull delete
+	Deleted int64 `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// Number of tasks moved to trash with the project
+	DeletedToDos         int64    `protobuf:"varint,3,opt,name=deleted_to_dos,json=deletedToDos,proto3" json:"deleted_to_dos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteProjectResponse) Reset()         { *m = DeleteProjectResponse{} }
+func (m *DeleteProjectResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteProjectResponse) ProtoMessage()    {}
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{77}
+}
+
+func (m *DeleteProjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteProjectResponse.Unmarshal(m, b)
+}
+func (m *DeleteProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteProjectResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteProjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteProjectResponse.Merge(m, src)
+}
+func (m *DeleteProjectResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteProjectResponse.Size(m)
+}
+func (m *DeleteProjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteProjectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteProjectResponse proto.InternalMessageInfo
+
+func (m *DeleteProjectResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteProjectResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+func (m *DeleteProjectResponse) GetDeletedToDos() int64 {
+	if m != nil {
+		return m.DeletedToDos
+	}
+	return 0
+}
+
+//*
+// Request data to move a task to another project
+type MoveTaskRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the top level task to move, its subtasks are moved with it
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Unique identifier of the project to move the task to, 0 to move it out of projects
+	ProjectId int64 `protobuf:"varint,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Etag of the task the move is based on, the move is aborted if the task has changed since
+	// The If-Match HTTP header is used if empty, the task is not checked if both are empty
+	Etag                 string   `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveTaskRequest) Reset()         { *m = MoveTaskRequest{} }
+func (m *MoveTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveTaskRequest) ProtoMessage()    {}
+func (*MoveTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{78}
+}
+
+func (m *MoveTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveTaskRequest.Unmarshal(m, b)
+}
+func (m *MoveTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveTaskRequest.Merge(m, src)
+}
+func (m *MoveTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveTaskRequest.Size(m)
+}
+func (m *MoveTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveTaskRequest proto.InternalMessageInfo
+
+func (m *MoveTaskRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *MoveTaskRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *MoveTaskRequest) GetProjectId() int64 {
+	if m != nil {
+		return m.ProjectId
+	}
+	return 0
+}
+
+func (m *MoveTaskRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+//*
+// Contains status of move operation
+type MoveTaskResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Number of tasks moved, the task and its subtasks
+	Moved int64 `protobuf:"varint,2,opt,name=moved,proto3" json:"moved,omitempty"`
+	// New etag of the task
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveTaskResponse) Reset()         { *m = MoveTaskResponse{} }
+func (m *MoveTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*MoveTaskResponse) ProtoMessage()    {}
+func (*MoveTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{79}
+}
+
+func (m *MoveTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveTaskResponse.Unmarshal(m, b)
+}
+func (m *MoveTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *MoveTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveTaskResponse.Merge(m, src)
+}
+func (m *MoveTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_MoveTaskResponse.Size(m)
+}
+func (m *MoveTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveTaskResponse proto.InternalMessageInfo
+
+func (m *MoveTaskResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *MoveTaskResponse) GetMoved() int64 {
+	if m != nil {
+		return m.Moved
+	}
+	return 0
+}
+
+func (m *MoveTaskResponse) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+//*
+// Request data to move a task in the custom order
+type ReorderRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task to move
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Unique identifier of the task to place the task right before
+	// Exactly one of before_id and after_id must be set
+	BeforeId int64 `protobuf:"varint,3,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"`
+	// Unique identifier of the task to place the task right after
+	AfterId int64 `protobuf:"varint,4,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	// Etag of the task the move is based on, the move is aborted if the task has changed since
+	// The If-Match HTTP header is used if empty, the task is not checked if both are empty
+	Etag                 string   `protobuf:"bytes,5,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReorderRequest) Reset()         { *m = ReorderRequest{} }
+func (m *ReorderRequest) String() string { return proto.CompactTextString(m) }
+func (*ReorderRequest) ProtoMessage()    {}
+func (*ReorderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{80}
+}
+
+func (m *ReorderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReorderRequest.Unmarshal(m, b)
+}
+func (m *ReorderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReorderRequest.Marshal(b, m, deterministic)
+}
+func (m *ReorderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReorderRequest.Merge(m, src)
+}
+func (m *ReorderRequest) XXX_Size() int {
+	return xxx_messageInfo_ReorderRequest.Size(m)
+}
+func (m *ReorderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReorderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReorderRequest proto.InternalMessageInfo
+
+func (m *ReorderRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReorderRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ReorderRequest) GetBeforeId() int64 {
+	if m != nil {
+		return m.BeforeId
+	}
+	return 0
+}
+
+func (m *ReorderRequest) GetAfterId() int64 {
+	if m != nil {
+		return m.AfterId
+	}
+	return 0
+}
+
+func (m *ReorderRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+//*
+// Contains the new position of the task
+type ReorderResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// New position of the task
+	Position string `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	// New etag of the task
+	Etag                 string   `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReorderResponse) Reset()         { *m = ReorderResponse{} }
+func (m *ReorderResponse) String() string { return proto.CompactTextString(m) }
+func (*ReorderResponse) ProtoMessage()    {}
+func (*ReorderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{81}
+}
+
+func (m *ReorderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReorderResponse.Unmarshal(m, b)
+}
+func (m *ReorderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReorderResponse.Marshal(b, m, deterministic)
+}
+func (m *ReorderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReorderResponse.Merge(m, src)
+}
+func (m *ReorderResponse) XXX_Size() int {
+	return xxx_messageInfo_ReorderResponse.Size(m)
+}
+func (m *ReorderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReorderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReorderResponse proto.InternalMessageInfo
+
+func (m *ReorderResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ReorderResponse) GetPosition() string {
+	if m != nil {
+		return m.Position
+	}
+	return ""
+}
+
+func (m *ReorderResponse) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+//*
+// User a task is shared with
+type Collaborator struct {
+	// Subject of the user, as authenticated by the server
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Access granted to the user, viewer or editor
+	Access AccessLevel `protobuf:"varint,2,opt,name=access,proto3,enum=v1.AccessLevel" json:"access,omitempty"`
+	// Time the task was shared with the user, set by server
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Collaborator) Reset()         { *m = Collaborator{} }
+func (m *Collaborator) String() string { return proto.CompactTextString(m) }
+func (*Collaborator) ProtoMessage()    {}
+func (*Collaborator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{82}
+}
+
+func (m *Collaborator) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Collaborator.Unmarshal(m, b)
+}
+func (m *Collaborator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Collaborator.Marshal(b, m, deterministic)
+}
+func (m *Collaborator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Collaborator.Merge(m, src)
+}
+func (m *Collaborator) XXX_Size() int {
+	return xxx_messageInfo_Collaborator.Size(m)
+}
+func (m *Collaborator) XXX_DiscardUnknown() {
+	xxx_messageInfo_Collaborator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Collaborator proto.InternalMessageInfo
+
+func (m *Collaborator) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *Collaborator) GetAccess() AccessLevel {
+	if m != nil {
+		return m.Access
+	}
+	return AccessLevel_ACCESS_LEVEL_UNSPECIFIED
+}
+
+func (m *Collaborator) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+//*
+// Request data to share a task, allowed to its owner only
+type ShareTaskRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Subject of the user to share the task with
+	Subject string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Access to grant, viewer or editor
+	// Access of a user the task is already shared with is replaced
+	Access               AccessLevel `protobuf:"varint,4,opt,name=access,proto3,enum=v1.AccessLevel" json:"access,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ShareTaskRequest) Reset()         { *m = ShareTaskRequest{} }
+func (m *ShareTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*ShareTaskRequest) ProtoMessage()    {}
+func (*ShareTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{83}
+}
+
+func (m *ShareTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShareTaskRequest.Unmarshal(m, b)
+}
+func (m *ShareTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShareTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *ShareTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShareTaskRequest.Merge(m, src)
+}
+func (m *ShareTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_ShareTaskRequest.Size(m)
+}
+func (m *ShareTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShareTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShareTaskRequest proto.InternalMessageInfo
+
+func (m *ShareTaskRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ShareTaskRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *ShareTaskRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *ShareTaskRequest) GetAccess() AccessLevel {
+	if m != nil {
+		return m.Access
+	}
+	return AccessLevel_ACCESS_LEVEL_UNSPECIFIED
+}
+
+//*
+// Contains the collaborator
+type ShareTaskResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Collaborator the task is shared with
+	Collaborator         *Collaborator `protobuf:"bytes,2,opt,name=collaborator,proto3" json:"collaborator,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ShareTaskResponse) Reset()         { *m = ShareTaskResponse{} }
+func (m *ShareTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*ShareTaskResponse) ProtoMessage()    {}
+func (*ShareTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{84}
+}
+
+func (m *ShareTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShareTaskResponse.Unmarshal(m, b)
+}
+func (m *ShareTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShareTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *ShareTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShareTaskResponse.Merge(m, src)
+}
+func (m *ShareTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_ShareTaskResponse.Size(m)
+}
+func (m *ShareTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShareTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShareTaskResponse proto.InternalMessageInfo
+
+func (m *ShareTaskResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ShareTaskResponse) GetCollaborator() *Collaborator {
+	if m != nil {
+		return m.Collaborator
+	}
+	return nil
+}
+
+//*
+// Request data to stop sharing a task, allowed to its owner only
+type UnshareTaskRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId int64 `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// Subject of the user to stop sharing the task with
+	Subject              string   `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnshareTaskRequest) Reset()         { *m = UnshareTaskRequest{} }
+func (m *UnshareTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*UnshareTaskRequest) ProtoMessage()    {}
+func (*UnshareTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{85}
+}
+
+func (m *UnshareTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnshareTaskRequest.Unmarshal(m, b)
+}
+func (m *UnshareTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnshareTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *UnshareTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnshareTaskRequest.Merge(m, src)
+}
+func (m *UnshareTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_UnshareTaskRequest.Size(m)
+}
+func (m *UnshareTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnshareTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnshareTaskRequest proto.InternalMessageInfo
+
+func (m *UnshareTaskRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UnshareTaskRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *UnshareTaskRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+//*
+// Contains status of unshare operation
+type UnshareTaskResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Number of collaborators removed, 1 in case of success
+	Removed              int64    `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnshareTaskResponse) Reset()         { *m = UnshareTaskResponse{} }
+func (m *UnshareTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*UnshareTaskResponse) ProtoMessage()    {}
+func (*UnshareTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{86}
+}
+
+func (m *UnshareTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnshareTaskResponse.Unmarshal(m, b)
+}
+func (m *UnshareTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnshareTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *UnshareTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnshareTaskResponse.Merge(m, src)
+}
+func (m *UnshareTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_UnshareTaskResponse.Size(m)
+}
+func (m *UnshareTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnshareTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnshareTaskResponse proto.InternalMessageInfo
+
+func (m *UnshareTaskResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *UnshareTaskResponse) GetRemoved() int64 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+//*
+// Request data to list users a task is shared with
+type ListCollaboratorsRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the task
+	ToDoId               int64    `protobuf:"varint,2,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCollaboratorsRequest) Reset()         { *m = ListCollaboratorsRequest{} }
+func (m *ListCollaboratorsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCollaboratorsRequest) ProtoMessage()    {}
+func (*ListCollaboratorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{87}
+}
+
+func (m *ListCollaboratorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCollaboratorsRequest.Unmarshal(m, b)
+}
+func (m *ListCollaboratorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCollaboratorsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCollaboratorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCollaboratorsRequest.Merge(m, src)
+}
+func (m *ListCollaboratorsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCollaboratorsRequest.Size(m)
+}
+func (m *ListCollaboratorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCollaboratorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCollaboratorsRequest proto.InternalMessageInfo
+
+func (m *ListCollaboratorsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListCollaboratorsRequest) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+//*
+// Contains users a task is shared with, in order of sharing
+type ListCollaboratorsResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Collaborators
+	Collaborators        []*Collaborator `protobuf:"bytes,2,rep,name=collaborators,proto3" json:"collaborators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListCollaboratorsResponse) Reset()         { *m = ListCollaboratorsResponse{} }
+func (m *ListCollaboratorsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCollaboratorsResponse) ProtoMessage()    {}
+func (*ListCollaboratorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{88}
+}
+
+func (m *ListCollaboratorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCollaboratorsResponse.Unmarshal(m, b)
+}
+func (m *ListCollaboratorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCollaboratorsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCollaboratorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCollaboratorsResponse.Merge(m, src)
+}
+func (m *ListCollaboratorsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCollaboratorsResponse.Size(m)
+}
+func (m *ListCollaboratorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCollaboratorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCollaboratorsResponse proto.InternalMessageInfo
+
+func (m *ListCollaboratorsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListCollaboratorsResponse) GetCollaborators() []*Collaborator {
+	if m != nil {
+		return m.Collaborators
+	}
+	return nil
+}
+
+//*
+// Subscription of an HTTP endpoint to task events
+type Webhook struct {
+	// Unique identifier of the webhook
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// URL events are posted to
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Types of events posted, all types if empty
+	EventTypes []EventType `protobuf:"varint,3,rep,packed,name=event_types,json=eventTypes,proto3,enum=v1.EventType" json:"event_types,omitempty"`
+	// Key of the HMAC-SHA256 signature of payloads in the X-Todo-Signature header
+	// Generated if empty on create, returned only by CreateWebhook
+	Secret string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Time the webhook was created
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Subject of the caller who created the webhook, set by server.
+	// Only events of tasks the owner views are posted, events of all tasks if empty
+	OwnerId              string   `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{89}
+}
+
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Webhook) GetEventTypes() []EventType {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *Webhook) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Webhook) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+//*
+// Request data to create a webhook
+type CreateWebhookRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Webhook to create, only events after it is created are posted
+	Webhook              *Webhook `protobuf:"bytes,2,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookRequest) Reset()         { *m = CreateWebhookRequest{} }
+func (m *CreateWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookRequest) ProtoMessage()    {}
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{90}
+}
+
+func (m *CreateWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookRequest.Unmarshal(m, b)
+}
+func (m *CreateWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookRequest.Merge(m, src)
+}
+func (m *CreateWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookRequest.Size(m)
+}
+func (m *CreateWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookRequest proto.InternalMessageInfo
+
+func (m *CreateWebhookRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateWebhookRequest) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+//*
+// Contains the created webhook with its secret
+type CreateWebhookResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Created webhook
+	Webhook              *Webhook `protobuf:"bytes,2,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookResponse) Reset()         { *m = CreateWebhookResponse{} }
+func (m *CreateWebhookResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookResponse) ProtoMessage()    {}
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{91}
+}
+
+func (m *CreateWebhookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookResponse.Unmarshal(m, b)
+}
+func (m *CreateWebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookResponse.Merge(m, src)
+}
+func (m *CreateWebhookResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookResponse.Size(m)
+}
+func (m *CreateWebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookResponse proto.InternalMessageInfo
+
+func (m *CreateWebhookResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateWebhookResponse) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+//*
+// Request data to list webhooks
+type ListWebhooksRequest struct {
+	// API versioning, specify version explicitly
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRequest) Reset()         { *m = ListWebhooksRequest{} }
+func (m *ListWebhooksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRequest) ProtoMessage()    {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{92}
+}
+
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRequest.Unmarshal(m, b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRequest.Size(m)
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+func (m *ListWebhooksRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+//*
+// Contains all webhooks without their secrets
+type ListWebhooksResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// List of webhooks ordered by ID
+	Webhooks             []*Webhook `protobuf:"bytes,2,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListWebhooksResponse) Reset()         { *m = ListWebhooksResponse{} }
+func (m *ListWebhooksResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksResponse) ProtoMessage()    {}
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{93}
+}
+
+func (m *ListWebhooksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksResponse.Unmarshal(m, b)
+}
+func (m *ListWebhooksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksResponse.Merge(m, src)
+}
+func (m *ListWebhooksResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksResponse.Size(m)
+}
+func (m *ListWebhooksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksResponse proto.InternalMessageInfo
+
+func (m *ListWebhooksResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+//*
+// Request data to delete a webhook
+type DeleteWebhookRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the webhook
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookRequest) Reset()         { *m = DeleteWebhookRequest{} }
+func (m *DeleteWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookRequest) ProtoMessage()    {}
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{94}
+}
+
+func (m *DeleteWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookRequest.Unmarshal(m, b)
+}
+func (m *DeleteWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookRequest.Merge(m, src)
+}
+func (m *DeleteWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookRequest.Size(m)
+}
+func (m *DeleteWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookRequest proto.InternalMessageInfo
+
+func (m *DeleteWebhookRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteWebhookRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//*
+// Contains status of delete operation
+type DeleteWebhookResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Equals 1 if delete was successful
+	Deleted              int64    `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookResponse) Reset()         { *m = DeleteWebhookResponse{} }
+func (m *DeleteWebhookResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookResponse) ProtoMessage()    {}
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{95}
+}
+
+func (m *DeleteWebhookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookResponse.Unmarshal(m, b)
+}
+func (m *DeleteWebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookResponse.Merge(m, src)
+}
+func (m *DeleteWebhookResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookResponse.Size(m)
+}
+func (m *DeleteWebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookResponse proto.InternalMessageInfo
+
+func (m *DeleteWebhookResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *DeleteWebhookResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+//*
+// Attempts to post an event to a webhook
+type WebhookDelivery struct {
+	// Unique identifier of the delivery, sent in the X-Todo-Delivery header
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Webhook the event is posted to
+	WebhookId int64 `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Identifier of the event, the same in all deliveries of the event
+	EventId int64 `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Kind of change
+	EventType EventType `protobuf:"varint,4,opt,name=event_type,json=eventType,proto3,enum=v1.EventType" json:"event_type,omitempty"`
+	// Changed task
+	ToDoId int64 `protobuf:"varint,5,opt,name=to_do_id,json=toDoId,proto3" json:"to_do_id,omitempty"`
+	// State of the delivery
+	Status DeliveryStatus `protobuf:"varint,6,opt,name=status,proto3,enum=v1.DeliveryStatus" json:"status,omitempty"`
+	// Number of attempts made
+	Attempts int32 `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// HTTP status of the last response, 0 if there was none
+	ResponseCode int32 `protobuf:"varint,8,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`
+	// Error of the last failed attempt
+	Error string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	// Time of the next attempt of a pending delivery
+	NextAttemptAt *timestamp.Timestamp `protobuf:"bytes,10,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	// Time of the successful attempt
+	DeliveredAt *timestamp.Timestamp `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	// Time the event was queued for the webhook
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{96}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetWebhookId() int64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetEventId() int64 {
+	if m != nil {
+		return m.EventId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetEventType() EventType {
+	if m != nil {
+		return m.EventType
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (m *WebhookDelivery) GetToDoId() int64 {
+	if m != nil {
+		return m.ToDoId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetStatus() DeliveryStatus {
+	if m != nil {
+		return m.Status
+	}
+	return DeliveryStatus_DELIVERY_STATUS_PENDING
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetResponseCode() int32 {
+	if m != nil {
+		return m.ResponseCode
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetNextAttemptAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.NextAttemptAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetDeliveredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeliveredAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+//*
+// Request data to list deliveries of a webhook
+type ListWebhookDeliveriesRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the webhook
+	WebhookId int64 `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Maximum number of deliveries to return in a page
+	// Server default is used if 0
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token of the page to return, as returned by a previous call
+	// Empty for the first page
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesRequest) Reset()         { *m = ListWebhookDeliveriesRequest{} }
+func (m *ListWebhookDeliveriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesRequest) ProtoMessage()    {}
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{97}
+}
+
+func (m *ListWebhookDeliveriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Size(m)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesRequest proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListWebhookDeliveriesRequest) GetWebhookId() int64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *ListWebhookDeliveriesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListWebhookDeliveriesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+//*
+// Contains deliveries of a webhook, latest first
+type ListWebhookDeliveriesResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// List of deliveries
+	Deliveries []*WebhookDelivery `protobuf:"bytes,2,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	// Token to pass as page_token to get the next page
+	// Empty if this is the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesResponse) Reset()         { *m = ListWebhookDeliveriesResponse{} }
+func (m *ListWebhookDeliveriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesResponse) ProtoMessage()    {}
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{98}
+}
+
+func (m *ListWebhookDeliveriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesResponse.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Size(m)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesResponse proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+func (m *ListWebhookDeliveriesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+//*
+// Key authenticating scripts in the x-api-key metadata or X-Api-Key HTTP header
+type ApiKey struct {
+	// Unique identifier of the key
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name describing what the key is used by
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// What calls the key is allowed to make
+	Scope ApiKeyScope `protobuf:"varint,3,opt,name=scope,proto3,enum=v1.ApiKeyScope" json:"scope,omitempty"`
+	// First characters of the key to recognize it by, the key itself is returned only by CreateApiKey
+	Prefix string `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Time the key was created
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Time the key was last used, not set if it was never used.
+	// Updated at most once a minute
+	LastUsedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	// Time the key was revoked, not set for a key in use
+	RevokedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ApiKey) Reset()         { *m = ApiKey{} }
+func (m *ApiKey) String() string { return proto.CompactTextString(m) }
+func (*ApiKey) ProtoMessage()    {}
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{99}
+}
+
+func (m *ApiKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApiKey.Unmarshal(m, b)
+}
+func (m *ApiKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApiKey.Marshal(b, m, deterministic)
+}
+func (m *ApiKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApiKey.Merge(m, src)
+}
+func (m *ApiKey) XXX_Size() int {
+	return xxx_messageInfo_ApiKey.Size(m)
+}
+func (m *ApiKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApiKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApiKey proto.InternalMessageInfo
+
+func (m *ApiKey) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ApiKey) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApiKey) GetScope() ApiKeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return ApiKeyScope_API_KEY_SCOPE_UNSPECIFIED
+}
+
+func (m *ApiKey) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *ApiKey) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *ApiKey) GetLastUsedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastUsedAt
+	}
+	return nil
+}
+
+func (m *ApiKey) GetRevokedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return nil
+}
+
+//*
+// Request data to mint an API key
+type CreateApiKeyRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Name describing what the key is used by
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// What calls the key is allowed to make
+	Scope                ApiKeyScope `protobuf:"varint,3,opt,name=scope,proto3,enum=v1.ApiKeyScope" json:"scope,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CreateApiKeyRequest) Reset()         { *m = CreateApiKeyRequest{} }
+func (m *CreateApiKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateApiKeyRequest) ProtoMessage()    {}
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{100}
+}
+
+func (m *CreateApiKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApiKeyRequest.Unmarshal(m, b)
+}
+func (m *CreateApiKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApiKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateApiKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApiKeyRequest.Merge(m, src)
+}
+func (m *CreateApiKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateApiKeyRequest.Size(m)
+}
+func (m *CreateApiKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApiKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateApiKeyRequest proto.InternalMessageInfo
+
+func (m *CreateApiKeyRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateApiKeyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateApiKeyRequest) GetScope() ApiKeyScope {
+	if m != nil {
+		return m.Scope
+	}
+	return ApiKeyScope_API_KEY_SCOPE_UNSPECIFIED
+}
+
+//*
+// Contains the minted key
+type CreateApiKeyResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Created key
+	ApiKey *ApiKey `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// The key to pass in x-api-key, it is stored hashed and cannot be retrieved again
+	Key                  string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateApiKeyResponse) Reset()         { *m = CreateApiKeyResponse{} }
+func (m *CreateApiKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateApiKeyResponse) ProtoMessage()    {}
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{101}
+}
+
+func (m *CreateApiKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApiKeyResponse.Unmarshal(m, b)
+}
+func (m *CreateApiKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApiKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateApiKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApiKeyResponse.Merge(m, src)
+}
+func (m *CreateApiKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateApiKeyResponse.Size(m)
+}
+func (m *CreateApiKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApiKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateApiKeyResponse proto.InternalMessageInfo
+
+func (m *CreateApiKeyResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CreateApiKeyResponse) GetApiKey() *ApiKey {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+func (m *CreateApiKeyResponse) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+//*
+// Request data to list API keys
+type ListApiKeysRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Return revoked keys too
+	ShowRevoked          bool     `protobuf:"varint,2,opt,name=show_revoked,json=showRevoked,proto3" json:"show_revoked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListApiKeysRequest) Reset()         { *m = ListApiKeysRequest{} }
+func (m *ListApiKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListApiKeysRequest) ProtoMessage()    {}
+func (*ListApiKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{102}
+}
+
+func (m *ListApiKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListApiKeysRequest.Unmarshal(m, b)
+}
+func (m *ListApiKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListApiKeysRequest.Marshal(b, m, deterministic)
+}
+func (m *ListApiKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListApiKeysRequest.Merge(m, src)
+}
+func (m *ListApiKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_ListApiKeysRequest.Size(m)
+}
+func (m *ListApiKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListApiKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListApiKeysRequest proto.InternalMessageInfo
+
+func (m *ListApiKeysRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListApiKeysRequest) GetShowRevoked() bool {
+	if m != nil {
+		return m.ShowRevoked
+	}
+	return false
+}
+
+//*
+// Contains API keys of the caller
+type ListApiKeysResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// List of keys ordered by ID
+	ApiKeys              []*ApiKey `protobuf:"bytes,2,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListApiKeysResponse) Reset()         { *m = ListApiKeysResponse{} }
+func (m *ListApiKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*ListApiKeysResponse) ProtoMessage()    {}
+func (*ListApiKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{103}
+}
+
+func (m *ListApiKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListApiKeysResponse.Unmarshal(m, b)
+}
+func (m *ListApiKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListApiKeysResponse.Marshal(b, m, deterministic)
+}
+func (m *ListApiKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListApiKeysResponse.Merge(m, src)
+}
+func (m *ListApiKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_ListApiKeysResponse.Size(m)
+}
+func (m *ListApiKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListApiKeysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListApiKeysResponse proto.InternalMessageInfo
+
+func (m *ListApiKeysResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListApiKeysResponse) GetApiKeys() []*ApiKey {
+	if m != nil {
+		return m.ApiKeys
+	}
+	return nil
+}
+
+//*
+// Request data to revoke an API key
+type RevokeApiKeyRequest struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Unique identifier of the key
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeApiKeyRequest) Reset()         { *m = RevokeApiKeyRequest{} }
+func (m *RevokeApiKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeApiKeyRequest) ProtoMessage()    {}
+func (*RevokeApiKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{104}
+}
+
+func (m *RevokeApiKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeApiKeyRequest.Unmarshal(m, b)
+}
+func (m *RevokeApiKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeApiKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeApiKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeApiKeyRequest.Merge(m, src)
+}
+func (m *RevokeApiKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeApiKeyRequest.Size(m)
+}
+func (m *RevokeApiKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeApiKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeApiKeyRequest proto.InternalMessageInfo
+
+func (m *RevokeApiKeyRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RevokeApiKeyRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//*
+// Contains status of revoke operation
+type RevokeApiKeyResponse struct {
+	// API versioning, specify version explicitly
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// Equals 1 if the key was revoked
+	Revoked              int64    `protobuf:"varint,2,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeApiKeyResponse) Reset()         { *m = RevokeApiKeyResponse{} }
+func (m *RevokeApiKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeApiKeyResponse) ProtoMessage()    {}
+func (*RevokeApiKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80b701c7b1c502fe, []int{105}
+}
+
+func (m *RevokeApiKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeApiKeyResponse.Unmarshal(m, b)
+}
+func (m *RevokeApiKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeApiKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeApiKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeApiKeyResponse.Merge(m, src)
+}
+func (m *RevokeApiKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeApiKeyResponse.Size(m)
+}
+func (m *RevokeApiKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeApiKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeApiKeyResponse proto.InternalMessageInfo
+
+func (m *RevokeApiKeyResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RevokeApiKeyResponse) GetRevoked() int64 {
+	if m != nil {
+		return m.Revoked
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("v1.Priority", Priority_name, Priority_value)
+	proto.RegisterEnum("v1.TagMatch", TagMatch_name, TagMatch_value)
+	proto.RegisterEnum("v1.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("v1.HistoryAction", HistoryAction_name, HistoryAction_value)
+	proto.RegisterEnum("v1.DeliveryStatus", DeliveryStatus_name, DeliveryStatus_value)
+	proto.RegisterEnum("v1.AccessLevel", AccessLevel_name, AccessLevel_value)
+	proto.RegisterEnum("v1.ProjectDeleteMode", ProjectDeleteMode_name, ProjectDeleteMode_value)
+	proto.RegisterEnum("v1.ApiKeyScope", ApiKeyScope_name, ApiKeyScope_value)
+	proto.RegisterType((*ToDo)(nil), "v1.ToDo")
+	proto.RegisterType((*CreateRequest)(nil), "v1.CreateRequest")
+	proto.RegisterType((*CreateResponse)(nil), "v1.CreateResponse")
+	proto.RegisterType((*ReadRequest)(nil), "v1.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "v1.ReadResponse")
+	proto.RegisterType((*ReadChildrenRequest)(nil), "v1.ReadChildrenRequest")
+	proto.RegisterType((*ReadChildrenResponse)(nil), "v1.ReadChildrenResponse")
+	proto.RegisterType((*UpdateRequest)(nil), "v1.UpdateRequest")
+	proto.RegisterType((*UpdateResponse)(nil), "v1.UpdateResponse")
+	proto.RegisterType((*DeleteRequest)(nil), "v1.DeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "v1.DeleteResponse")
+	proto.RegisterType((*BatchCreateRequest)(nil), "v1.BatchCreateRequest")
+	proto.RegisterType((*BatchCreateResponse)(nil), "v1.BatchCreateResponse")
+	proto.RegisterType((*BatchUpdateRequest)(nil), "v1.BatchUpdateRequest")
+	proto.RegisterType((*BatchUpdateResponse)(nil), "v1.BatchUpdateResponse")
+	proto.RegisterType((*BatchDeleteRequest)(nil), "v1.BatchDeleteRequest")
+	proto.RegisterType((*BatchDeleteResponse)(nil), "v1.BatchDeleteResponse")
+	proto.RegisterType((*ReadAllRequest)(nil), "v1.ReadAllRequest")
+	proto.RegisterType((*ReadAllResponse)(nil), "v1.ReadAllResponse")
+	proto.RegisterType((*ListDeletedRequest)(nil), "v1.ListDeletedRequest")
+	proto.RegisterType((*ListDeletedResponse)(nil), "v1.ListDeletedResponse")
+	proto.RegisterType((*RestoreRequest)(nil), "v1.RestoreRequest")
+	proto.RegisterType((*RestoreResponse)(nil), "v1.RestoreResponse")
+	proto.RegisterType((*PurgeRequest)(nil), "v1.PurgeRequest")
+	proto.RegisterType((*PurgeResponse)(nil), "v1.PurgeResponse")
+	proto.RegisterType((*CompleteRequest)(nil), "v1.CompleteRequest")
+	proto.RegisterType((*CompleteResponse)(nil), "v1.CompleteResponse")
+	proto.RegisterType((*ListOccurrencesRequest)(nil), "v1.ListOccurrencesRequest")
+	proto.RegisterType((*ListOccurrencesResponse)(nil), "v1.ListOccurrencesResponse")
+	proto.RegisterType((*ReopenRequest)(nil), "v1.ReopenRequest")
+	proto.RegisterType((*ReopenResponse)(nil), "v1.ReopenResponse")
+	proto.RegisterType((*Tag)(nil), "v1.Tag")
+	proto.RegisterType((*ListTagsRequest)(nil), "v1.ListTagsRequest")
+	proto.RegisterType((*ListTagsResponse)(nil), "v1.ListTagsResponse")
+	proto.RegisterType((*RenameTagRequest)(nil), "v1.RenameTagRequest")
+	proto.RegisterType((*RenameTagResponse)(nil), "v1.RenameTagResponse")
+	proto.RegisterType((*DeleteTagRequest)(nil), "v1.DeleteTagRequest")
+	proto.RegisterType((*DeleteTagResponse)(nil), "v1.DeleteTagResponse")
+	proto.RegisterType((*AddTagsRequest)(nil), "v1.AddTagsRequest")
+	proto.RegisterType((*AddTagsResponse)(nil), "v1.AddTagsResponse")
+	proto.RegisterType((*RemoveTagsRequest)(nil), "v1.RemoveTagsRequest")
+	proto.RegisterType((*RemoveTagsResponse)(nil), "v1.RemoveTagsResponse")
+	proto.RegisterType((*SearchRequest)(nil), "v1.SearchRequest")
+	proto.RegisterType((*SearchResult)(nil), "v1.SearchResult")
+	proto.RegisterType((*SearchResponse)(nil), "v1.SearchResponse")
+	proto.RegisterType((*WatchRequest)(nil), "v1.WatchRequest")
+	proto.RegisterType((*WatchResponse)(nil), "v1.WatchResponse")
+	proto.RegisterType((*TaskHistoryEntry)(nil), "v1.TaskHistoryEntry")
+	proto.RegisterType((*ListTaskHistoryRequest)(nil), "v1.ListTaskHistoryRequest")
+	proto.RegisterType((*ListTaskHistoryResponse)(nil), "v1.ListTaskHistoryResponse")
+	proto.RegisterType((*Comment)(nil), "v1.Comment")
+	proto.RegisterType((*CreateCommentRequest)(nil), "v1.CreateCommentRequest")
+	proto.RegisterType((*CreateCommentResponse)(nil), "v1.CreateCommentResponse")
+	proto.RegisterType((*ListCommentsRequest)(nil), "v1.ListCommentsRequest")
+	proto.RegisterType((*ListCommentsResponse)(nil), "v1.ListCommentsResponse")
+	proto.RegisterType((*UpdateCommentRequest)(nil), "v1.UpdateCommentRequest")
+	proto.RegisterType((*UpdateCommentResponse)(nil), "v1.UpdateCommentResponse")
+	proto.RegisterType((*DeleteCommentRequest)(nil), "v1.DeleteCommentRequest")
+	proto.RegisterType((*DeleteCommentResponse)(nil), "v1.DeleteCommentResponse")
+	proto.RegisterType((*Attachment)(nil), "v1.Attachment")
+	proto.RegisterType((*UploadAttachmentRequest)(nil), "v1.UploadAttachmentRequest")
+	proto.RegisterType((*UploadAttachmentResponse)(nil), "v1.UploadAttachmentResponse")
+	proto.RegisterType((*ListAttachmentsRequest)(nil), "v1.ListAttachmentsRequest")
+	proto.RegisterType((*ListAttachmentsResponse)(nil), "v1.ListAttachmentsResponse")
+	proto.RegisterType((*DownloadAttachmentRequest)(nil), "v1.DownloadAttachmentRequest")
+	proto.RegisterType((*DeleteAttachmentRequest)(nil), "v1.DeleteAttachmentRequest")
+	proto.RegisterType((*DeleteAttachmentResponse)(nil), "v1.DeleteAttachmentResponse")
+	proto.RegisterType((*Project)(nil), "v1.Project")
+	proto.RegisterType((*CreateProjectRequest)(nil), "v1.CreateProjectRequest")
+	proto.RegisterType((*CreateProjectResponse)(nil), "v1.CreateProjectResponse")
+	proto.RegisterType((*ReadProjectRequest)(nil), "v1.ReadProjectRequest")
+	proto.RegisterType((*ReadProjectResponse)(nil), "v1.ReadProjectResponse")
+	proto.RegisterType((*ListProjectsRequest)(nil), "v1.ListProjectsRequest")
+	proto.RegisterType((*ListProjectsResponse)(nil), "v1.ListProjectsResponse")
+	proto.RegisterType((*UpdateProjectRequest)(nil), "v1.UpdateProjectRequest")
+	proto.RegisterType((*UpdateProjectResponse)(nil), "v1.UpdateProjectResponse")
+	proto.RegisterType((*DeleteProjectRequest)(nil), "v1.DeleteProjectRequest")
+	proto.RegisterType((*DeleteProjectResponse)(nil), "v1.DeleteProjectResponse")
+	proto.RegisterType((*MoveTaskRequest)(nil), "v1.MoveTaskRequest")
+	proto.RegisterType((*MoveTaskResponse)(nil), "v1.MoveTaskResponse")
+	proto.RegisterType((*ReorderRequest)(nil), "v1.ReorderRequest")
+	proto.RegisterType((*ReorderResponse)(nil), "v1.ReorderResponse")
+	proto.RegisterType((*Collaborator)(nil), "v1.Collaborator")
+	proto.RegisterType((*ShareTaskRequest)(nil), "v1.ShareTaskRequest")
+	proto.RegisterType((*ShareTaskResponse)(nil), "v1.ShareTaskResponse")
+	proto.RegisterType((*UnshareTaskRequest)(nil), "v1.UnshareTaskRequest")
+	proto.RegisterType((*UnshareTaskResponse)(nil), "v1.UnshareTaskResponse")
+	proto.RegisterType((*ListCollaboratorsRequest)(nil), "v1.ListCollaboratorsRequest")
+	proto.RegisterType((*ListCollaboratorsResponse)(nil), "v1.ListCollaboratorsResponse")
+	proto.RegisterType((*Webhook)(nil), "v1.Webhook")
+	proto.RegisterType((*CreateWebhookRequest)(nil), "v1.CreateWebhookRequest")
+	proto.RegisterType((*CreateWebhookResponse)(nil), "v1.CreateWebhookResponse")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "v1.ListWebhooksRequest")
+	proto.RegisterType((*ListWebhooksResponse)(nil), "v1.ListWebhooksResponse")
+	proto.RegisterType((*DeleteWebhookRequest)(nil), "v1.DeleteWebhookRequest")
+	proto.RegisterType((*DeleteWebhookResponse)(nil), "v1.DeleteWebhookResponse")
+	proto.RegisterType((*WebhookDelivery)(nil), "v1.WebhookDelivery")
+	proto.RegisterType((*ListWebhookDeliveriesRequest)(nil), "v1.ListWebhookDeliveriesRequest")
+	proto.RegisterType((*ListWebhookDeliveriesResponse)(nil), "v1.ListWebhookDeliveriesResponse")
+	proto.RegisterType((*ApiKey)(nil), "v1.ApiKey")
+	proto.RegisterType((*CreateApiKeyRequest)(nil), "v1.CreateApiKeyRequest")
+	proto.RegisterType((*CreateApiKeyResponse)(nil), "v1.CreateApiKeyResponse")
+	proto.RegisterType((*ListApiKeysRequest)(nil), "v1.ListApiKeysRequest")
+	proto.RegisterType((*ListApiKeysResponse)(nil), "v1.ListApiKeysResponse")
+	proto.RegisterType((*RevokeApiKeyRequest)(nil), "v1.RevokeApiKeyRequest")
+	proto.RegisterType((*RevokeApiKeyResponse)(nil), "v1.RevokeApiKeyResponse")
+}
+
+func init() {
+	proto.RegisterFile("todo-service.proto", fileDescriptor_80b701c7b1c502fe)
+}
+
+var fileDescriptor_80b701c7b1c502fe = []byte{
+	// 4832 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x7c, 0xdd, 0x73, 0x1b, 0x47,
+	0x72, 0xb8, 0x97, 0x20, 0xf1, 0xd1, 0x00, 0x41, 0x70, 0x48, 0x91, 0xe0, 0x52, 0x92, 0xa9, 0x95,
+	0x7c, 0x92, 0xf1, 0x13, 0x09, 0x99, 0xf6, 0xcf, 0x39, 0xcb, 0x4e, 0x6c, 0x08, 0x80, 0x2c, 0xe4,
+	0x28, 0x92, 0xb7, 0x04, 0xad, 0xc8, 0xbe, 0x2b, 0xdc, 0x12, 0x18, 0x81, 0x6b, 0x81, 0x58, 0x78,
+	0x77, 0x49, 0x99, 0x56, 0x94, 0xcf, 0x4a, 0xe5, 0xea, 0xe2, 0x4a, 0x55, 0x92, 0x97, 0x54, 0x2a,
+	0xa9, 0xfc, 0x03, 0xa9, 0xca, 0x53, 0xf2, 0x1f, 0xe4, 0x21, 0xcf, 0xa9, 0x3c, 0xa7, 0x2a, 0x75,
+	0x0f, 0x79, 0x48, 0x55, 0xf2, 0x27, 0xa4, 0xe6, 0x6b, 0x77, 0x67, 0x3f, 0x00, 0x90, 0xb2, 0x9e,
+	0x84, 0xe9, 0xee, 0xe9, 0xee, 0xe9, 0xe9, 0xe9, 0xee, 0x9d, 0x69, 0x0a, 0x90, 0x6b, 0xf5, 0xac,
+	0x4d, 0x07, 0xdb, 0x67, 0x66, 0x17, 0x6f, 0x8d, 0x6c, 0xcb, 0xb5, 0xd0, 0xcc, 0xd9, 0x7b, 0xea,
+	0xdb, 0x7d, 0xcb, 0xea, 0x0f, 0x70, 0x95, 0x42, 0x8e, 0x4e, 0x9f, 0x55, 0x5d, 0xf3, 0x04, 0x3b,
+	0xae, 0x71, 0x32, 0x62, 0x44, 0xea, 0x46, 0x98, 0xe0, 0x99, 0x89, 0x07, 0xbd, 0xce, 0x89, 0xe1,
+	0x3c, 0xe7, 0x14, 0x57, 0x39, 0x85, 0x31, 0x32, 0xab, 0xc6, 0x70, 0x68, 0xb9, 0x86, 0x6b, 0x5a,
+	0x43, 0x87, 0x63, 0xd7, 0x02, 0xd8, 0x63, 0xd7, 0x1d, 0x1d, 0x59, 0xbd, 0x73, 0x8e, 0xba, 0x4b,
+	0xff, 0xe9, 0x6e, 0xf6, 0xf1, 0x70, 0xd3, 0x79, 0x61, 0xf4, 0xfb, 0xd8, 0xae, 0x5a, 0x23, 0x3a,
+	0x39, 0xca, 0x48, 0xfb, 0xbb, 0x39, 0x98, 0x6d, 0x5b, 0x0d, 0x0b, 0x15, 0x61, 0xc6, 0xec, 0x95,
+	0x95, 0x0d, 0xe5, 0x4e, 0x4a, 0x9f, 0x31, 0x7b, 0x68, 0x19, 0xe6, 0x5c, 0xd3, 0x1d, 0xe0, 0xf2,
+	0xcc, 0x86, 0x72, 0x27, 0xa7, 0xb3, 0x01, 0xda, 0x80, 0x7c, 0x0f, 0x3b, 0x5d, 0xdb, 0xa4, 0x0c,
+	0xcb, 0x29, 0x8a, 0x0b, 0x82, 0xd0, 0x87, 0x90, 0xb5, 0xf1, 0x89, 0x39, 0xec, 0x61, 0xbb, 0x3c,
+	0xbb, 0xa1, 0xdc, 0xc9, 0x6f, 0xab, 0x5b, 0x4c, 0xd9, 0x2d, 0xb1, 0xd8, 0xad, 0xb6, 0xb0, 0x86,
+	0xee, 0xd1, 0xa2, 0xab, 0x90, 0xeb, 0x5a, 0x27, 0xa3, 0x01, 0x76, 0x71, 0xaf, 0x3c, 0xb7, 0xa1,
+	0xdc, 0xc9, 0xea, 0x3e, 0x00, 0xfd, 0x26, 0x14, 0xbc, 0x41, 0xc7, 0x70, 0xcb, 0xe9, 0x89, 0x9c,
+	0xf3, 0x1e, 0x7d, 0xcd, 0x45, 0x77, 0x21, 0xd5, 0x3b, 0xc5, 0xe5, 0xcc, 0xc4, 0x59, 0x84, 0x0c,
+	0xdd, 0x81, 0xec, 0xc8, 0x36, 0x2d, 0xdb, 0x74, 0xcf, 0xcb, 0xd9, 0x0d, 0xe5, 0x4e, 0x71, 0xbb,
+	0xb0, 0x75, 0xf6, 0xde, 0xd6, 0x3e, 0x87, 0xe9, 0x1e, 0x16, 0x21, 0x98, 0x75, 0x8d, 0xbe, 0x53,
+	0xce, 0x6d, 0xa4, 0xee, 0xe4, 0x74, 0xfa, 0x1b, 0xad, 0x43, 0x6e, 0x64, 0xd8, 0x78, 0xe8, 0x76,
+	0xcc, 0x5e, 0x19, 0xa8, 0x3d, 0xb3, 0x0c, 0xd0, 0xea, 0xa1, 0x5b, 0x90, 0xed, 0x1e, 0x9b, 0x83,
+	0x9e, 0x8d, 0x87, 0xe5, 0xfc, 0x46, 0xea, 0x4e, 0x7e, 0x3b, 0x4b, 0x58, 0x93, 0x1d, 0xd0, 0x3d,
+	0x0c, 0xba, 0x0e, 0x60, 0xe3, 0xee, 0xa9, 0x6d, 0xe3, 0x61, 0x17, 0x97, 0x0b, 0xd4, 0xc8, 0x01,
+	0x08, 0x11, 0x41, 0x1c, 0xaa, 0xf3, 0x9d, 0x35, 0xc4, 0xe5, 0x79, 0x8a, 0xce, 0x12, 0xc0, 0x97,
+	0xd6, 0x10, 0xa3, 0x8f, 0x00, 0x7a, 0xd8, 0x33, 0x54, 0x71, 0xe2, 0x92, 0x73, 0x9c, 0xba, 0xe6,
+	0x92, 0xe5, 0x60, 0xd7, 0xe8, 0x97, 0x17, 0x28, 0x4b, 0xfa, 0x1b, 0x5d, 0x03, 0x18, 0xd9, 0xd6,
+	0xd7, 0xb8, 0x4b, 0xd7, 0x53, 0xa2, 0xeb, 0xc9, 0x71, 0x48, 0xab, 0x87, 0x54, 0xc8, 0x8e, 0x2c,
+	0xc7, 0xa4, 0xde, 0xb0, 0xc8, 0x34, 0x11, 0x63, 0xb4, 0x06, 0x59, 0xeb, 0xc5, 0x10, 0xdb, 0x64,
+	0x22, 0xa2, 0xb8, 0x0c, 0x1d, 0xb7, 0x7a, 0xe8, 0x36, 0xa4, 0x8d, 0x6e, 0x17, 0x3b, 0x4e, 0x79,
+	0x89, 0x1a, 0x78, 0x81, 0x58, 0xa1, 0x46, 0x21, 0x3b, 0xf8, 0x0c, 0x0f, 0x74, 0x8e, 0xd6, 0x3e,
+	0x85, 0xf9, 0xba, 0x8d, 0x0d, 0x17, 0xeb, 0xf8, 0x9b, 0x53, 0xec, 0xb8, 0xa8, 0x04, 0x29, 0x63,
+	0x64, 0x52, 0x47, 0xcd, 0xe9, 0xe4, 0x27, 0xba, 0x0a, 0xb3, 0xae, 0xd5, 0xb0, 0xa8, 0xa3, 0x06,
+	0xed, 0x49, 0xa1, 0xda, 0x36, 0x14, 0x05, 0x03, 0x67, 0x64, 0x0d, 0x1d, 0x1c, 0xc3, 0x81, 0xf9,
+	0xfe, 0x8c, 0xf0, 0x7d, 0xed, 0x4f, 0x15, 0xc8, 0xeb, 0xd8, 0xe8, 0x25, 0xcb, 0x0c, 0xcd, 0x20,
+	0xa7, 0xa5, 0x87, 0x47, 0xee, 0x31, 0x3d, 0x11, 0x73, 0x3a, 0x1b, 0xa0, 0x1b, 0x50, 0x70, 0x8e,
+	0xad, 0x17, 0x1d, 0x6e, 0x61, 0x7a, 0x1e, 0xb2, 0x7a, 0x9e, 0xc0, 0x1a, 0x0c, 0x44, 0xcc, 0x6b,
+	0x0c, 0x06, 0x1d, 0x6a, 0x17, 0x47, 0xf8, 0xbd, 0x31, 0x18, 0xec, 0x51, 0x80, 0xf6, 0x5b, 0x50,
+	0x60, 0x8a, 0x24, 0xea, 0x3e, 0x7e, 0xf5, 0xbf, 0x01, 0x4b, 0x64, 0x7e, 0x9d, 0x7b, 0xd6, 0xd4,
+	0x0b, 0xd2, 0x1e, 0xc1, 0xb2, 0x3c, 0x31, 0x51, 0x81, 0xeb, 0x30, 0x47, 0x44, 0x39, 0xe5, 0x99,
+	0x90, 0x3f, 0x33, 0xb0, 0xf6, 0x8f, 0x0a, 0xcc, 0x1f, 0x8e, 0x7a, 0x97, 0xdf, 0x42, 0xf4, 0x31,
+	0xe4, 0x4f, 0x29, 0x03, 0x1a, 0x1f, 0xcb, 0xa9, 0x04, 0x97, 0x7e, 0x48, 0x42, 0xe8, 0x63, 0xc3,
+	0x79, 0xae, 0x03, 0x23, 0x27, 0xbf, 0x3d, 0x9f, 0x9e, 0x95, 0x7d, 0x7a, 0x9c, 0xd1, 0xf7, 0xa1,
+	0x28, 0x14, 0x4e, 0x5c, 0x75, 0x19, 0x32, 0x4c, 0x88, 0x30, 0x9a, 0x18, 0x7a, 0x02, 0x53, 0xbe,
+	0x40, 0xed, 0xf7, 0x60, 0x9e, 0x6d, 0xf8, 0xf4, 0x1e, 0x55, 0x86, 0x4c, 0xd7, 0x70, 0xba, 0x46,
+	0x0f, 0x53, 0x4e, 0x59, 0x5d, 0x0c, 0x2f, 0xb3, 0xa2, 0x4f, 0xa0, 0x28, 0xe4, 0x8f, 0x5b, 0x91,
+	0xf0, 0x53, 0xbe, 0x22, 0x3e, 0xd4, 0x0e, 0x01, 0x3d, 0x30, 0xdc, 0xee, 0xf1, 0xa4, 0x83, 0xb8,
+	0x49, 0x42, 0x3f, 0x45, 0x0a, 0x67, 0x58, 0x24, 0x3b, 0x29, 0x4d, 0xd3, 0x3d, 0x12, 0xed, 0x29,
+	0x2c, 0x49, 0x6c, 0x13, 0x35, 0xbb, 0x07, 0x39, 0x9b, 0x63, 0x05, 0x63, 0x14, 0x64, 0xcc, 0x50,
+	0xba, 0x4f, 0xe4, 0x69, 0x3c, 0xc9, 0xef, 0x12, 0x34, 0x96, 0xa6, 0xc5, 0x68, 0x3c, 0xd1, 0x3b,
+	0x92, 0x34, 0x96, 0x27, 0xc6, 0x69, 0x3c, 0xc9, 0x4d, 0x12, 0x34, 0x96, 0xa6, 0xc5, 0x68, 0x3c,
+	0x71, 0xf7, 0x93, 0x34, 0x96, 0x27, 0x06, 0x35, 0xfe, 0x8f, 0x19, 0x28, 0x92, 0x10, 0x51, 0x1b,
+	0x0c, 0x92, 0xd5, 0xa5, 0xc9, 0xb0, 0x8f, 0x3b, 0x8e, 0xf9, 0x1d, 0xab, 0x24, 0xe6, 0x48, 0x32,
+	0xec, 0xe3, 0x03, 0xf3, 0x3b, 0x4c, 0x53, 0x0b, 0x41, 0xba, 0xd6, 0x73, 0x2c, 0x6a, 0x09, 0x4a,
+	0xde, 0x26, 0x00, 0x74, 0x17, 0x90, 0x39, 0xec, 0x0e, 0x4e, 0x7b, 0x84, 0xc2, 0x35, 0x06, 0x8c,
+	0x09, 0x8b, 0xa1, 0x25, 0x8e, 0x69, 0x13, 0x04, 0x65, 0xb6, 0x02, 0xe9, 0x67, 0xe6, 0xc0, 0xc5,
+	0x36, 0xf5, 0xfe, 0x9c, 0xce, 0x47, 0x34, 0x09, 0xd9, 0x3d, 0x6c, 0x77, 0x8e, 0xce, 0xcb, 0x69,
+	0x9e, 0x84, 0xc8, 0xf8, 0x81, 0x9f, 0xbd, 0x33, 0x81, 0xec, 0xfd, 0x2e, 0xe4, 0x5c, 0xa3, 0xdf,
+	0x39, 0x21, 0x46, 0x0b, 0x26, 0xff, 0xb6, 0xd1, 0x7f, 0x4c, 0x60, 0x7a, 0xd6, 0xe5, 0xbf, 0x22,
+	0xd1, 0x3d, 0x17, 0x1b, 0xdd, 0x03, 0xc9, 0x13, 0xc2, 0xc9, 0x53, 0x3e, 0xb5, 0xf9, 0xf0, 0xa9,
+	0xfd, 0x95, 0x02, 0x0b, 0x9e, 0x85, 0x2f, 0x1b, 0x7f, 0xd1, 0x8f, 0x60, 0x61, 0x88, 0xbf, 0x75,
+	0x3b, 0x11, 0x53, 0xcf, 0x13, 0xf0, 0xbe, 0x67, 0xee, 0x6b, 0x00, 0x21, 0x33, 0xa7, 0xf4, 0x9c,
+	0x2b, 0xec, 0xab, 0x1d, 0x01, 0xda, 0x31, 0x1d, 0x97, 0xaf, 0xec, 0x8d, 0xec, 0xb8, 0x66, 0xc1,
+	0x92, 0x24, 0xe3, 0x4d, 0xaf, 0x99, 0x14, 0x07, 0x3a, 0x76, 0x5c, 0xcb, 0x9e, 0x3e, 0x30, 0x6b,
+	0x9f, 0xc2, 0x82, 0x37, 0x27, 0x51, 0x41, 0x95, 0x1c, 0x53, 0x4a, 0x24, 0xa6, 0x7a, 0x63, 0xcd,
+	0x81, 0xc2, 0xfe, 0xa9, 0xdd, 0xbf, 0x40, 0x2e, 0xa8, 0x41, 0x51, 0x94, 0x74, 0x47, 0xf8, 0x99,
+	0x65, 0xe3, 0x72, 0x6a, 0x62, 0x59, 0x37, 0xcf, 0x67, 0x3c, 0xa0, 0x13, 0xb4, 0x8f, 0x60, 0x9e,
+	0x0b, 0x4d, 0xd4, 0x79, 0x05, 0xd2, 0x23, 0x42, 0x22, 0x24, 0xf3, 0x91, 0xf6, 0x33, 0x58, 0xa8,
+	0xf3, 0x5a, 0x7a, 0x7a, 0x95, 0x6f, 0xc3, 0x82, 0x28, 0xc0, 0x3b, 0xac, 0xfa, 0xe5, 0x69, 0xac,
+	0x28, 0xc0, 0xfb, 0x14, 0xaa, 0xfd, 0x02, 0x4a, 0x3e, 0xf7, 0xcb, 0x55, 0x39, 0x04, 0x4b, 0xf6,
+	0xb5, 0x9c, 0x0a, 0x63, 0x09, 0x54, 0xfb, 0x2f, 0x05, 0x56, 0x88, 0x5b, 0xed, 0x75, 0x45, 0x01,
+	0xed, 0x4c, 0xbf, 0x8e, 0x8f, 0x00, 0x1c, 0xd7, 0xb0, 0xdd, 0x0e, 0xa9, 0xaf, 0xa7, 0x30, 0x7b,
+	0x8e, 0x52, 0x93, 0x31, 0xfa, 0xff, 0x90, 0xc5, 0xc3, 0x1e, 0x9b, 0x38, 0xf9, 0x4b, 0x28, 0x83,
+	0x87, 0x3d, 0x3a, 0x4d, 0x3a, 0x40, 0x73, 0x63, 0x0f, 0x50, 0x3a, 0x7c, 0x80, 0xfe, 0x42, 0x81,
+	0xd5, 0xc8, 0x52, 0x13, 0x8d, 0xfa, 0x09, 0xe4, 0x2d, 0x9f, 0x90, 0x9f, 0xa5, 0xb1, 0xdf, 0x54,
+	0x01, 0xf2, 0xa9, 0xcf, 0xd8, 0x7b, 0x30, 0xaf, 0x63, 0x6b, 0x74, 0x91, 0xe2, 0xf3, 0x33, 0x28,
+	0x8a, 0x29, 0x97, 0xac, 0x7b, 0xab, 0x90, 0x6a, 0x1b, 0x7d, 0x12, 0xe1, 0x87, 0xc6, 0x09, 0xe6,
+	0xf3, 0xe8, 0x6f, 0x52, 0xaa, 0x77, 0xad, 0xd3, 0xa1, 0xcb, 0xe5, 0xb1, 0x81, 0x76, 0x13, 0x16,
+	0x88, 0xe1, 0xda, 0x46, 0x3f, 0xd9, 0x39, 0xb4, 0x1a, 0x94, 0x7c, 0xa2, 0x44, 0xcd, 0xd6, 0x79,
+	0x5a, 0x61, 0xf6, 0xcc, 0xf0, 0xec, 0xc1, 0xf2, 0x8b, 0x76, 0x00, 0x25, 0x1d, 0x13, 0x3d, 0x08,
+	0x28, 0xd1, 0x20, 0x42, 0xef, 0x99, 0x80, 0xde, 0x6b, 0x90, 0x1d, 0xe2, 0x17, 0x1d, 0x0a, 0x67,
+	0x86, 0xce, 0x0c, 0xf1, 0x8b, 0x5d, 0xe3, 0x04, 0x6b, 0x9f, 0xc1, 0x62, 0x80, 0x69, 0xa2, 0x62,
+	0x6b, 0x90, 0x22, 0x75, 0x23, 0xb3, 0x98, 0xa7, 0x17, 0x81, 0x69, 0x3f, 0x86, 0x12, 0x8b, 0xba,
+	0x17, 0x55, 0x4b, 0xfb, 0x14, 0x16, 0x03, 0x33, 0x2f, 0x51, 0x5d, 0x3e, 0x84, 0x62, 0xad, 0xd7,
+	0x1b, 0x6b, 0xf8, 0xc8, 0xa9, 0x14, 0x99, 0x3b, 0xe5, 0x67, 0x6e, 0xad, 0x06, 0x0b, 0x1e, 0x9f,
+	0x4b, 0x7a, 0x4d, 0x8b, 0xd8, 0xf1, 0xc4, 0x3a, 0xc3, 0xaf, 0xaf, 0x4d, 0x03, 0x50, 0x90, 0xd5,
+	0x25, 0x15, 0x7a, 0x0e, 0xf3, 0x07, 0xd8, 0xb0, 0xbb, 0xc7, 0xc9, 0xca, 0x14, 0x40, 0xf9, 0x86,
+	0x6f, 0x88, 0xf2, 0x8d, 0x1c, 0x3c, 0x52, 0x63, 0x83, 0xc7, 0x6c, 0x38, 0x78, 0xfc, 0xb5, 0x02,
+	0x05, 0x21, 0xcd, 0x39, 0x1d, 0xb8, 0x9e, 0x6e, 0x4a, 0x6c, 0xd0, 0x5d, 0x86, 0x39, 0xa7, 0x4b,
+	0x72, 0x11, 0x11, 0xae, 0xe8, 0x6c, 0x80, 0x6e, 0xc2, 0x3c, 0xbd, 0x29, 0xea, 0x38, 0x43, 0x73,
+	0x34, 0xc2, 0x2e, 0x77, 0xd5, 0x02, 0x05, 0x1e, 0x30, 0x18, 0xaa, 0xc2, 0x52, 0xe0, 0xca, 0xc8,
+	0x23, 0x65, 0x1a, 0xa1, 0x00, 0x8a, 0x4f, 0xd0, 0xce, 0xa0, 0xe8, 0x69, 0x96, 0x64, 0xc9, 0x0a,
+	0x64, 0x6c, 0xaa, 0xb7, 0x38, 0x79, 0x25, 0xa2, 0x70, 0x70, 0x41, 0xba, 0x20, 0x98, 0x3a, 0x76,
+	0xd5, 0xa1, 0xf0, 0xc4, 0x70, 0xc7, 0x99, 0xff, 0x06, 0x14, 0x08, 0xd3, 0x13, 0xc1, 0x86, 0xed,
+	0x44, 0x9e, 0xc1, 0x18, 0x93, 0x7f, 0x52, 0x60, 0x9e, 0x73, 0x49, 0x54, 0xfe, 0x06, 0xcc, 0xba,
+	0xe7, 0x23, 0x66, 0xcb, 0xe2, 0xf6, 0x3c, 0xd1, 0xbc, 0x79, 0x86, 0x87, 0x6e, 0xfb, 0x7c, 0x84,
+	0x75, 0x8a, 0xf2, 0x76, 0x23, 0x15, 0xbb, 0x1b, 0x5b, 0x30, 0x3b, 0x65, 0xa2, 0xa1, 0x74, 0x11,
+	0xbd, 0xe7, 0xa2, 0x7a, 0xff, 0x8f, 0x02, 0xa5, 0xb6, 0xe1, 0x3c, 0x7f, 0x64, 0x92, 0xc2, 0xe5,
+	0xbc, 0x39, 0x74, 0xed, 0xf3, 0xc8, 0x35, 0x61, 0x19, 0xb2, 0xae, 0xd5, 0xe9, 0x59, 0x1d, 0xef,
+	0x44, 0xa4, 0x89, 0x3e, 0xad, 0x1e, 0x7a, 0x97, 0x5c, 0xf1, 0x78, 0xb7, 0x84, 0x45, 0xf6, 0x9d,
+	0xc2, 0x79, 0xd5, 0x28, 0x42, 0xe7, 0x04, 0xc4, 0x95, 0x8c, 0xae, 0x6b, 0xd9, 0xdc, 0x03, 0xd8,
+	0x00, 0x6d, 0x40, 0x9a, 0x57, 0x3b, 0x73, 0xa1, 0x25, 0x73, 0x38, 0x29, 0x03, 0x8d, 0x67, 0xa4,
+	0xe4, 0x4f, 0x87, 0x08, 0x18, 0xd8, 0x33, 0x4a, 0x66, 0x3a, 0xa3, 0x68, 0x67, 0xac, 0x50, 0x08,
+	0x2c, 0x7a, 0xfa, 0x20, 0xf0, 0x3a, 0x27, 0xef, 0x8f, 0x79, 0xda, 0x96, 0x04, 0x27, 0xfa, 0xca,
+	0x16, 0x64, 0xf0, 0xd0, 0xb5, 0x4d, 0x2f, 0x65, 0x2f, 0xb3, 0x50, 0x2e, 0xef, 0x94, 0x2e, 0x88,
+	0xa6, 0x76, 0xf6, 0x7f, 0x57, 0x20, 0x53, 0xb7, 0x4e, 0x4e, 0xf0, 0xd0, 0xbd, 0xc0, 0x36, 0xaf,
+	0x40, 0xda, 0x38, 0x75, 0x8f, 0x2d, 0x9b, 0x33, 0xe5, 0x23, 0x12, 0x14, 0xc9, 0xa5, 0xb4, 0xb8,
+	0xa5, 0x20, 0xbf, 0x49, 0x31, 0xd5, 0xa5, 0xdf, 0xec, 0xf4, 0x6a, 0x72, 0x6e, 0x72, 0x31, 0xc5,
+	0xa9, 0x6b, 0x2e, 0x99, 0xca, 0x2f, 0x58, 0xa6, 0xbb, 0xfe, 0xcd, 0x71, 0xea, 0x9a, 0xab, 0x99,
+	0xb0, 0xcc, 0x6e, 0x0a, 0xf8, 0xe2, 0x92, 0xf7, 0x34, 0x79, 0x95, 0xef, 0x40, 0xa6, 0xcb, 0x66,
+	0xf3, 0xf3, 0x97, 0xa7, 0x17, 0x10, 0x9c, 0xa1, 0xc0, 0x69, 0xfb, 0x70, 0x25, 0x24, 0x2a, 0x71,
+	0x17, 0x03, 0x1c, 0x67, 0xc6, 0x70, 0x7c, 0xc5, 0x3e, 0x89, 0x38, 0xdc, 0xb9, 0x8c, 0xee, 0xaf,
+	0xe3, 0x99, 0xe7, 0xb0, 0x2c, 0x8b, 0x4f, 0x5c, 0xcf, 0x6d, 0xc8, 0x72, 0x9d, 0x85, 0x5b, 0x4a,
+	0x0b, 0xf2, 0x90, 0x53, 0xbb, 0xe3, 0x39, 0x2c, 0xb3, 0xeb, 0x92, 0xd7, 0xd8, 0x36, 0xe6, 0xc6,
+	0x29, 0xcf, 0x8d, 0x03, 0x46, 0x9f, 0x1d, 0xbf, 0x8d, 0x21, 0xd1, 0xaf, 0xbb, 0x8d, 0x3a, 0x2c,
+	0xb3, 0x2a, 0xe9, 0x87, 0x5b, 0x8c, 0x56, 0x87, 0x2b, 0x21, 0x9e, 0x97, 0xa8, 0xbe, 0xfe, 0x45,
+	0x01, 0xa8, 0xb9, 0xae, 0xd1, 0x3d, 0xbe, 0xe0, 0xb9, 0x5f, 0x87, 0xdc, 0x33, 0x73, 0x80, 0x83,
+	0xf5, 0x68, 0x96, 0x00, 0x76, 0x0d, 0x96, 0x5d, 0xba, 0xd6, 0xd0, 0x25, 0x8f, 0x20, 0x34, 0xad,
+	0x31, 0xbf, 0xca, 0x73, 0x18, 0x49, 0x6a, 0x24, 0x3e, 0x78, 0x5f, 0x38, 0x29, 0x9d, 0xfe, 0x0e,
+	0xc5, 0x87, 0xf4, 0x05, 0xe2, 0x83, 0xf6, 0xbd, 0x02, 0xab, 0x87, 0xa3, 0x81, 0x65, 0xf4, 0xfc,
+	0xd5, 0x5c, 0xf2, 0xb0, 0x24, 0x2f, 0xeb, 0x4e, 0x20, 0xa6, 0x91, 0xb0, 0xcb, 0x35, 0x33, 0x46,
+	0xe6, 0xd6, 0x23, 0xd7, 0x1d, 0x3d, 0xb0, 0x7a, 0xe7, 0x2c, 0xd2, 0x69, 0x3f, 0x83, 0x72, 0x54,
+	0x9b, 0x31, 0x11, 0x1d, 0x0c, 0x8f, 0x8e, 0xfb, 0x51, 0x91, 0xbe, 0x88, 0xf8, 0xb3, 0x03, 0x14,
+	0x5a, 0x83, 0xe5, 0x29, 0x1f, 0x7b, 0x99, 0xb8, 0xa0, 0xfd, 0x1c, 0x56, 0x23, 0x5c, 0xc6, 0xdc,
+	0x0f, 0xe6, 0x7d, 0x05, 0xc4, 0x09, 0x0f, 0xeb, 0x18, 0x24, 0xd1, 0x9e, 0xc0, 0x5a, 0xc3, 0x7a,
+	0x31, 0x7c, 0xfd, 0x2d, 0x09, 0xfb, 0xfd, 0x21, 0xac, 0x32, 0xbf, 0xff, 0x61, 0xd9, 0x3e, 0x84,
+	0x72, 0x94, 0xed, 0x25, 0x4e, 0xd4, 0x7f, 0x2a, 0x90, 0xd9, 0x67, 0x57, 0x7c, 0x91, 0xe3, 0x14,
+	0xf7, 0x5d, 0x37, 0xf9, 0x49, 0xf5, 0x63, 0xc8, 0x93, 0x22, 0xd6, 0x3c, 0x63, 0xe7, 0x62, 0x72,
+	0x89, 0x07, 0x82, 0x9c, 0x25, 0xce, 0xcb, 0xe6, 0xdc, 0xe0, 0xfb, 0x5d, 0x5a, 0x7a, 0xbf, 0xd3,
+	0xf6, 0x44, 0x4e, 0xe5, 0x2b, 0x4d, 0xde, 0x80, 0x77, 0x20, 0xc3, 0x2f, 0x3c, 0x83, 0x01, 0x52,
+	0x4c, 0x13, 0x38, 0x3f, 0x73, 0x7a, 0x0c, 0xc7, 0x85, 0xdc, 0x69, 0x38, 0x7e, 0x48, 0xbe, 0xc0,
+	0x8c, 0xde, 0x44, 0x05, 0xc3, 0x97, 0x0f, 0xbb, 0xb0, 0x24, 0xcd, 0x7b, 0x5d, 0x3d, 0x7e, 0xa9,
+	0xb0, 0x14, 0xce, 0x11, 0xce, 0x9b, 0xb9, 0x2c, 0xbf, 0x09, 0xf3, 0xf4, 0x32, 0x5a, 0xec, 0x3c,
+	0xbf, 0x27, 0xa7, 0x37, 0xd4, 0x35, 0x0e, 0x13, 0xd9, 0xdc, 0xd7, 0x64, 0x5c, 0x36, 0xe7, 0xfa,
+	0x4b, 0xd9, 0x5c, 0x2c, 0xce, 0x43, 0x4e, 0x9d, 0xcd, 0xbf, 0x57, 0x44, 0x3a, 0xff, 0x81, 0x3c,
+	0xe6, 0xb5, 0x5e, 0x05, 0xfd, 0x0c, 0xff, 0x83, 0x6d, 0x73, 0x57, 0x64, 0xf8, 0x8b, 0x3a, 0x1c,
+	0x7a, 0x17, 0x66, 0x4f, 0x2c, 0xfe, 0xcc, 0x57, 0xdc, 0xbe, 0x12, 0xe0, 0xce, 0x18, 0x3e, 0xb6,
+	0x7a, 0x58, 0xa7, 0x24, 0x9a, 0x29, 0x52, 0xfe, 0x64, 0xb5, 0x13, 0x03, 0x14, 0xba, 0xe5, 0xdf,
+	0x26, 0xd3, 0xd0, 0xe8, 0xf0, 0x20, 0x58, 0xe0, 0xd0, 0x36, 0x7d, 0xb6, 0x7d, 0x06, 0x0b, 0x8f,
+	0xe9, 0xf5, 0x85, 0xf3, 0x7c, 0xfa, 0xa5, 0xc8, 0xef, 0x1d, 0xa9, 0xf0, 0x7b, 0x47, 0xcc, 0xcb,
+	0xa5, 0xb6, 0x0b, 0x25, 0x5f, 0x4e, 0xe2, 0x6a, 0x96, 0x61, 0x8e, 0x5c, 0xa6, 0x08, 0x59, 0x6c,
+	0x90, 0xf0, 0xd4, 0x4a, 0xee, 0x0e, 0xc9, 0x0b, 0xcf, 0x85, 0xbe, 0xdd, 0xd8, 0x17, 0xa5, 0xaf,
+	0x75, 0x96, 0x01, 0x5a, 0x3d, 0x12, 0x05, 0xe9, 0xd7, 0x24, 0xc1, 0xb1, 0x57, 0x91, 0x0c, 0x1d,
+	0x07, 0xd6, 0x33, 0x17, 0x90, 0x7f, 0x00, 0x0b, 0x9e, 0xfc, 0x71, 0xcf, 0x03, 0x5e, 0xd7, 0xc4,
+	0x4c, 0xa8, 0x6b, 0x22, 0x6e, 0x51, 0xdf, 0x2b, 0x50, 0xa8, 0x5b, 0x83, 0x81, 0x71, 0x64, 0xd9,
+	0x06, 0xf9, 0x36, 0x2e, 0x43, 0xc6, 0x39, 0x3d, 0xa2, 0x4e, 0xc9, 0xd8, 0x8a, 0x61, 0xa0, 0xb3,
+	0x62, 0x66, 0x6c, 0x67, 0x45, 0x28, 0x31, 0xa4, 0x2e, 0x52, 0x6c, 0xfd, 0xa1, 0x02, 0xa5, 0x83,
+	0x63, 0xc3, 0x9e, 0xe0, 0x1d, 0xc9, 0xb9, 0x37, 0xa0, 0x7e, 0x2a, 0x49, 0xfd, 0xd9, 0xf1, 0x8d,
+	0x21, 0x5f, 0xc1, 0x62, 0x40, 0x85, 0x44, 0x4b, 0x7f, 0x40, 0x2a, 0x51, 0xdf, 0x70, 0xfc, 0x08,
+	0x97, 0x58, 0x91, 0xee, 0xc3, 0x75, 0x89, 0x4a, 0xfb, 0x12, 0xd0, 0xe1, 0xd0, 0x79, 0x23, 0x2b,
+	0xd4, 0x6a, 0xb0, 0x24, 0xf1, 0x1e, 0x77, 0x82, 0x6d, 0x1c, 0xf4, 0x7a, 0x31, 0x24, 0xa5, 0x0a,
+	0xfb, 0x2a, 0xf3, 0x55, 0xbe, 0x54, 0x05, 0x88, 0x61, 0x2d, 0x86, 0x4f, 0xa2, 0x42, 0x1f, 0xc2,
+	0x7c, 0xd0, 0x4a, 0xd2, 0x3d, 0x9b, 0x64, 0x4c, 0x99, 0x4c, 0xfb, 0x57, 0x05, 0x32, 0x4f, 0xf0,
+	0xd1, 0xb1, 0x65, 0x3d, 0x8f, 0x54, 0x44, 0x25, 0x48, 0x9d, 0xda, 0x03, 0x7e, 0x08, 0xc8, 0x4f,
+	0xb4, 0x05, 0x79, 0x7c, 0x26, 0xbe, 0x1c, 0xd8, 0xa5, 0x6a, 0xe4, 0x46, 0x0c, 0xb0, 0xf8, 0xe9,
+	0x90, 0x0b, 0x08, 0x07, 0x77, 0x6d, 0xef, 0xfe, 0x90, 0x8f, 0xde, 0x74, 0xe1, 0xc3, 0x17, 0x34,
+	0x36, 0x8d, 0xbd, 0x60, 0x34, 0xc1, 0xbc, 0x21, 0xa6, 0x09, 0x9c, 0x5f, 0xf8, 0x78, 0x0c, 0xc7,
+	0x65, 0xa2, 0x69, 0x38, 0xde, 0x66, 0xf5, 0x06, 0x87, 0x8f, 0x79, 0xce, 0xf8, 0x29, 0x2c, 0xcb,
+	0x84, 0xe3, 0xca, 0x01, 0xce, 0x5d, 0x2a, 0x07, 0x84, 0x68, 0x0f, 0xa9, 0xfd, 0x58, 0x64, 0xc1,
+	0x89, 0xe6, 0x09, 0x97, 0x5d, 0xde, 0xd7, 0xec, 0x64, 0x3b, 0x24, 0xd7, 0xde, 0xbf, 0x4e, 0xc1,
+	0x02, 0x9f, 0xdf, 0xc0, 0x03, 0xf3, 0x0c, 0xc7, 0xdc, 0x58, 0x5e, 0x03, 0xe0, 0xea, 0xfa, 0x07,
+	0x22, 0xc7, 0x21, 0x2c, 0xdc, 0x33, 0xf7, 0xf3, 0x52, 0x41, 0x86, 0x8e, 0x5b, 0x3d, 0x74, 0x17,
+	0xc0, 0xf7, 0x4c, 0x1e, 0x9f, 0x42, 0x8e, 0x99, 0xf3, 0x1c, 0x53, 0x3a, 0x76, 0x73, 0x52, 0x6c,
+	0xa8, 0x40, 0xda, 0x71, 0x0d, 0xf7, 0xd4, 0xa1, 0xce, 0x55, 0xf4, 0x1a, 0x2d, 0xa8, 0xbe, 0x07,
+	0x14, 0xa3, 0x73, 0x0a, 0x92, 0x29, 0x0c, 0xd7, 0xc5, 0x27, 0x23, 0xd7, 0xa1, 0xd7, 0x98, 0x73,
+	0xba, 0x37, 0x26, 0x35, 0x9f, 0x68, 0xc7, 0xe8, 0x74, 0x49, 0x05, 0x91, 0xa5, 0x04, 0x05, 0x01,
+	0xac, 0x5b, 0x3d, 0xfa, 0xdc, 0x85, 0x6d, 0xdb, 0xb2, 0x69, 0x7b, 0x42, 0x4e, 0x67, 0x03, 0xf4,
+	0x80, 0x97, 0x6d, 0x9c, 0x17, 0x39, 0x21, 0x30, 0xf9, 0x49, 0x99, 0x4c, 0xa9, 0xb1, 0x19, 0x35,
+	0x97, 0xf4, 0x64, 0xf6, 0x98, 0xd2, 0xec, 0x88, 0xe5, 0x27, 0x32, 0xc8, 0x7b, 0xf4, 0x91, 0x0f,
+	0x93, 0xc2, 0x45, 0xf2, 0xcf, 0x9f, 0x29, 0x70, 0x35, 0xe0, 0xb9, 0xdc, 0x74, 0xe6, 0xb8, 0x77,
+	0xdd, 0x09, 0xbb, 0xfe, 0x3a, 0x77, 0x64, 0x7f, 0xae, 0xc0, 0xb5, 0x04, 0x6d, 0x12, 0x5d, 0xf8,
+	0x7d, 0xda, 0xa4, 0xc9, 0xe9, 0xf8, 0x91, 0x5a, 0x0a, 0x1c, 0x29, 0xe1, 0x0d, 0x7a, 0x80, 0x6c,
+	0xea, 0x5a, 0xfb, 0xef, 0x67, 0x20, 0x5d, 0x1b, 0x99, 0x3f, 0xc1, 0xe7, 0x53, 0x7d, 0x80, 0xbe,
+	0x43, 0x1f, 0x72, 0x46, 0xa2, 0x00, 0x65, 0x19, 0x97, 0x4e, 0x3f, 0x20, 0x60, 0x9d, 0x61, 0x69,
+	0x7b, 0x80, 0x8d, 0x9f, 0x99, 0xdf, 0x8a, 0x38, 0xcb, 0x46, 0xaf, 0x13, 0x67, 0x3f, 0x81, 0xc2,
+	0xc0, 0x70, 0xdc, 0xce, 0xa9, 0x33, 0xed, 0x8d, 0x0f, 0x10, 0xfa, 0x43, 0x47, 0x38, 0x90, 0x8d,
+	0xcf, 0xac, 0xe7, 0x6c, 0xee, 0xe4, 0x3b, 0xfe, 0x1c, 0xa7, 0xae, 0xb9, 0xda, 0x11, 0x2c, 0xb1,
+	0xa0, 0xcb, 0xd6, 0x79, 0xb1, 0x87, 0xd8, 0xe9, 0xec, 0xa5, 0x75, 0x44, 0xa6, 0x10, 0x32, 0x12,
+	0x9d, 0xe1, 0x26, 0x64, 0x8c, 0x91, 0xd9, 0x79, 0x8e, 0xcf, 0x79, 0x5c, 0x07, 0x9f, 0xa5, 0x9e,
+	0x36, 0xe8, 0xbf, 0x64, 0x1a, 0x21, 0x60, 0x1b, 0x4e, 0x7e, 0x6a, 0x2d, 0xd6, 0x91, 0xc3, 0xe8,
+	0x9c, 0xb1, 0x4f, 0x54, 0xf4, 0xd3, 0x90, 0x2f, 0xbf, 0x3c, 0xe3, 0xf7, 0x29, 0xe9, 0x0c, 0x44,
+	0xbe, 0x79, 0x25, 0x56, 0x63, 0x52, 0x50, 0x96, 0xab, 0x2a, 0xbc, 0x36, 0xa8, 0x6b, 0x86, 0xe9,
+	0xea, 0xb0, 0xb6, 0x53, 0xc2, 0x7a, 0x92, 0x7d, 0xc3, 0x59, 0xe0, 0x01, 0x2c, 0xcb, 0x13, 0xc7,
+	0x57, 0x47, 0xfe, 0x82, 0x52, 0xba, 0x18, 0x56, 0x06, 0x90, 0x15, 0xad, 0xda, 0x68, 0x11, 0xe6,
+	0xf7, 0xf5, 0xd6, 0x9e, 0xde, 0x6a, 0x3f, 0xed, 0xec, 0xee, 0xed, 0x36, 0x4b, 0x6f, 0xa1, 0x12,
+	0x14, 0x3c, 0xd0, 0xce, 0xde, 0x93, 0x92, 0x82, 0x96, 0x60, 0xc1, 0x83, 0x3c, 0x6e, 0x36, 0x5a,
+	0x87, 0x8f, 0x4b, 0x33, 0xd2, 0xcc, 0x47, 0xad, 0xcf, 0x1f, 0x95, 0x52, 0x12, 0xdd, 0xa1, 0xfe,
+	0x79, 0x73, 0xb7, 0x5d, 0x9a, 0xad, 0xdc, 0x83, 0xac, 0xe8, 0x0d, 0x23, 0x73, 0xda, 0xb5, 0xcf,
+	0x3b, 0x8f, 0x6b, 0xed, 0xfa, 0xa3, 0x4e, 0x6d, 0xf7, 0x69, 0xe9, 0xad, 0x10, 0x68, 0x67, 0xa7,
+	0xa4, 0x54, 0x7e, 0xa9, 0x40, 0xce, 0xcb, 0x18, 0x48, 0x85, 0x95, 0xe6, 0x17, 0xcd, 0xdd, 0x76,
+	0xa7, 0xfd, 0x74, 0xbf, 0xd9, 0x39, 0xdc, 0x3d, 0xd8, 0x6f, 0xd6, 0x5b, 0x0f, 0x5b, 0xcd, 0x46,
+	0xe9, 0x2d, 0xb4, 0x02, 0x28, 0x80, 0xab, 0xeb, 0xcd, 0x5a, 0xbb, 0xd9, 0x28, 0x29, 0x21, 0xf8,
+	0xe1, 0x7e, 0x83, 0xc2, 0x67, 0x42, 0xf0, 0x46, 0x73, 0xa7, 0x49, 0xe0, 0x29, 0xb4, 0x0a, 0x4b,
+	0x01, 0xb8, 0xde, 0x7c, 0xdc, 0xda, 0x6d, 0x34, 0xf5, 0xd2, 0x6c, 0xe5, 0x0f, 0x14, 0x98, 0x97,
+	0x9e, 0xe4, 0xd0, 0x75, 0x50, 0x1f, 0xb5, 0x0e, 0xda, 0x7b, 0xfa, 0xd3, 0x4e, 0xad, 0xde, 0x6e,
+	0xed, 0xed, 0x86, 0x54, 0x5a, 0x83, 0x2b, 0x21, 0x3c, 0x53, 0xab, 0xa4, 0xc4, 0xa0, 0x98, 0x66,
+	0xa5, 0x99, 0x18, 0x14, 0x53, 0xae, 0x94, 0xaa, 0x1c, 0xd3, 0xd6, 0xd4, 0x40, 0xea, 0x43, 0xeb,
+	0xb0, 0xda, 0x68, 0xee, 0xb4, 0xbe, 0x68, 0xea, 0x4f, 0x3b, 0x07, 0xed, 0x5a, 0xfb, 0xf0, 0xa0,
+	0xb3, 0xdf, 0xdc, 0x6d, 0xb4, 0x76, 0x3f, 0x2f, 0xbd, 0x85, 0xae, 0xc1, 0x5a, 0x18, 0x79, 0x70,
+	0x58, 0xaf, 0x37, 0x9b, 0x0d, 0x6a, 0x19, 0x15, 0x56, 0xc2, 0xe8, 0x87, 0xb5, 0xd6, 0x0e, 0xb1,
+	0x4e, 0xe5, 0x14, 0xf2, 0x81, 0x0f, 0x09, 0x74, 0x15, 0xca, 0xb5, 0x7a, 0xbd, 0x79, 0x70, 0xd0,
+	0xd9, 0x69, 0x7e, 0xd1, 0xdc, 0x09, 0xad, 0x73, 0x15, 0x96, 0x24, 0xec, 0x17, 0xad, 0xe6, 0x93,
+	0xa6, 0x5e, 0x52, 0x22, 0x88, 0x66, 0xa3, 0xd5, 0xde, 0xd3, 0x99, 0xf1, 0x25, 0xc4, 0xde, 0x93,
+	0xdd, 0xa6, 0x5e, 0x4a, 0x55, 0x0e, 0x61, 0x31, 0xf2, 0x39, 0x8f, 0xde, 0x86, 0xf5, 0x7d, 0x7d,
+	0xef, 0xb7, 0x9b, 0xf5, 0x36, 0xb7, 0x44, 0xe7, 0xf1, 0x5e, 0xa3, 0xd9, 0xa9, 0xe9, 0xf5, 0x47,
+	0xad, 0x2f, 0x88, 0x97, 0x26, 0x10, 0xd4, 0x6b, 0x07, 0xf5, 0x5a, 0xa3, 0x59, 0x52, 0x2a, 0x7d,
+	0xc8, 0x07, 0x82, 0x0e, 0xb1, 0x4b, 0x6d, 0xbf, 0xd5, 0xf9, 0x49, 0xf3, 0x69, 0xe7, 0xa0, 0xbe,
+	0x17, 0xf1, 0xa4, 0x75, 0x58, 0x95, 0xd1, 0x7a, 0xb3, 0xd6, 0xe8, 0xec, 0xed, 0xee, 0x3c, 0x2d,
+	0x29, 0xd4, 0x12, 0x51, 0xe4, 0x13, 0xbd, 0x45, 0xf6, 0x6e, 0xfb, 0x1f, 0xde, 0x86, 0x3c, 0xb9,
+	0x12, 0x38, 0x60, 0x7f, 0xe5, 0x82, 0xbe, 0x86, 0x0c, 0x6f, 0x4a, 0x44, 0xb4, 0x70, 0x91, 0x7b,
+	0x40, 0xd5, 0x25, 0x09, 0xc6, 0x8e, 0xaf, 0xf6, 0xe1, 0x1f, 0xfd, 0xdb, 0xaf, 0xff, 0x6a, 0xe6,
+	0x1e, 0x2a, 0x54, 0xcf, 0xde, 0xab, 0xba, 0x56, 0xcf, 0xaa, 0x1a, 0x83, 0xc1, 0x97, 0x1b, 0xe8,
+	0x3a, 0x19, 0x8b, 0xbb, 0xa4, 0xea, 0x4b, 0xff, 0xee, 0xe0, 0x15, 0xa5, 0x42, 0x0d, 0x48, 0xb3,
+	0x18, 0x8a, 0xa2, 0x9d, 0xc4, 0x6a, 0x4c, 0x0f, 0xb0, 0xb6, 0x44, 0x05, 0xcd, 0x6b, 0x59, 0x21,
+	0xe8, 0xbe, 0x52, 0x41, 0x9f, 0xc1, 0x2c, 0x51, 0x08, 0x2d, 0x08, 0xd5, 0x04, 0x87, 0x92, 0x0f,
+	0xe0, 0xf3, 0xaf, 0xd0, 0xf9, 0x0b, 0x68, 0xde, 0x53, 0xf4, 0xa5, 0xd9, 0x7b, 0x85, 0x0c, 0x28,
+	0x04, 0xbb, 0xe1, 0xd1, 0xaa, 0x98, 0x18, 0x6a, 0xac, 0x57, 0xcb, 0x51, 0x04, 0xe7, 0x7c, 0x9d,
+	0x72, 0x2e, 0xa3, 0x15, 0x89, 0x73, 0xd5, 0xfb, 0x9b, 0x8f, 0xaf, 0x21, 0xcd, 0x6e, 0xa4, 0x50,
+	0xb4, 0x05, 0x59, 0x8d, 0x69, 0x1e, 0xd6, 0x3e, 0xa2, 0x0c, 0xdf, 0x57, 0x91, 0xcf, 0x90, 0xd4,
+	0x97, 0x5b, 0x66, 0xef, 0xd5, 0x7d, 0xa5, 0xf2, 0xa5, 0xba, 0x1d, 0x87, 0x60, 0xcd, 0x02, 0x0f,
+	0x21, 0xcd, 0x7c, 0x11, 0x45, 0x9b, 0x87, 0xd5, 0x98, 0xb6, 0x5f, 0x61, 0x96, 0x4a, 0xc8, 0x2c,
+	0x1d, 0xc8, 0x07, 0x3a, 0xb8, 0xd1, 0x0a, 0x99, 0x19, 0xed, 0x14, 0x57, 0x57, 0x23, 0x70, 0xce,
+	0xf6, 0x6d, 0xca, 0x76, 0x4d, 0x5b, 0xf6, 0x76, 0xeb, 0xc8, 0xa7, 0x22, 0x3b, 0x27, 0x04, 0x70,
+	0xcb, 0xf8, 0x02, 0x64, 0xf3, 0xac, 0x46, 0xe0, 0xe3, 0x05, 0x30, 0xaa, 0xa0, 0x00, 0x6e, 0x0e,
+	0x5f, 0x80, 0x6c, 0x93, 0xd5, 0x08, 0x7c, 0xbc, 0x00, 0x46, 0x45, 0x04, 0xfc, 0x14, 0xf2, 0x81,
+	0x96, 0x56, 0x26, 0x20, 0xda, 0x47, 0xab, 0xae, 0x46, 0xe0, 0x5c, 0xc0, 0x22, 0x15, 0x90, 0x47,
+	0x39, 0x2a, 0xc0, 0x36, 0x9c, 0x63, 0xd4, 0x26, 0x07, 0x90, 0xc4, 0x6c, 0x2c, 0x0e, 0x60, 0xb0,
+	0x83, 0x55, 0x5d, 0x92, 0x60, 0x9c, 0xcd, 0x06, 0x65, 0xa3, 0x6a, 0x57, 0xa4, 0x0d, 0xbc, 0xcf,
+	0x7b, 0x52, 0x99, 0xa2, 0x73, 0xb4, 0x41, 0x14, 0xd1, 0x43, 0x11, 0x6c, 0x50, 0x55, 0x17, 0x03,
+	0x10, 0xce, 0xef, 0x26, 0xe5, 0x77, 0xad, 0xe2, 0xab, 0xf5, 0x65, 0xa9, 0x52, 0xf4, 0x06, 0xcc,
+	0x3d, 0x7e, 0x07, 0xb2, 0xa2, 0xb5, 0x13, 0x2d, 0xf1, 0x67, 0xd1, 0x60, 0x1b, 0xa9, 0xba, 0x2c,
+	0x03, 0x39, 0xef, 0x1b, 0x94, 0xf7, 0xba, 0x26, 0x9f, 0x94, 0xfb, 0xa2, 0x6f, 0x94, 0x28, 0xbb,
+	0x0f, 0x69, 0xd6, 0x20, 0xc8, 0x1c, 0x58, 0xea, 0x2f, 0x54, 0x51, 0x10, 0x94, 0xb4, 0x4f, 0x62,
+	0xfd, 0x84, 0x8a, 0x70, 0x3c, 0x81, 0x85, 0x50, 0xe3, 0x24, 0x52, 0xc5, 0x9e, 0x44, 0x1b, 0x47,
+	0xd5, 0xf5, 0x58, 0x9c, 0xbc, 0x00, 0xb4, 0x26, 0x1f, 0xf5, 0x60, 0xf3, 0xe4, 0xe7, 0x90, 0x15,
+	0x9d, 0x84, 0xcc, 0x34, 0xa1, 0xe6, 0x43, 0x75, 0x59, 0x06, 0x72, 0xce, 0x25, 0xca, 0x19, 0x10,
+	0x0b, 0x6f, 0x64, 0xf2, 0x57, 0x90, 0xf3, 0x5a, 0xff, 0xd0, 0x32, 0x5b, 0xb9, 0xdc, 0x5e, 0xa8,
+	0x5e, 0x09, 0x41, 0x63, 0xcd, 0x6c, 0xf4, 0x9d, 0xea, 0x4b, 0x42, 0x42, 0x8c, 0x42, 0xfe, 0x65,
+	0x3e, 0x91, 0xf3, 0x7a, 0xfb, 0x18, 0xf3, 0x70, 0x93, 0xa0, 0x7a, 0x25, 0x04, 0xe5, 0xcc, 0x57,
+	0x29, 0xf3, 0xc5, 0xca, 0x42, 0x88, 0x39, 0x71, 0x5e, 0xde, 0xa5, 0xc7, 0x9c, 0x57, 0x6e, 0xfd,
+	0x53, 0x97, 0x24, 0xd8, 0x78, 0xe7, 0x35, 0x18, 0x19, 0x51, 0xf4, 0x17, 0x00, 0x7e, 0xb7, 0x1d,
+	0xe2, 0x0b, 0x0e, 0x35, 0xf2, 0xa9, 0x2b, 0x61, 0xb0, 0xec, 0xcb, 0x5a, 0x39, 0xec, 0x1b, 0x82,
+	0x92, 0x48, 0x78, 0x04, 0x69, 0xd6, 0x4a, 0xc6, 0x3c, 0x4e, 0xea, 0xca, 0x53, 0x51, 0x10, 0x24,
+	0x5b, 0x00, 0x2d, 0x78, 0x91, 0xc1, 0x61, 0xf3, 0x1f, 0xc2, 0x1c, 0xed, 0x06, 0x63, 0x07, 0x2d,
+	0xd8, 0x5e, 0xa6, 0x2e, 0x06, 0x20, 0x9c, 0xcd, 0x0a, 0x65, 0x53, 0x42, 0x45, 0x8f, 0xcd, 0x0b,
+	0x82, 0xbf, 0xa7, 0x20, 0x53, 0x74, 0xac, 0x7a, 0x7d, 0x3f, 0xbe, 0xc7, 0x46, 0x3b, 0x98, 0xd4,
+	0xf5, 0x58, 0x1c, 0x97, 0x72, 0x8d, 0x4a, 0x59, 0x45, 0xb2, 0x85, 0xab, 0xc7, 0x9c, 0xaf, 0x23,
+	0xfe, 0x08, 0x4f, 0xb4, 0x07, 0x95, 0xfd, 0xd4, 0x2b, 0x37, 0x34, 0xa8, 0x6b, 0x31, 0x18, 0x2e,
+	0x64, 0x93, 0x0a, 0xb9, 0xad, 0x5d, 0x0d, 0xe6, 0x25, 0x76, 0x41, 0xf2, 0xaa, 0x2a, 0xda, 0x43,
+	0xee, 0x8b, 0x96, 0x09, 0xd4, 0x87, 0x42, 0xb0, 0xf5, 0x04, 0x79, 0x21, 0x32, 0xd4, 0x0b, 0xa3,
+	0x96, 0xa3, 0x08, 0x2e, 0xf1, 0x16, 0x95, 0x78, 0x1d, 0x8d, 0x95, 0x88, 0xbe, 0x15, 0x7f, 0x9f,
+	0x26, 0xad, 0x2e, 0xae, 0xf7, 0x44, 0x5d, 0x8b, 0xc1, 0x70, 0x59, 0xdb, 0x54, 0xd6, 0xdd, 0xed,
+	0x1b, 0xe3, 0x64, 0x31, 0xcf, 0xf2, 0x96, 0x68, 0x89, 0x3f, 0x0b, 0x93, 0x24, 0xc7, 0x35, 0x8a,
+	0xa8, 0x6b, 0x31, 0x18, 0x2e, 0xf9, 0x5d, 0x2a, 0xf9, 0x66, 0x65, 0xb2, 0x64, 0xf4, 0xbb, 0x50,
+	0x0a, 0xb7, 0x25, 0xa0, 0x75, 0xb6, 0xa6, 0xd8, 0x77, 0x7a, 0xf5, 0x6a, 0x3c, 0x32, 0xb4, 0xa3,
+	0x6f, 0xc7, 0x49, 0x0e, 0xf4, 0x02, 0xdc, 0x67, 0xed, 0x5f, 0x0e, 0xf3, 0x58, 0x9f, 0x51, 0x20,
+	0xc6, 0x46, 0x7b, 0x19, 0xd4, 0xf5, 0x58, 0x1c, 0x17, 0x7d, 0x9b, 0x8a, 0xbe, 0x81, 0x26, 0x89,
+	0x46, 0xbf, 0x0f, 0x28, 0xda, 0x86, 0x80, 0xae, 0x51, 0x73, 0x26, 0xb5, 0x27, 0xa8, 0xb1, 0xad,
+	0x1d, 0xda, 0x07, 0x54, 0xe6, 0x16, 0xba, 0x3b, 0x41, 0x26, 0xaf, 0xec, 0x58, 0xb3, 0x0b, 0x7a,
+	0x29, 0x5a, 0xab, 0xc3, 0x36, 0x4f, 0x68, 0x62, 0x50, 0xaf, 0xc6, 0x23, 0xf9, 0xc2, 0xef, 0x52,
+	0x25, 0x7e, 0x54, 0xb9, 0x35, 0x8d, 0x12, 0xa8, 0x2b, 0x4e, 0xae, 0xe8, 0x48, 0x08, 0x9c, 0x5c,
+	0xf9, 0xa1, 0x52, 0x5d, 0x8b, 0xc1, 0xc8, 0xe1, 0x41, 0x2b, 0x04, 0xcb, 0xf5, 0xfb, 0xde, 0x4b,
+	0xec, 0x53, 0xf6, 0xd7, 0xb2, 0x42, 0xc4, 0x8a, 0xa8, 0x81, 0x43, 0x02, 0x56, 0x23, 0x70, 0xce,
+	0x7e, 0x8d, 0xb2, 0x5f, 0x42, 0x8b, 0xf2, 0xd7, 0x00, 0xd1, 0xff, 0x09, 0x0b, 0x02, 0x7c, 0x46,
+	0x20, 0x08, 0x84, 0x5e, 0xd3, 0xd5, 0x72, 0x14, 0xc1, 0xb9, 0x2f, 0x53, 0xee, 0x45, 0x24, 0x29,
+	0x8f, 0x46, 0xe2, 0xd0, 0x4b, 0x86, 0x89, 0x7b, 0xa1, 0x56, 0xd7, 0x62, 0x30, 0x9c, 0x77, 0x85,
+	0xf2, 0xbe, 0xb5, 0xbd, 0x16, 0xfb, 0x1d, 0x43, 0x2b, 0x6e, 0xcf, 0x4a, 0x1d, 0x71, 0xd8, 0x25,
+	0x89, 0x71, 0x6f, 0xc6, 0xea, 0x5a, 0x0c, 0x46, 0xb6, 0x55, 0x25, 0xc6, 0x56, 0x6d, 0xc8, 0x8a,
+	0x97, 0x54, 0x56, 0x53, 0x84, 0xde, 0x6f, 0xd5, 0x65, 0x19, 0x18, 0xda, 0x5c, 0x24, 0xa7, 0x3f,
+	0x92, 0xfc, 0x48, 0xe2, 0xa3, 0xd5, 0x26, 0x7d, 0xcf, 0x14, 0xd5, 0x66, 0xf0, 0x71, 0x55, 0x5d,
+	0x92, 0x60, 0x93, 0xaa, 0x4d, 0x4a, 0x46, 0xb8, 0x3e, 0x83, 0x9c, 0xf7, 0x7a, 0xc7, 0x2a, 0x8b,
+	0xf0, 0x7b, 0xa2, 0x7a, 0x25, 0x04, 0x95, 0xfd, 0x5f, 0x4b, 0x88, 0x76, 0x81, 0x77, 0x27, 0x22,
+	0xc7, 0x81, 0x7c, 0xe0, 0xb1, 0x8d, 0xb9, 0x66, 0xf4, 0x65, 0x4f, 0x5d, 0x8d, 0xc0, 0xb9, 0xb4,
+	0xf7, 0xa9, 0xb4, 0xcd, 0xca, 0xff, 0x9b, 0x28, 0xad, 0xfa, 0x92, 0x3f, 0xf0, 0xbd, 0x42, 0x2f,
+	0x61, 0x31, 0xf2, 0xac, 0x86, 0xae, 0xfa, 0x59, 0x2a, 0xfa, 0x6a, 0xa7, 0x5e, 0x4b, 0xc0, 0xca,
+	0x21, 0x1e, 0x4d, 0x5e, 0xf4, 0xf6, 0x3f, 0xa7, 0xa0, 0xc8, 0x2f, 0x91, 0xc5, 0x17, 0xfb, 0xcf,
+	0x45, 0x10, 0xe0, 0xf0, 0x60, 0x10, 0x90, 0xdf, 0x69, 0xd4, 0xb5, 0x18, 0x8c, 0x5c, 0xd0, 0xb0,
+	0x20, 0x20, 0x1e, 0x7c, 0x88, 0x8d, 0xf9, 0x19, 0xe5, 0xf4, 0x81, 0x33, 0x1a, 0x7a, 0x81, 0x52,
+	0xcb, 0x51, 0x44, 0xdc, 0x19, 0x15, 0xbc, 0xfd, 0x13, 0x23, 0xe9, 0x1d, 0xf7, 0xbe, 0xa4, 0xae,
+	0xc5, 0x60, 0xe2, 0x4e, 0x8c, 0xe0, 0xcd, 0x4e, 0xcc, 0xaf, 0x14, 0xb8, 0x12, 0x7b, 0x73, 0x8f,
+	0x36, 0x42, 0xaa, 0x46, 0x9e, 0x18, 0xd4, 0x1b, 0x63, 0x28, 0x64, 0x57, 0x45, 0xb7, 0x64, 0xc9,
+	0xfe, 0x3b, 0xc4, 0xab, 0xaa, 0x7f, 0xbb, 0xbf, 0xfd, 0xb7, 0x33, 0x30, 0xcf, 0x6f, 0x74, 0xf8,
+	0xbe, 0x3d, 0x85, 0x42, 0xf0, 0x06, 0x99, 0x19, 0x36, 0xe6, 0xde, 0x5a, 0x2d, 0x47, 0x11, 0x72,
+	0xf9, 0xa8, 0xe5, 0x89, 0x0a, 0xc6, 0xc8, 0x24, 0x17, 0xb7, 0x64, 0xcf, 0x0e, 0xd8, 0x67, 0x29,
+	0xa3, 0x76, 0xfc, 0xcf, 0x52, 0xf9, 0x32, 0x59, 0x5d, 0x8d, 0xc0, 0xe5, 0x7b, 0x16, 0x14, 0xe4,
+	0x8b, 0xbe, 0x22, 0xb7, 0x24, 0xfe, 0xe5, 0xad, 0xb8, 0x25, 0x89, 0xdc, 0x03, 0xab, 0xe5, 0x28,
+	0x82, 0xf3, 0x2d, 0x53, 0xbe, 0xa8, 0x52, 0x0a, 0xf0, 0xa5, 0x7b, 0xf5, 0xe0, 0x7f, 0x95, 0xbf,
+	0xac, 0xfd, 0xb7, 0x82, 0xfe, 0x44, 0x81, 0x02, 0xb9, 0x8d, 0xda, 0xe0, 0xff, 0xe9, 0x8a, 0x36,
+	0x82, 0xeb, 0x7d, 0x6b, 0xb3, 0x6f, 0x8f, 0xba, 0x9b, 0xc7, 0xae, 0x3b, 0xda, 0x24, 0x9f, 0xb4,
+	0x9b, 0x27, 0x66, 0xd7, 0xb6, 0x38, 0x05, 0xba, 0x4f, 0xe0, 0xce, 0xfd, 0x6a, 0xb5, 0x6f, 0xba,
+	0xc7, 0xa7, 0x47, 0x5b, 0x5d, 0xeb, 0xa4, 0x8a, 0xcf, 0xad, 0x4d, 0xeb, 0xc4, 0x70, 0xab, 0xe3,
+	0xe7, 0xaa, 0x08, 0x9f, 0x5b, 0x5b, 0x84, 0xf0, 0xb3, 0xfe, 0x89, 0x61, 0x0e, 0xc8, 0xdc, 0xed,
+	0xd4, 0x7b, 0x5b, 0xf7, 0x2a, 0x8a, 0xb2, 0x5d, 0x32, 0x46, 0xa3, 0x81, 0xd9, 0xa5, 0xff, 0x9d,
+	0x4a, 0xf5, 0x6b, 0xc7, 0x1a, 0xde, 0x17, 0x10, 0xd3, 0xe5, 0x10, 0xfd, 0x63, 0x48, 0x7d, 0x70,
+	0xef, 0x03, 0xf4, 0x01, 0x54, 0x74, 0xec, 0x9e, 0xda, 0x43, 0xdc, 0xdb, 0x78, 0x71, 0x8c, 0x87,
+	0x1b, 0xee, 0x31, 0xde, 0xb0, 0xb1, 0x63, 0x9d, 0xda, 0x5d, 0xbc, 0xd1, 0xb3, 0xb0, 0xb3, 0x31,
+	0xb4, 0xdc, 0x0d, 0xfc, 0xad, 0xe9, 0xb8, 0x5b, 0x28, 0x0d, 0xb3, 0x7f, 0x33, 0xa3, 0x64, 0x8e,
+	0xd2, 0xf4, 0x11, 0xe3, 0xfd, 0xff, 0x1b, 0x00, 0xc5, 0x16, 0xbe, 0x0d, 0x66, 0x46, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ToDoServiceClient is the client API for ToDoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ToDoServiceClient interface {
+	// Read all Tasks
+	ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (*ReadAllResponse, error)
+	// Create a new task
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	// Read a task
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+	// Read subtasks of a task
+	ReadChildren(ctx context.Context, in *ReadChildrenRequest, opts ...grpc.CallOption) (*ReadChildrenResponse, error)
+	// Update a task
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	// Move a task to trash
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Create tasks in one transaction
+	BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchCreateResponse, error)
+	// Update tasks in one transaction
+	BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error)
+	// Move tasks to trash in one transaction
+	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
+	// List tasks in trash
+	ListDeleted(ctx context.Context, in *ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedResponse, error)
+	// Restore a task from trash
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	// Permanently delete tasks in trash
+	Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeResponse, error)
+	// Mark a task as completed, completing a completed task does nothing
+	Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error)
+	// Mark a completed task as not done
+	Reopen(ctx context.Context, in *ReopenRequest, opts ...grpc.CallOption) (*ReopenResponse, error)
+	// List occurrences of a recurring task in a time window
+	ListOccurrences(ctx context.Context, in *ListOccurrencesRequest, opts ...grpc.CallOption) (*ListOccurrencesResponse, error)
+	// List all tags
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// Rename a tag on all tasks
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error)
+	// Delete a tag from all tasks
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+	// Add tags to a task
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error)
+	// Remove tags from a task
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error)
+	// Search tasks by keywords in title and description
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// Stream task changes
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ToDoService_WatchClient, error)
+	// List changes of a task
+	ListTaskHistory(ctx context.Context, in *ListTaskHistoryRequest, opts ...grpc.CallOption) (*ListTaskHistoryResponse, error)
+	// Comment on a task
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
+	// List comments on a task
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// Edit a comment
+	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error)
+	// Delete a comment
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	// Attach a file to a task, the request body is the content of the file
+	UploadAttachment(ctx context.Context, in *UploadAttachmentRequest, opts ...grpc.CallOption) (*UploadAttachmentResponse, error)
+	// List attachments of a task
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	// Download content of an attachment
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// Delete an attachment
+	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error)
+	// Create a project
+	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error)
+	// Read a project
+	ReadProject(ctx context.Context, in *ReadProjectRequest, opts ...grpc.CallOption) (*ReadProjectResponse, error)
+	// List projects
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	// Update a project
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
+	// Archive a project or delete it moving its tasks to trash
+	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error)
+	// Move a task with its subtasks to another project, allowed to the owner of the task only
+	MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*MoveTaskResponse, error)
+	// Move a task right before or after another task in the custom order
+	Reorder(ctx context.Context, in *ReorderRequest, opts ...grpc.CallOption) (*ReorderResponse, error)
+	// Share a task with a user as viewer or editor
+	ShareTask(ctx context.Context, in *ShareTaskRequest, opts ...grpc.CallOption) (*ShareTaskResponse, error)
+	// Stop sharing a task with a user
+	UnshareTask(ctx context.Context, in *UnshareTaskRequest, opts ...grpc.CallOption) (*UnshareTaskResponse, error)
+	// List users a task is shared with
+	ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error)
+}
+
+type toDoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewToDoServiceClient(cc grpc.ClientConnInterface) ToDoServiceClient {
+	return &toDoServiceClient{cc}
+}
+
+func (c *toDoServiceClient) ReadAll(ctx context.Context, in *ReadAllRequest, opts ...grpc.CallOption) (*ReadAllResponse, error) {
+	out := new(ReadAllResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ReadAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
+	out := new(ReadResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Read", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ReadChildren(ctx context.Context, in *ReadChildrenRequest, opts ...grpc.CallOption) (*ReadChildrenResponse, error) {
+	out := new(ReadChildrenResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ReadChildren", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchCreateResponse, error) {
+	out := new(BatchCreateResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/BatchCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error) {
+	out := new(BatchUpdateResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/BatchUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error) {
+	out := new(BatchDeleteResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/BatchDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ListDeleted(ctx context.Context, in *ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedResponse, error) {
+	out := new(ListDeletedResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ListDeleted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeResponse, error) {
+	out := new(PurgeResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Purge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error) {
+	out := new(CompleteResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Complete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Reopen(ctx context.Context, in *ReopenRequest, opts ...grpc.CallOption) (*ReopenResponse, error) {
+	out := new(ReopenResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Reopen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ListOccurrences(ctx context.Context, in *ListOccurrencesRequest, opts ...grpc.CallOption) (*ListOccurrencesResponse, error) {
+	out := new(ListOccurrencesResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ListOccurrences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ListTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error) {
+	out := new(RenameTagResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/RenameTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/DeleteTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error) {
+	out := new(AddTagsResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/AddTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error) {
+	out := new(RemoveTagsResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/RemoveTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ToDoService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ToDoService_serviceDesc.Streams[0], "/v1.ToDoService/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &toDoServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ToDoService_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type toDoServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *toDoServiceWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *toDoServiceClient) ListTaskHistory(ctx context.Context, in *ListTaskHistoryRequest, opts ...grpc.CallOption) (*ListTaskHistoryResponse, error) {
+	out := new(ListTaskHistoryResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ListTaskHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error) {
+	out := new(CreateCommentResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/CreateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ListComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error) {
+	out := new(UpdateCommentResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/UpdateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/DeleteComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) UploadAttachment(ctx context.Context, in *UploadAttachmentRequest, opts ...grpc.CallOption) (*UploadAttachmentResponse, error) {
+	out := new(UploadAttachmentResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/UploadAttachment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ListAttachments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/DownloadAttachment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error) {
+	out := new(DeleteAttachmentResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/DeleteAttachment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error) {
+	out := new(CreateProjectResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/CreateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ReadProject(ctx context.Context, in *ReadProjectRequest, opts ...grpc.CallOption) (*ReadProjectResponse, error) {
+	out := new(ReadProjectResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ReadProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ListProjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error) {
+	out := new(UpdateProjectResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/UpdateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error) {
+	out := new(DeleteProjectResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/DeleteProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*MoveTaskResponse, error) {
+	out := new(MoveTaskResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/MoveTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) Reorder(ctx context.Context, in *ReorderRequest, opts ...grpc.CallOption) (*ReorderResponse, error) {
+	out := new(ReorderResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/Reorder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ShareTask(ctx context.Context, in *ShareTaskRequest, opts ...grpc.CallOption) (*ShareTaskResponse, error) {
+	out := new(ShareTaskResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ShareTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) UnshareTask(ctx context.Context, in *UnshareTaskRequest, opts ...grpc.CallOption) (*UnshareTaskResponse, error) {
+	out := new(UnshareTaskResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/UnshareTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toDoServiceClient) ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error) {
+	out := new(ListCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, "/v1.ToDoService/ListCollaborators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
-func (c *toDoServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
-	out := new(CreateResponse)
-	err := c.cc.Invoke(ctx, "/v1.ToDoService/Create", in, out, opts...)
+// ToDoServiceServer is the server API for ToDoService service.
+type ToDoServiceServer interface {
+	// Read all Tasks
+	ReadAll(context.Context, *ReadAllRequest) (*ReadAllResponse, error)
+	// Create a new task
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	// Read a task
+	Read(context.Context, *ReadRequest) (*ReadResponse, error)
+	// Read subtasks of a task
+	ReadChildren(context.Context, *ReadChildrenRequest) (*ReadChildrenResponse, error)
+	// Update a task
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	// Move a task to trash
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Create tasks in one transaction
+	BatchCreate(context.Context, *BatchCreateRequest) (*BatchCreateResponse, error)
+	// Update tasks in one transaction
+	BatchUpdate(context.Context, *BatchUpdateRequest) (*BatchUpdateResponse, error)
+	// Move tasks to trash in one transaction
+	BatchDelete(context.Context, *BatchDeleteRequest) (*BatchDeleteResponse, error)
+	// List tasks in trash
+	ListDeleted(context.Context, *ListDeletedRequest) (*ListDeletedResponse, error)
+	// Restore a task from trash
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	// Permanently delete tasks in trash
+	Purge(context.Context, *PurgeRequest) (*PurgeResponse, error)
+	// Mark a task as completed, completing a completed task does nothing
+	Complete(context.Context, *CompleteRequest) (*CompleteResponse, error)
+	// Mark a completed task as not done
+	Reopen(context.Context, *ReopenRequest) (*ReopenResponse, error)
+	// List occurrences of a recurring task in a time window
+	ListOccurrences(context.Context, *ListOccurrencesRequest) (*ListOccurrencesResponse, error)
+	// List all tags
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// Rename a tag on all tasks
+	RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error)
+	// Delete a tag from all tasks
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
+	// Add tags to a task
+	AddTags(context.Context, *AddTagsRequest) (*AddTagsResponse, error)
+	// Remove tags from a task
+	RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error)
+	// Search tasks by keywords in title and description
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// Stream task changes
+	Watch(*WatchRequest, ToDoService_WatchServer) error
+	// List changes of a task
+	ListTaskHistory(context.Context, *ListTaskHistoryRequest) (*ListTaskHistoryResponse, error)
+	// Comment on a task
+	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
+	// List comments on a task
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// Edit a comment
+	UpdateComment(context.Context, *UpdateCommentRequest) (*UpdateCommentResponse, error)
+	// Delete a comment
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	// Attach a file to a task, the request body is the content of the file
+	UploadAttachment(context.Context, *UploadAttachmentRequest) (*UploadAttachmentResponse, error)
+	// List attachments of a task
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	// Download content of an attachment
+	DownloadAttachment(context.Context, *DownloadAttachmentRequest) (*httpbody.HttpBody, error)
+	// Delete an attachment
+	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error)
+	// Create a project
+	CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error)
+	// Read a project
+	ReadProject(context.Context, *ReadProjectRequest) (*ReadProjectResponse, error)
+	// List projects
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	// Update a project
+	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
+	// Archive a project or delete it moving its tasks to trash
+	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
+	// Move a task with its subtasks to another project, allowed to the owner of the task only
+	MoveTask(context.Context, *MoveTaskRequest) (*MoveTaskResponse, error)
+	// Move a task right before or after another task in the custom order
+	Reorder(context.Context, *ReorderRequest) (*ReorderResponse, error)
+	// Share a task with a user as viewer or editor
+	ShareTask(context.Context, *ShareTaskRequest) (*ShareTaskResponse, error)
+	// Stop sharing a task with a user
+	UnshareTask(context.Context, *UnshareTaskRequest) (*UnshareTaskResponse, error)
+	// List users a task is shared with
+	ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error)
+}
+
+// UnimplementedToDoServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedToDoServiceServer struct {
+}
+
+func (*UnimplementedToDoServiceServer) ReadAll(ctx context.Context, req *ReadAllRequest) (*ReadAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadAll not implemented")
+}
+func (*UnimplementedToDoServiceServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedToDoServiceServer) Read(ctx context.Context, req *ReadRequest) (*ReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (*UnimplementedToDoServiceServer) ReadChildren(ctx context.Context, req *ReadChildrenRequest) (*ReadChildrenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadChildren not implemented")
+}
+func (*UnimplementedToDoServiceServer) Update(ctx context.Context, req *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (*UnimplementedToDoServiceServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedToDoServiceServer) BatchCreate(ctx context.Context, req *BatchCreateRequest) (*BatchCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreate not implemented")
+}
+func (*UnimplementedToDoServiceServer) BatchUpdate(ctx context.Context, req *BatchUpdateRequest) (*BatchUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdate not implemented")
+}
+func (*UnimplementedToDoServiceServer) BatchDelete(ctx context.Context, req *BatchDeleteRequest) (*BatchDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDelete not implemented")
+}
+func (*UnimplementedToDoServiceServer) ListDeleted(ctx context.Context, req *ListDeletedRequest) (*ListDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeleted not implemented")
+}
+func (*UnimplementedToDoServiceServer) Restore(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (*UnimplementedToDoServiceServer) Purge(ctx context.Context, req *PurgeRequest) (*PurgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
+func (*UnimplementedToDoServiceServer) Complete(ctx context.Context, req *CompleteRequest) (*CompleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
+}
+func (*UnimplementedToDoServiceServer) Reopen(ctx context.Context, req *ReopenRequest) (*ReopenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reopen not implemented")
+}
+func (*UnimplementedToDoServiceServer) ListOccurrences(ctx context.Context, req *ListOccurrencesRequest) (*ListOccurrencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOccurrences not implemented")
+}
+func (*UnimplementedToDoServiceServer) ListTags(ctx context.Context, req *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (*UnimplementedToDoServiceServer) RenameTag(ctx context.Context, req *RenameTagRequest) (*RenameTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (*UnimplementedToDoServiceServer) DeleteTag(ctx context.Context, req *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (*UnimplementedToDoServiceServer) AddTags(ctx context.Context, req *AddTagsRequest) (*AddTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTags not implemented")
+}
+func (*UnimplementedToDoServiceServer) RemoveTags(ctx context.Context, req *RemoveTagsRequest) (*RemoveTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (*UnimplementedToDoServiceServer) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedToDoServiceServer) Watch(req *WatchRequest, srv ToDoService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (*UnimplementedToDoServiceServer) ListTaskHistory(ctx context.Context, req *ListTaskHistoryRequest) (*ListTaskHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskHistory not implemented")
+}
+func (*UnimplementedToDoServiceServer) CreateComment(ctx context.Context, req *CreateCommentRequest) (*CreateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (*UnimplementedToDoServiceServer) ListComments(ctx context.Context, req *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (*UnimplementedToDoServiceServer) UpdateComment(ctx context.Context, req *UpdateCommentRequest) (*UpdateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (*UnimplementedToDoServiceServer) DeleteComment(ctx context.Context, req *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (*UnimplementedToDoServiceServer) UploadAttachment(ctx context.Context, req *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (*UnimplementedToDoServiceServer) ListAttachments(ctx context.Context, req *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (*UnimplementedToDoServiceServer) DownloadAttachment(ctx context.Context, req *DownloadAttachmentRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (*UnimplementedToDoServiceServer) DeleteAttachment(ctx context.Context, req *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
+func (*UnimplementedToDoServiceServer) CreateProject(ctx context.Context, req *CreateProjectRequest) (*CreateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (*UnimplementedToDoServiceServer) ReadProject(ctx context.Context, req *ReadProjectRequest) (*ReadProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadProject not implemented")
+}
+func (*UnimplementedToDoServiceServer) ListProjects(ctx context.Context, req *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (*UnimplementedToDoServiceServer) UpdateProject(ctx context.Context, req *UpdateProjectRequest) (*UpdateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (*UnimplementedToDoServiceServer) DeleteProject(ctx context.Context, req *DeleteProjectRequest) (*DeleteProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (*UnimplementedToDoServiceServer) MoveTask(ctx context.Context, req *MoveTaskRequest) (*MoveTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTask not implemented")
+}
+func (*UnimplementedToDoServiceServer) Reorder(ctx context.Context, req *ReorderRequest) (*ReorderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reorder not implemented")
+}
+func (*UnimplementedToDoServiceServer) ShareTask(ctx context.Context, req *ShareTaskRequest) (*ShareTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareTask not implemented")
+}
+func (*UnimplementedToDoServiceServer) UnshareTask(ctx context.Context, req *UnshareTaskRequest) (*UnshareTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareTask not implemented")
+}
+func (*UnimplementedToDoServiceServer) ListCollaborators(ctx context.Context, req *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollaborators not implemented")
+}
+
+func RegisterToDoServiceServer(s *grpc.Server, srv ToDoServiceServer) {
+	s.RegisterService(&_ToDoService_serviceDesc, srv)
+}
+
+func _ToDoService_ReadAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ReadAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ReadAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ReadAll(ctx, req.(*ReadAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Read",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Read(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ReadChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadChildrenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ReadChildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ReadChildren",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ReadChildren(ctx, req.(*ReadChildrenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_BatchCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).BatchCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/BatchCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).BatchCreate(ctx, req.(*BatchCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_BatchUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).BatchUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/BatchUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).BatchUpdate(ctx, req.(*BatchUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).BatchDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/BatchDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).BatchDelete(ctx, req.(*BatchDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ListDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ListDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ListDeleted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ListDeleted(ctx, req.(*ListDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Purge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Purge(ctx, req.(*PurgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Complete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Complete(ctx, req.(*CompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Reopen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReopenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Reopen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Reopen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Reopen(ctx, req.(*ReopenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ListOccurrences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOccurrencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ListOccurrences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ListOccurrences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ListOccurrences(ctx, req.(*ListOccurrencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ListTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/RenameTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/DeleteTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/AddTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/RemoveTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ToDoServiceServer).Watch(m, &toDoServiceWatchServer{stream})
+}
+
+type ToDoService_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type toDoServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *toDoServiceWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ToDoService_ListTaskHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ListTaskHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ListTaskHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ListTaskHistory(ctx, req.(*ListTaskHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/CreateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ListComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/UpdateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/DeleteComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_UploadAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).UploadAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/UploadAttachment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).UploadAttachment(ctx, req.(*UploadAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ListAttachments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_DownloadAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).DownloadAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/DownloadAttachment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).DownloadAttachment(ctx, req.(*DownloadAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/DeleteAttachment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).DeleteAttachment(ctx, req.(*DeleteAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/CreateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).CreateProject(ctx, req.(*CreateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ReadProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ReadProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ReadProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ReadProject(ctx, req.(*ReadProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ListProjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/UpdateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/DeleteProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).DeleteProject(ctx, req.(*DeleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_MoveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).MoveTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/MoveTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).MoveTask(ctx, req.(*MoveTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_Reorder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).Reorder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/Reorder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).Reorder(ctx, req.(*ReorderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ShareTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ShareTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ShareTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ShareTask(ctx, req.(*ShareTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_UnshareTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).UnshareTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/UnshareTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).UnshareTask(ctx, req.(*UnshareTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToDoService_ListCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToDoServiceServer).ListCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ToDoService/ListCollaborators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToDoServiceServer).ListCollaborators(ctx, req.(*ListCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ToDoService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.ToDoService",
+	HandlerType: (*ToDoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadAll",
+			Handler:    _ToDoService_ReadAll_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _ToDoService_Create_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _ToDoService_Read_Handler,
+		},
+		{
+			MethodName: "ReadChildren",
+			Handler:    _ToDoService_ReadChildren_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ToDoService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ToDoService_Delete_Handler,
+		},
+		{
+			MethodName: "BatchCreate",
+			Handler:    _ToDoService_BatchCreate_Handler,
+		},
+		{
+			MethodName: "BatchUpdate",
+			Handler:    _ToDoService_BatchUpdate_Handler,
+		},
+		{
+			MethodName: "BatchDelete",
+			Handler:    _ToDoService_BatchDelete_Handler,
+		},
+		{
+			MethodName: "ListDeleted",
+			Handler:    _ToDoService_ListDeleted_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _ToDoService_Restore_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _ToDoService_Purge_Handler,
+		},
+		{
+			MethodName: "Complete",
+			Handler:    _ToDoService_Complete_Handler,
+		},
+		{
+			MethodName: "Reopen",
+			Handler:    _ToDoService_Reopen_Handler,
+		},
+		{
+			MethodName: "ListOccurrences",
+			Handler:    _ToDoService_ListOccurrences_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _ToDoService_ListTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _ToDoService_RenameTag_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _ToDoService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _ToDoService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _ToDoService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _ToDoService_Search_Handler,
+		},
+		{
+			MethodName: "ListTaskHistory",
+			Handler:    _ToDoService_ListTaskHistory_Handler,
+		},
+		{
+			MethodName: "CreateComment",
+			Handler:    _ToDoService_CreateComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _ToDoService_ListComments_Handler,
+		},
+		{
+			MethodName: "UpdateComment",
+			Handler:    _ToDoService_UpdateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _ToDoService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "UploadAttachment",
+			Handler:    _ToDoService_UploadAttachment_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _ToDoService_ListAttachments_Handler,
+		},
+		{
+			MethodName: "DownloadAttachment",
+			Handler:    _ToDoService_DownloadAttachment_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _ToDoService_DeleteAttachment_Handler,
+		},
+		{
+			MethodName: "CreateProject",
+			Handler:    _ToDoService_CreateProject_Handler,
+		},
+		{
+			MethodName: "ReadProject",
+			Handler:    _ToDoService_ReadProject_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _ToDoService_ListProjects_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _ToDoService_UpdateProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _ToDoService_DeleteProject_Handler,
+		},
+		{
+			MethodName: "MoveTask",
+			Handler:    _ToDoService_MoveTask_Handler,
+		},
+		{
+			MethodName: "Reorder",
+			Handler:    _ToDoService_Reorder_Handler,
+		},
+		{
+			MethodName: "ShareTask",
+			Handler:    _ToDoService_ShareTask_Handler,
+		},
+		{
+			MethodName: "UnshareTask",
+			Handler:    _ToDoService_UnshareTask_Handler,
+		},
+		{
+			MethodName: "ListCollaborators",
+			Handler:    _ToDoService_ListCollaborators_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _ToDoService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "todo-service.proto",
+}
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	// Subscribe a URL to task events
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	// List webhooks
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// Delete a webhook with its deliveries
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	// List deliveries of a webhook
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/CreateWebhook", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *toDoServiceClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
-	out := new(ReadResponse)
-	err := c.cc.Invoke(ctx, "/v1.ToDoService/Read", in, out, opts...)
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/ListWebhooks", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *toDoServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
-	out := new(UpdateResponse)
-	err := c.cc.Invoke(ctx, "/v1.ToDoService/Update", in, out, opts...)
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/DeleteWebhook", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *toDoServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, "/v1.ToDoService/Delete", in, out, opts...)
+func (c *webhookServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/v1.WebhookService/ListWebhookDeliveries", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// ToDoServiceServer is the server API for ToDoService service.
-type ToDoServiceServer interface {
-	// Read all Tasks
-	ReadAll(context.Context, *ReadAllRequest) (*ReadAllResponse, error)
-	// Create a new task
-	Create(context.Context, *CreateRequest) (*CreateResponse, error)
-	// Read a task
-	Read(context.Context, *ReadRequest) (*ReadResponse, error)
-	// Update a task
-	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
-	// Delete a task
-	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+// WebhookServiceServer is the server API for WebhookService service.
+type WebhookServiceServer interface {
+	// Subscribe a URL to task events
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// List webhooks
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// Delete a webhook with its deliveries
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// List deliveries of a webhook
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
 }
 
-// UnimplementedToDoServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedToDoServiceServer struct {
+// UnimplementedWebhookServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWebhookServiceServer struct {
 }
 
-func (*UnimplementedToDoServiceServer) ReadAll(ctx context.Context, req *ReadAllRequest) (*ReadAllResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReadAll not implemented")
+func (*UnimplementedWebhookServiceServer) CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
 }
-func (*UnimplementedToDoServiceServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+func (*UnimplementedWebhookServiceServer) ListWebhooks(ctx context.Context, req *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
 }
-func (*UnimplementedToDoServiceServer) Read(ctx context.Context, req *ReadRequest) (*ReadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+func (*UnimplementedWebhookServiceServer) DeleteWebhook(ctx context.Context, req *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
 }
-func (*UnimplementedToDoServiceServer) Update(ctx context.Context, req *UpdateRequest) (*UpdateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+func (*UnimplementedWebhookServiceServer) ListWebhookDeliveries(ctx context.Context, req *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
 }
-func (*UnimplementedToDoServiceServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+
+func RegisterWebhookServiceServer(s *grpc.Server, srv WebhookServiceServer) {
+	s.RegisterService(&_WebhookService_serviceDesc, srv)
 }
 
-func RegisterToDoServiceServer(s *grpc.Server, srv ToDoServiceServer) {
-	s.RegisterService(&_ToDoService_serviceDesc, srv)
+func _WebhookService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WebhookService/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ToDoService_ReadAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadAllRequest)
+func _WebhookService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ToDoServiceServer).ReadAll(ctx, in)
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.ToDoService/ReadAll",
+		FullMethod: "/v1.WebhookService/ListWebhooks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ToDoServiceServer).ReadAll(ctx, req.(*ReadAllRequest))
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ToDoService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ToDoServiceServer).Create(ctx, in)
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.ToDoService/Create",
+		FullMethod: "/v1.WebhookService/DeleteWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ToDoServiceServer).Create(ctx, req.(*CreateRequest))
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ToDoService_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadRequest)
+func _WebhookService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ToDoServiceServer).Read(ctx, in)
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.ToDoService/Read",
+		FullMethod: "/v1.WebhookService/ListWebhookDeliveries",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ToDoServiceServer).Read(ctx, req.(*ReadRequest))
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ToDoService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRequest)
+var _WebhookService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _WebhookService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _WebhookService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _WebhookService_ListWebhookDeliveries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo-service.proto",
+}
+
+// ApiKeyServiceClient is the client API for ApiKeyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ApiKeyServiceClient interface {
+	// Mint an API key owned by the caller
+	CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error)
+	// List API keys of the caller
+	ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error)
+	// Revoke an API key of the caller, it is rejected from then on
+	RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*RevokeApiKeyResponse, error)
+}
+
+type apiKeyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewApiKeyServiceClient(cc grpc.ClientConnInterface) ApiKeyServiceClient {
+	return &apiKeyServiceClient{cc}
+}
+
+func (c *apiKeyServiceClient) CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error) {
+	out := new(CreateApiKeyResponse)
+	err := c.cc.Invoke(ctx, "/v1.ApiKeyService/CreateApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiKeyServiceClient) ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error) {
+	out := new(ListApiKeysResponse)
+	err := c.cc.Invoke(ctx, "/v1.ApiKeyService/ListApiKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiKeyServiceClient) RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*RevokeApiKeyResponse, error) {
+	out := new(RevokeApiKeyResponse)
+	err := c.cc.Invoke(ctx, "/v1.ApiKeyService/RevokeApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApiKeyServiceServer is the server API for ApiKeyService service.
+type ApiKeyServiceServer interface {
+	// Mint an API key owned by the caller
+	CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	// List API keys of the caller
+	ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error)
+	// Revoke an API key of the caller, it is rejected from then on
+	RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*RevokeApiKeyResponse, error)
+}
+
+// UnimplementedApiKeyServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedApiKeyServiceServer struct {
+}
+
+func (*UnimplementedApiKeyServiceServer) CreateApiKey(ctx context.Context, req *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiKey not implemented")
+}
+func (*UnimplementedApiKeyServiceServer) ListApiKeys(ctx context.Context, req *ListApiKeysRequest) (*ListApiKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiKeys not implemented")
+}
+func (*UnimplementedApiKeyServiceServer) RevokeApiKey(ctx context.Context, req *RevokeApiKeyRequest) (*RevokeApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeApiKey not implemented")
+}
+
+func RegisterApiKeyServiceServer(s *grpc.Server, srv ApiKeyServiceServer) {
+	s.RegisterService(&_ApiKeyService_serviceDesc, srv)
+}
+
+func _ApiKeyService_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ToDoServiceServer).Update(ctx, in)
+		return srv.(ApiKeyServiceServer).CreateApiKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.ToDoService/Update",
+		FullMethod: "/v1.ApiKeyService/CreateApiKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ToDoServiceServer).Update(ctx, req.(*UpdateRequest))
+		return srv.(ApiKeyServiceServer).CreateApiKey(ctx, req.(*CreateApiKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ToDoService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
+func _ApiKeyService_ListApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiKeysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ToDoServiceServer).Delete(ctx, in)
+		return srv.(ApiKeyServiceServer).ListApiKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.ToDoService/Delete",
+		FullMethod: "/v1.ApiKeyService/ListApiKeys",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ToDoServiceServer).Delete(ctx, req.(*DeleteRequest))
+		return srv.(ApiKeyServiceServer).ListApiKeys(ctx, req.(*ListApiKeysRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ToDoService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "v1.ToDoService",
-	HandlerType: (*ToDoServiceServer)(nil),
+func _ApiKeyService_RevokeApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiKeyServiceServer).RevokeApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ApiKeyService/RevokeApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiKeyServiceServer).RevokeApiKey(ctx, req.(*RevokeApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ApiKeyService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.ApiKeyService",
+	HandlerType: (*ApiKeyServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ReadAll",
-			Handler:    _ToDoService_ReadAll_Handler,
-		},
-		{
-			MethodName: "Create",
-			Handler:    _ToDoService_Create_Handler,
-		},
-		{
-			MethodName: "Read",
-			Handler:    _ToDoService_Read_Handler,
+			MethodName: "CreateApiKey",
+			Handler:    _ApiKeyService_CreateApiKey_Handler,
 		},
 		{
-			MethodName: "Update",
-			Handler:    _ToDoService_Update_Handler,
+			MethodName: "ListApiKeys",
+			Handler:    _ApiKeyService_ListApiKeys_Handler,
 		},
 		{
-			MethodName: "Delete",
-			Handler:    _ToDoService_Delete_Handler,
+			MethodName: "RevokeApiKey",
+			Handler:    _ApiKeyService_RevokeApiKey_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// authorizationMetadata is the metadata key of the bearer token, the HTTP gateway passes the Authorization header in it
+	authorizationMetadata = "authorization"
+	// apiKeyMetadata is the metadata key of the API key, the HTTP gateway passes the X-Api-Key header in it
+	apiKeyMetadata = "x-api-key"
+)
+
+// readMethods are prefixes of names of methods which only read data, the calls read-only API keys may make
+var readMethods = []string{"Read", "List", "Search", "Watch", "Download"}
+
+// ErrUnknownAPIKey is returned by APIKeyStore when there is no such key or it is revoked
+var ErrUnknownAPIKey = errors.New("unknown API key")
+
+// APIKey is what an API key authenticates
+type APIKey struct {
+	// Subject is who the key acts for
+	Subject string
+	// ReadOnly keys may only make calls reading data
+	ReadOnly bool
+}
+
+// APIKeyStore looks up API keys
+type APIKeyStore interface {
+	// LookupAPIKey returns the API key, ErrUnknownAPIKey if it is not valid
+	LookupAPIKey(ctx context.Context, key string) (APIKey, error)
+}
+
+// Authenticator authenticates calls by bearer token or API key
+type Authenticator struct {
+	verifier *Verifier
+	keys     APIKeyStore
+}
+
+// NewAuthenticator creates authenticator accepting bearer tokens checked by verifier and API keys found in keys,
+// either may be nil to reject that kind of credentials
+func NewAuthenticator(verifier *Verifier, keys APIKeyStore) *Authenticator {
+	return &Authenticator{verifier: verifier, keys: keys}
+}
+
+// subjectKey is the context key of the verified subject
+type subjectKey struct{}
+
+// NewContext returns a copy of ctx carrying the verified subject
+func NewContext(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+// Subject returns the verified subject of the request, false if the request is not authenticated
+func Subject(ctx context.Context) (string, bool) {
+	subject, ok := ctx.Value(subjectKey{}).(string)
+	return subject, ok
+}
+
+// readMethod reports whether the gRPC method only reads data
+func readMethod(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range readMethods {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate checks the API key or the bearer token of the call to method and returns ctx carrying its subject
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get(apiKeyMetadata); len(keys) > 0 {
+		return a.authenticateKey(ctx, keys[0], method)
+	}
+	if a.verifier == nil {
+		return nil, status.Error(codes.Unauthenticated, "API key is missing")
+	}
+	values := md.Get(authorizationMetadata)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization bearer token is missing")
+	}
+	fields := strings.Fields(values[0])
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "Bearer") {
+		return nil, status.Error(codes.Unauthenticated, "authorization is not a bearer token")
+	}
+	claims, err := a.verifier.Verify(fields[1])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "failed to verify token-> "+err.Error())
+	}
+	return NewContext(ctx, claims.Subject), nil
+}
+
+// authenticateKey looks up the API key and returns ctx carrying its subject
+// or PermissionDenied error if the key is read-only and method writes data
+func (a *Authenticator) authenticateKey(ctx context.Context, key, method string) (context.Context, error) {
+	if a.keys == nil {
+		return nil, status.Error(codes.Unauthenticated, "API keys are not accepted")
+	}
+	k, err := a.keys.LookupAPIKey(ctx, key)
+	if err == ErrUnknownAPIKey {
+		return nil, status.Error(codes.Unauthenticated, "API key is not valid")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if k.ReadOnly && !readMethod(method) {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("API key is read-only, %s is not allowed", method))
+	}
+	return NewContext(ctx, k.Subject), nil
+}
+
+// UnaryServerInterceptor returns interceptor rejecting calls without valid credentials
+func UnaryServerInterceptor(a *Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// serverStream is a server stream with the authenticated context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor returns interceptor rejecting streams without valid credentials
+func StreamServerInterceptor(a *Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
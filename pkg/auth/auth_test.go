@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var secret = []byte("secret")
+
+// sign returns a token with the header and claims signed by HS256 secret or RS256 private key
+func sign(t *testing.T, h header, claims map[string]interface{}, key interface{}) string {
+	t.Helper()
+	hb, _ := json.Marshal(h)
+	cb, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(hb) + "." + base64.RawURLEncoding.EncodeToString(cb)
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	exp := now.Add(time.Hour).Unix()
+	v := NewVerifier([]Key{{Secret: secret}, {ID: "rsa", Public: &private.PublicKey}}, "issuer", "todo")
+	v.now = func() time.Time { return now }
+	claims := func(extra map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{"sub": "alice", "iss": "issuer", "aud": "todo", "exp": exp}
+		for k, val := range extra {
+			if val == nil {
+				delete(c, k)
+			} else {
+				c[k] = val
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{name: "HS256", token: sign(t, header{Alg: "HS256"}, claims(nil), secret), want: "alice"},
+		{name: "RS256", token: sign(t, header{Alg: "RS256", Kid: "rsa"}, claims(nil), private), want: "alice"},
+		{name: "Audience list", token: sign(t, header{Alg: "HS256"}, claims(map[string]interface{}{"aud": []string{"other", "todo"}}), secret), want: "alice"},
+		{name: "Expired within clock skew", token: sign(t, header{Alg: "HS256"}, claims(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()}), secret), want: "alice"},
+		{name: "Expired", token: sign(t, header{Alg: "HS256"}, claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()}), secret), wantErr: true},
+		{name: "No expiry", token: sign(t, header{Alg: "HS256"}, claims(map[string]interface{}{"exp": nil}), secret), wantErr: true},
+		{name: "Not valid yet", token: sign(t, header{Alg: "HS256"}, claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()}), secret), wantErr: true},
+		{name: "Wrong secret", token: sign(t, header{Alg: "HS256"}, claims(nil), []byte("other")), wantErr: true},
+		{name: "Unknown kid", token: sign(t, header{Alg: "RS256", Kid: "other"}, claims(nil), private), wantErr: true},
+		{name: "Unsigned", token: sign(t, header{Alg: "none"}, claims(nil), nil), wantErr: true},
+		{name: "Wrong issuer", token: sign(t, header{Alg: "HS256"}, claims(map[string]interface{}{"iss": "other"}), secret), wantErr: true},
+		{name: "Wrong audience", token: sign(t, header{Alg: "HS256"}, claims(map[string]interface{}{"aud": "other"}), secret), wantErr: true},
+		{name: "No subject", token: sign(t, header{Alg: "HS256"}, claims(map[string]interface{}{"sub": nil}), secret), wantErr: true},
+		{name: "Malformed", token: "not a token", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Subject != tt.want {
+				t.Errorf("Verify() subject = %v, want %v", got.Subject, tt.want)
+			}
+		})
+	}
+
+	// an RSA public key must not be usable as an HMAC secret
+	der := x509.MarshalPKCS1PublicKey(&private.PublicKey)
+	forged := sign(t, header{Alg: "HS256", Kid: "rsa"}, claims(nil), pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: der}))
+	if _, err := v.Verify(forged); err == nil {
+		t.Errorf("Verify() accepted HS256 token signed with the public key")
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	keys, err := LoadKeyFile(write("secret", []byte("secret\n")))
+	if err != nil || len(keys) != 1 || string(keys[0].Secret) != "secret" {
+		t.Errorf("LoadKeyFile() of secret = %v, %v", keys, err)
+	}
+	keys, err = LoadKeyFile(write("public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	if err != nil || len(keys) != 1 || keys[0].Public == nil || keys[0].Public.N.Cmp(private.N) != 0 {
+		t.Errorf("LoadKeyFile() of public key = %v, %v", keys, err)
+	}
+	if _, err := LoadKeyFile(write("empty", []byte("\n"))); err == nil {
+		t.Errorf("LoadKeyFile() accepted empty file")
+	}
+
+	jwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "n": base64.RawURLEncoding.EncodeToString(private.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(private.E)).Bytes())},
+		{"kty": "oct", "kid": "hmac", "k": base64.RawURLEncoding.EncodeToString(secret)},
+		{"kty": "RSA", "kid": "enc", "use": "enc"},
+		{"kty": "EC", "kid": "ec"},
+	}})
+	keys, err = LoadJWKSFile(write("jwks.json", jwks))
+	if err != nil {
+		t.Fatalf("LoadJWKSFile() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "rsa" || keys[0].Public.E != private.E || keys[0].Public.N.Cmp(private.N) != 0 ||
+		keys[1].ID != "hmac" || string(keys[1].Secret) != "secret" {
+		t.Errorf("LoadJWKSFile() = %v", keys)
+	}
+	if _, err := LoadJWKSFile(write("none.json", []byte(`{"keys":[]}`))); err == nil {
+		t.Errorf("LoadJWKSFile() accepted set without keys")
+	}
+}
+
+// apiKeys is an APIKeyStore of keys by their values
+type apiKeys map[string]APIKey
+
+// LookupAPIKey returns the key or ErrUnknownAPIKey
+func (s apiKeys) LookupAPIKey(ctx context.Context, key string) (APIKey, error) {
+	k, ok := s[key]
+	if !ok {
+		return APIKey{}, ErrUnknownAPIKey
+	}
+	return k, nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	v := NewVerifier([]Key{{Secret: secret}}, "", "")
+	keys := apiKeys{"rw": {Subject: "cron"}, "ro": {Subject: "report", ReadOnly: true}}
+	token := sign(t, header{Alg: "HS256"}, map[string]interface{}{"sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}, secret)
+	interceptor := UnaryServerInterceptor(NewAuthenticator(v, keys))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		subject, _ := Subject(ctx)
+		return subject, nil
+	}
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		method   string
+		want     string
+		wantCode codes.Code
+	}{
+		{name: "Bearer token", md: metadata.Pairs("authorization", "Bearer "+token), want: "alice"},
+		{name: "Lower case scheme", md: metadata.Pairs("authorization", "bearer "+token), want: "alice"},
+		{name: "Missing", md: metadata.MD{}, wantCode: codes.Unauthenticated},
+		{name: "Basic", md: metadata.Pairs("authorization", "Basic YWxpY2U6cGFzcw=="), wantCode: codes.Unauthenticated},
+		{name: "Invalid token", md: metadata.Pairs("authorization", "Bearer "+token+"x"), wantCode: codes.Unauthenticated},
+		{name: "Read-write API key", md: metadata.Pairs("x-api-key", "rw"), method: "/v1.ToDoService/Delete", want: "cron"},
+		{name: "Read-only API key reads", md: metadata.Pairs("x-api-key", "ro"), method: "/v1.ToDoService/ReadAll", want: "report"},
+		{name: "Read-only API key writes", md: metadata.Pairs("x-api-key", "ro"), method: "/v1.ToDoService/Reorder", wantCode: codes.PermissionDenied},
+		{name: "Unknown API key", md: metadata.Pairs("x-api-key", "other"), wantCode: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			got, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("interceptor error = %v, want code %v", err, tt.wantCode)
+				return
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("interceptor subject = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_withoutVerifier(t *testing.T) {
+	interceptor := UnaryServerInterceptor(NewAuthenticator(nil, apiKeys{}))
+	token := sign(t, header{Alg: "HS256"}, map[string]interface{}{"sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}, secret)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("interceptor error = %v, want code %v", err, codes.Unauthenticated)
+	}
+}
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// clockSkew is how far clocks of the token issuer and the server may differ
+const clockSkew = time.Minute
+
+var (
+	// ErrInvalidToken is returned by Verifier when a token is malformed or its signature does not match
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrExpiredToken is returned by Verifier when a token is expired or not valid yet
+	ErrExpiredToken = errors.New("token is expired or not valid yet")
+)
+
+// header is the JOSE header of a token
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// audience is the aud claim, a single string or a list of them
+type audience []string
+
+// UnmarshalJSON reads aud claim
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// Claims are the registered claims of a token checked by Verifier
+type Claims struct {
+	// Subject is who the token was issued to
+	Subject string `json:"sub"`
+	// Issuer is who issued the token
+	Issuer string `json:"iss"`
+	// Audience is who the token is intended for
+	Audience audience `json:"aud"`
+	// ExpiresAt is the Unix time the token expires at
+	ExpiresAt *float64 `json:"exp"`
+	// NotBefore is the Unix time the token is valid from, 0 if not set
+	NotBefore float64 `json:"nbf"`
+}
+
+// Verifier checks signatures and claims of HS256 and RS256 JSON Web Tokens
+type Verifier struct {
+	keys     []Key
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewVerifier creates verifier accepting tokens signed with one of keys.
+// Tokens must be issued by issuer and intended for audience, unless they are empty.
+func NewVerifier(keys []Key, issuer, audience string) *Verifier {
+	return &Verifier{keys: keys, issuer: issuer, audience: audience, now: time.Now}
+}
+
+// Verify checks the token and returns its claims
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if !v.verifySignature(h, parts[0]+"."+parts[1], sig) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if len(claims.Subject) == 0 {
+		return nil, fmt.Errorf("%v: sub claim is missing", ErrInvalidToken)
+	}
+	now := v.now()
+	if claims.ExpiresAt == nil || now.After(unixTime(*claims.ExpiresAt).Add(clockSkew)) {
+		return nil, ErrExpiredToken
+	}
+	if now.Add(clockSkew).Before(unixTime(claims.NotBefore)) {
+		return nil, ErrExpiredToken
+	}
+	if len(v.issuer) > 0 && claims.Issuer != v.issuer {
+		return nil, fmt.Errorf("%v: issuer '%s' is not accepted", ErrInvalidToken, claims.Issuer)
+	}
+	if len(v.audience) > 0 && !claims.Audience.contains(v.audience) {
+		return nil, fmt.Errorf("%v: token is not intended for '%s'", ErrInvalidToken, v.audience)
+	}
+	return &claims, nil
+}
+
+// verifySignature reports whether sig is a signature of signed by a key matching the header.
+// The algorithm must match the key type so that a public key is never used as an HMAC secret.
+func (v *Verifier) verifySignature(h header, signed string, sig []byte) bool {
+	digest := sha256.Sum256([]byte(signed))
+	for _, k := range v.keys {
+		if len(k.ID) > 0 && len(h.Kid) > 0 && k.ID != h.Kid {
+			continue
+		}
+		switch {
+		case h.Alg == "HS256" && len(k.Secret) > 0:
+			mac := hmac.New(sha256.New, k.Secret)
+			mac.Write([]byte(signed))
+			if hmac.Equal(sig, mac.Sum(nil)) {
+				return true
+			}
+		case h.Alg == "RS256" && k.Public != nil:
+			if rsa.VerifyPKCS1v15(k.Public, crypto.SHA256, digest[:], sig) == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contains reports whether aud is one of the audiences
+func (a audience) contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token into v
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// unixTime converts a NumericDate claim to time
+func unixTime(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
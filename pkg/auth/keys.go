@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+)
+
+// Key is a key tokens are signed with
+type Key struct {
+	// ID matches the kid header of tokens, a key without ID matches any token
+	ID string
+	// Secret is the shared key of HS256 tokens
+	Secret []byte
+	// Public is the public key of RS256 tokens
+	Public *rsa.PublicKey
+}
+
+// LoadKeyFile reads a key from file. A PEM encoded RSA public key or certificate is used for RS256 tokens,
+// any other content is the HS256 secret, surrounding white space is trimmed.
+func LoadKeyFile(path string) ([]Key, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		secret := bytes.TrimSpace(data)
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("key file '%s' is empty", path)
+		}
+		return []Key{{Secret: secret}}, nil
+	}
+	public, err := parsePublicKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("key file '%s': %v", path, err)
+	}
+	return []Key{{Public: public}}, nil
+}
+
+// parsePublicKey parses RSA public key from PEM block
+func parsePublicKey(block *pem.Block) (*rsa.PublicKey, error) {
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		public, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("public key is not an RSA key")
+		}
+		return public, nil
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		public, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("certificate key is not an RSA key")
+		}
+		return public, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block '%s'", block.Type)
+}
+
+// jwk is a JSON Web Key
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// N and E are the modulus and exponent of RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+	// K is the secret of symmetric keys
+	K string `json:"k"`
+}
+
+// LoadJWKSFile reads keys from a JSON Web Key Set file. RSA keys verify RS256 tokens and
+// symmetric keys HS256 ones, keys of other types or not used for signatures are skipped.
+func LoadJWKSFile(path string) ([]Key, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("JWKS file '%s': %v", path, err)
+	}
+	var keys []Key
+	for _, k := range set.Keys {
+		if len(k.Use) > 0 && k.Use != "sig" {
+			continue
+		}
+		switch k.Kty {
+		case "RSA":
+			public, err := k.rsaKey()
+			if err != nil {
+				return nil, fmt.Errorf("JWKS file '%s': key '%s': %v", path, k.Kid, err)
+			}
+			keys = append(keys, Key{ID: k.Kid, Public: public})
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil || len(secret) == 0 {
+				return nil, fmt.Errorf("JWKS file '%s': key '%s' has invalid secret", path, k.Kid)
+			}
+			keys = append(keys, Key{ID: k.Kid, Secret: secret})
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS file '%s' has no signing keys", path)
+	}
+	return keys, nil
+}
+
+// rsaKey returns the RSA public key of the JWK
+func (k jwk) rsaKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid modulus")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
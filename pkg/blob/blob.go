@@ -0,0 +1,19 @@
+package blob
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by Store when there is no blob with the key
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps contents of attachments by key
+type Store interface {
+	// Put stores data under key, replacing the blob stored under it before
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns data stored under key, ErrNotFound if there is none
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes data stored under key, it does nothing if there is none
+	Delete(ctx context.Context, key string) error
+}
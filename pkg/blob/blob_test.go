@@ -0,0 +1,52 @@
+package blob
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "blob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	if _, err := s.Get(ctx, "1"); err != ErrNotFound {
+		t.Errorf("Get() of missing blob error = %v, want %v", err, ErrNotFound)
+	}
+	if err := s.Put(ctx, "1", []byte("first")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := s.Put(ctx, "1", []byte("second")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, err := s.Get(ctx, "1")
+	if err != nil || !reflect.DeepEqual(got, []byte("second")) {
+		t.Errorf("Get() = %q, %v, want %q", got, err, "second")
+	}
+	if err := s.Delete(ctx, "1"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if err := s.Delete(ctx, "1"); err != nil {
+		t.Errorf("Delete() of missing blob error = %v", err)
+	}
+	if _, err := s.Get(ctx, "1"); err != ErrNotFound {
+		t.Errorf("Get() of deleted blob error = %v, want %v", err, ErrNotFound)
+	}
+
+	for _, key := range []string{"", "..", "../1", "a/b"} {
+		if err := s.Put(ctx, key, nil); err == nil {
+			t.Errorf("Put() accepted invalid key %q", key)
+		}
+	}
+}
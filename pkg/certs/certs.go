@@ -0,0 +1,170 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// nextProtos are the ALPN protocols servers negotiate, h2 for gRPC and the HTTP gateway
+var nextProtos = []string{"h2", "http/1.1"}
+
+// Files are the PEM files of a TLS endpoint
+type Files struct {
+	// Cert is the certificate presented to peers, Key is its private key, both are empty if there is none
+	Cert, Key string
+	// CA is the bundle peer certificates are verified with, empty if servers are verified with
+	// the system roots and clients need not present certificates
+	CA string
+}
+
+// fileState is what a file looked like when it was loaded
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Reloader keeps the certificate and the CA bundle of Files, reloading them when the files change
+type Reloader struct {
+	files Files
+
+	mu     sync.Mutex
+	states []fileState
+	cert   *tls.Certificate
+	pool   *x509.CertPool
+}
+
+// NewReloader creates reloader of files, it fails if they can't be loaded
+func NewReloader(files Files) (*Reloader, error) {
+	if (len(files.Cert) == 0) != (len(files.Key) == 0) {
+		return nil, errors.New("certificate and key files must be set together")
+	}
+	r := &Reloader{files: files}
+	states, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(states); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// stat returns the states of the files
+func (r *Reloader) stat() ([]fileState, error) {
+	var states []fileState
+	for _, path := range []string{r.files.Cert, r.files.Key, r.files.CA} {
+		if len(path) == 0 {
+			states = append(states, fileState{})
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, fileState{modTime: fi.ModTime(), size: fi.Size()})
+	}
+	return states, nil
+}
+
+// load reads the files which had states
+func (r *Reloader) load(states []fileState) error {
+	var cert *tls.Certificate
+	if len(r.files.Cert) > 0 {
+		c, err := tls.LoadX509KeyPair(r.files.Cert, r.files.Key)
+		if err != nil {
+			return fmt.Errorf("failed to load certificate '%s': %v", r.files.Cert, err)
+		}
+		cert = &c
+	}
+	var pool *x509.CertPool
+	if len(r.files.CA) > 0 {
+		data, err := ioutil.ReadFile(r.files.CA)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("CA file '%s' has no certificates", r.files.CA)
+		}
+	}
+	r.states, r.cert, r.pool = states, cert, pool
+	return nil
+}
+
+// current returns the certificate and the CA pool, reloading them if any of the files changed.
+// Files which fail to load, e.g. while they are being replaced, are tried again on the next call
+// and the ones loaded before are used meanwhile.
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	states, err := r.stat()
+	if err == nil && !sameStates(states, r.states) {
+		err = r.load(states)
+	}
+	if err != nil {
+		log.Printf("failed to reload TLS files, using the ones loaded before: %v", err)
+	}
+	return r.cert, r.pool
+}
+
+// sameStates reports whether no file changed between states a and b
+func sameStates(a, b []fileState) bool {
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+	return true
+}
+
+// ServerConfig returns config of servers presenting the current certificate, the reloader must have one.
+// Clients must present certificates signed by the CA bundle if there is one.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// http.Server refuses configs without certificates, the handshake uses GetConfigForClient
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := r.current()
+			if cert == nil {
+				return nil, errors.New("server has no certificate")
+			}
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				NextProtos:   nextProtos,
+			}
+			if pool != nil {
+				c.ClientCAs = pool
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return c, nil
+		},
+	}
+}
+
+// clientConfig returns config of clients presenting the current certificate if there is one
+// and verifying serverName with the CA bundle, or with the system roots if there is none
+func (r *Reloader) clientConfig(serverName string) *tls.Config {
+	cert, pool := r.current()
+	c := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		RootCAs:    pool,
+	}
+	if cert != nil {
+		c.Certificates = []tls.Certificate{*cert}
+	}
+	return c
+}
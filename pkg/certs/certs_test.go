@@ -0,0 +1,196 @@
+package certs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// issuer signs test certificates
+type issuer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newIssuer creates a self-signed CA and writes it to path
+func newIssuer(t *testing.T, path string) *issuer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	writePEM(t, path, "CERTIFICATE", der)
+	return &issuer{cert: cert, key: key}
+}
+
+// issue writes a certificate for localhost with serial to certPath and its key to keyPath
+func (i *issuer) issue(t *testing.T, serial int64, certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, i.cert, &key.PublicKey, i.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, certPath, "CERTIFICATE", der)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+}
+
+// writePEM writes the PEM block to path, marking it modified later than before
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+	modTime := time.Now()
+	if fi, err := os.Stat(path); err == nil {
+		modTime = fi.ModTime().Add(time.Second)
+	}
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake connects client to server over loopback, reads a byte the server sends and returns the serial of the server certificate
+func handshake(t *testing.T, server, client credentials.TransportCredentials) (int64, error) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if conn, _, err := server.ServerHandshake(conn); err == nil {
+			_, _ = conn.Write([]byte{1})
+		}
+	}()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tlsConn, info, err := client.ClientHandshake(ctx, "localhost:443", conn)
+	if err != nil {
+		return 0, err
+	}
+	// TLS 1.3 servers reject client certificates after the client finished the handshake
+	if _, err := tlsConn.Read(make([]byte, 1)); err != nil {
+		return 0, err
+	}
+	return info.(credentials.TLSInfo).State.PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func TestReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	ca := newIssuer(t, path("ca.pem"))
+	ca.issue(t, 10, path("server.pem"), path("server-key.pem"))
+	ca.issue(t, 20, path("client.pem"), path("client-key.pem"))
+	other := newIssuer(t, path("other-ca.pem"))
+	other.issue(t, 30, path("other.pem"), path("other-key.pem"))
+
+	newReloader := func(files Files) *Reloader {
+		t.Helper()
+		r, err := NewReloader(files)
+		if err != nil {
+			t.Fatalf("NewReloader() error = %v", err)
+		}
+		return r
+	}
+	server := NewServerCredentials(newReloader(Files{Cert: path("server.pem"), Key: path("server-key.pem"), CA: path("ca.pem")}))
+	client := NewClientCredentials(newReloader(Files{Cert: path("client.pem"), Key: path("client-key.pem"), CA: path("ca.pem")}), "")
+
+	if serial, err := handshake(t, server, client); err != nil || serial != 10 {
+		t.Errorf("handshake() = %d, %v, want %d", serial, err, 10)
+	}
+	noCert := NewClientCredentials(newReloader(Files{CA: path("ca.pem")}), "")
+	if _, err := handshake(t, server, noCert); err == nil {
+		t.Errorf("handshake() without client certificate succeeded")
+	}
+	untrusted := NewClientCredentials(newReloader(Files{Cert: path("other.pem"), Key: path("other-key.pem"), CA: path("ca.pem")}), "")
+	if _, err := handshake(t, server, untrusted); err == nil {
+		t.Errorf("handshake() with client certificate of other CA succeeded")
+	}
+	wrongName := NewClientCredentials(newReloader(Files{Cert: path("client.pem"), Key: path("client-key.pem"), CA: path("ca.pem")}), "example.com")
+	if _, err := handshake(t, server, wrongName); err == nil {
+		t.Errorf("handshake() with wrong server name succeeded")
+	}
+
+	// replaced certificate is used by the next handshake
+	ca.issue(t, 11, path("server.pem"), path("server-key.pem"))
+	if serial, err := handshake(t, server, client); err != nil || serial != 11 {
+		t.Errorf("handshake() after reload = %d, %v, want %d", serial, err, 11)
+	}
+
+	// broken certificate is not used until it is fixed
+	writePEM(t, path("server.pem"), "CERTIFICATE", []byte("broken"))
+	if serial, err := handshake(t, server, client); err != nil || serial != 11 {
+		t.Errorf("handshake() with broken file = %d, %v, want %d", serial, err, 11)
+	}
+	ca.issue(t, 12, path("server.pem"), path("server-key.pem"))
+	if serial, err := handshake(t, server, client); err != nil || serial != 12 {
+		t.Errorf("handshake() after fix = %d, %v, want %d", serial, err, 12)
+	}
+
+	if _, err := NewReloader(Files{Cert: path("server.pem")}); err == nil {
+		t.Errorf("NewReloader() accepted certificate without key")
+	}
+	if _, err := NewReloader(Files{CA: path("missing.pem")}); err == nil {
+		t.Errorf("NewReloader() accepted missing CA file")
+	}
+}
@@ -0,0 +1,54 @@
+package certs
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// clientCredentials are gRPC transport credentials of clients handshaking with the current files of the reloader.
+// gRPC TLS credentials copy their config, so the CA bundle they verify servers with could not be reloaded.
+type clientCredentials struct {
+	r          *Reloader
+	serverName string
+}
+
+// NewClientCredentials creates gRPC credentials of clients using the files of r,
+// servers are verified by serverName, or by the host they are dialed at if it is empty
+func NewClientCredentials(r *Reloader, serverName string) credentials.TransportCredentials {
+	return &clientCredentials{r: r, serverName: serverName}
+}
+
+// NewServerCredentials creates gRPC credentials of servers using the files of r
+func NewServerCredentials(r *Reloader) credentials.TransportCredentials {
+	return credentials.NewTLS(r.ServerConfig())
+}
+
+// ClientHandshake does the TLS handshake with the server
+func (c *clientCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.r.clientConfig(c.serverName)).ClientHandshake(ctx, authority, conn)
+}
+
+// ServerHandshake fails, the credentials are for clients only
+func (c *clientCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("client credentials can't be used by servers")
+}
+
+// Info returns the protocol of the credentials
+func (c *clientCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls", SecurityVersion: "1.2", ServerName: c.serverName}
+}
+
+// Clone returns a copy of the credentials
+func (c *clientCredentials) Clone() credentials.TransportCredentials {
+	clone := *c
+	return &clone
+}
+
+// OverrideServerName sets the name servers are verified by
+func (c *clientCredentials) OverrideServerName(serverName string) error {
+	c.serverName = serverName
+	return nil
+}
@@ -1,14 +1,23 @@
 package cmd
 
 import (
+	"crypto/tls"
 	"database/sql"
 	"fmt"
 	"flag"
 	"context"
+	"strings"
+	"time"
 
 	// mysql driver
 	_ "github.com/go-sql-driver/mysql"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/blob"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/certs"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/notify"
 	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/protocol/grpc"
 	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/protocol/rest"
 	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/service/v1"
@@ -19,10 +28,28 @@ type Config struct {
 	// gRPC Server start up parameters section
 	// GRPCPort is TCP port to listen on by gRPC Server
 	GRPCPort string
+	// GRPCCertFile is the PEM certificate of gRPC server, it listens in plaintext if empty
+	GRPCCertFile string
+	// GRPCKeyFile is the PEM private key of GRPCCertFile
+	GRPCKeyFile string
+	// GRPCClientCAFile is the PEM CA bundle gRPC clients must present certificates signed by, any client may connect if empty
+	GRPCClientCAFile string
 
 	// HTTP/REST start up parameters section
 	// HTTPPort is the TCP port to listen on by HTTP/REST gateway
 	HTTPPort string
+	// HTTPCertFile is the PEM certificate of HTTP/REST gateway, it listens in plaintext if empty
+	HTTPCertFile string
+	// HTTPKeyFile is the PEM private key of HTTPCertFile
+	HTTPKeyFile string
+	// HTTPClientCAFile is the PEM CA bundle HTTP clients must present certificates signed by, any client may connect if empty
+	HTTPClientCAFile string
+	// GatewayCertFile is the PEM client certificate HTTP/REST gateway presents to gRPC server
+	GatewayCertFile string
+	// GatewayKeyFile is the PEM private key of GatewayCertFile
+	GatewayKeyFile string
+	// GatewayCAFile is the PEM CA bundle HTTP/REST gateway verifies gRPC server with, the system roots if empty
+	GatewayCAFile string
 
 	// DB Datastore parameters section
 	// DatastoreDBHost is database host
@@ -33,6 +60,128 @@ type Config struct {
 	DatastoreDBPassword string
 	// DatastoreDBSchema is the database schema
 	DatastoreDBSchema string
+
+	// Trash parameters section
+	// TrashRetention is how long deleted tasks are kept in trash before they are purged, 0 keeps them forever
+	TrashRetention time.Duration
+
+	// Reminder parameters section
+	// ReminderNotifiers is comma separated list of notifiers delivering due reminders: log, webhook, stream
+	ReminderNotifiers string
+	// ReminderWebhookURL is the URL reminders are posted to by the webhook notifier
+	ReminderWebhookURL string
+
+	// Attachment parameters section
+	// AttachmentDir is the directory contents of attachments are stored in
+	AttachmentDir string
+	// AttachmentMaxSize is the largest attachment in bytes
+	AttachmentMaxSize int64
+	// AttachmentTypes is comma separated list of content types attachments may have, "image/*" allows all images
+	AttachmentTypes string
+
+	// Authentication parameters section
+	// AuthKeyFile is the file with the HS256 secret or the PEM encoded RSA public key tokens are signed with
+	AuthKeyFile string
+	// AuthJWKSFile is the JSON Web Key Set file with keys tokens are signed with
+	AuthJWKSFile string
+	// AuthIssuer is the iss claim tokens must have, any issuer if empty
+	AuthIssuer string
+	// AuthAudience is the aud claim tokens must have, any audience if empty
+	AuthAudience string
+	// AuthDisabled turns authentication off, anyone who reaches the server can call it
+	AuthDisabled bool
+	// Admins is comma separated list of subjects allowed to reach tasks of all owners
+	Admins string
+}
+
+// messageOverhead is the room left in gRPC messages for the fields around attachment contents
+const messageOverhead = 64 * 1024
+
+// newNotifiers creates the notifiers listed in config delivering reminders
+func newNotifiers(cfg Config, db *sql.DB) ([]notify.Named, error) {
+	var notifiers []notify.Named
+	for _, name := range strings.Split(cfg.ReminderNotifiers, ",") {
+		name = strings.TrimSpace(name)
+		var n notify.Notifier
+		switch name {
+		case "":
+			continue
+		case "log":
+			n = notify.NewLogNotifier()
+		case "webhook":
+			if len(cfg.ReminderWebhookURL) == 0 {
+				return nil, fmt.Errorf("webhook reminder notifier requires reminder webhook URL")
+			}
+			n = notify.NewWebhookNotifier(cfg.ReminderWebhookURL)
+		case "stream":
+			n = v1.NewWatchNotifier(db)
+		default:
+			return nil, fmt.Errorf("invalid reminder notifier: '%s'", name)
+		}
+		for _, other := range notifiers {
+			if other.Name == name {
+				return nil, fmt.Errorf("duplicate reminder notifier: '%s'", name)
+			}
+		}
+		notifiers = append(notifiers, notify.Named{Name: name, Notifier: n})
+	}
+	return notifiers, nil
+}
+
+// newAuthenticator creates authenticator accepting API keys and bearer tokens signed with keys from the files in config,
+// only API keys if there are no key files, it returns nil if authentication is disabled
+func newAuthenticator(cfg Config, db *sql.DB) (*auth.Authenticator, error) {
+	if cfg.AuthDisabled {
+		return nil, nil
+	}
+	var keys []auth.Key
+	if len(cfg.AuthKeyFile) > 0 {
+		k, err := auth.LoadKeyFile(cfg.AuthKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auth key: %v", err)
+		}
+		keys = append(keys, k...)
+	}
+	if len(cfg.AuthJWKSFile) > 0 {
+		k, err := auth.LoadJWKSFile(cfg.AuthJWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auth JWKS: %v", err)
+		}
+		keys = append(keys, k...)
+	}
+	// without keys only API keys are accepted
+	var verifier *auth.Verifier
+	if len(keys) > 0 {
+		verifier = auth.NewVerifier(keys, cfg.AuthIssuer, cfg.AuthAudience)
+	}
+	return auth.NewAuthenticator(verifier, v1.NewApiKeyStore(db)), nil
+}
+
+// newServerTLS creates reloader of the certificate of a listener, it returns nil if the listener is plaintext
+func newServerTLS(certFile, keyFile, clientCAFile string) (*certs.Reloader, error) {
+	if len(certFile) == 0 {
+		if len(clientCAFile) > 0 {
+			return nil, fmt.Errorf("client CA file requires certificate file")
+		}
+		return nil, nil
+	}
+	return certs.NewReloader(certs.Files{Cert: certFile, Key: keyFile, CA: clientCAFile})
+}
+
+// newGatewayCredentials creates credentials HTTP gateway dials gRPC server with, it returns nil if gRPC server is plaintext
+func newGatewayCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	if len(cfg.GRPCCertFile) == 0 {
+		return nil, nil
+	}
+	if len(cfg.GRPCClientCAFile) > 0 && len(cfg.GatewayCertFile) == 0 {
+		return nil, fmt.Errorf("gRPC client CA file requires gateway certificate file")
+	}
+	r, err := certs.NewReloader(certs.Files{Cert: cfg.GatewayCertFile, Key: cfg.GatewayKeyFile, CA: cfg.GatewayCAFile})
+	if err != nil {
+		return nil, err
+	}
+	// the gateway dials localhost
+	return certs.NewClientCredentials(r, ""), nil
 }
 
 // RunServer runs gRPC server  and HTTP gateway
@@ -43,10 +192,31 @@ func RunServer() error {
 	var cfg Config
 	flag.StringVar(&cfg.GRPCPort, "grpc-port", "", "gRPC port to bind")
 	flag.StringVar(&cfg.HTTPPort, "http-port", "", "HTTP port to bind")
+	flag.StringVar(&cfg.GRPCCertFile, "grpc-cert-file", "", "PEM certificate of gRPC server, plaintext if empty")
+	flag.StringVar(&cfg.GRPCKeyFile, "grpc-key-file", "", "PEM private key of gRPC server")
+	flag.StringVar(&cfg.GRPCClientCAFile, "grpc-client-ca-file", "", "PEM CA bundle gRPC client certificates must be signed by")
+	flag.StringVar(&cfg.HTTPCertFile, "http-cert-file", "", "PEM certificate of HTTP gateway, plaintext if empty")
+	flag.StringVar(&cfg.HTTPKeyFile, "http-key-file", "", "PEM private key of HTTP gateway")
+	flag.StringVar(&cfg.HTTPClientCAFile, "http-client-ca-file", "", "PEM CA bundle HTTP client certificates must be signed by")
+	flag.StringVar(&cfg.GatewayCertFile, "gateway-cert-file", "", "PEM client certificate HTTP gateway presents to gRPC server")
+	flag.StringVar(&cfg.GatewayKeyFile, "gateway-key-file", "", "PEM private key of HTTP gateway client certificate")
+	flag.StringVar(&cfg.GatewayCAFile, "gateway-ca-file", "", "PEM CA bundle HTTP gateway verifies gRPC server certificate for localhost with")
 	flag.StringVar(&cfg.DatastoreDBHost, "db-host", "", "Database host")
 	flag.StringVar(&cfg.DatastoreDBUser, "db-user", "", "Database user")
 	flag.StringVar(&cfg.DatastoreDBPassword, "db-password", "", "Database password")
 	flag.StringVar(&cfg.DatastoreDBSchema, "db-schema", "", "Database schema")
+	flag.DurationVar(&cfg.TrashRetention, "trash-retention", 30*24*time.Hour, "Time deleted tasks are kept in trash, 0 keeps them forever")
+	flag.StringVar(&cfg.ReminderNotifiers, "reminder-notifiers", "log,stream", "Comma separated notifiers delivering reminders: log, webhook, stream")
+	flag.StringVar(&cfg.ReminderWebhookURL, "reminder-webhook-url", "", "URL reminders are posted to by the webhook notifier")
+	flag.StringVar(&cfg.AttachmentDir, "attachment-dir", "attachments", "Directory attachments are stored in")
+	flag.Int64Var(&cfg.AttachmentMaxSize, "attachment-max-size", 10<<20, "Largest attachment in bytes")
+	flag.StringVar(&cfg.AttachmentTypes, "attachment-types", "image/png,image/jpeg,image/gif,application/pdf,text/plain", "Comma separated content types attachments may have")
+	flag.StringVar(&cfg.AuthKeyFile, "auth-key-file", "", "File with the HS256 secret or the PEM encoded RSA public key of bearer tokens")
+	flag.StringVar(&cfg.AuthJWKSFile, "auth-jwks-file", "", "JSON Web Key Set file with keys of bearer tokens")
+	flag.StringVar(&cfg.AuthIssuer, "auth-issuer", "", "Issuer bearer tokens must have, any issuer if empty")
+	flag.StringVar(&cfg.AuthAudience, "auth-audience", "", "Audience bearer tokens must have, any audience if empty")
+	flag.BoolVar(&cfg.AuthDisabled, "auth-disabled", false, "Accept calls without bearer tokens")
+	flag.StringVar(&cfg.Admins, "admins", "", "Comma separated subjects allowed to reach tasks of all owners with all_owners")
 	flag.Parse()
 
 	if len(cfg.GRPCPort) == 0 {
@@ -57,6 +227,32 @@ func RunServer() error {
 		return fmt.Errorf("invalid TCP port for HTTP gateway: '%s'", cfg.HTTPPort)
 	}
 
+	if cfg.TrashRetention < 0 {
+		return fmt.Errorf("invalid trash retention: '%s'", cfg.TrashRetention)
+	}
+
+	if cfg.AttachmentMaxSize <= 0 {
+		return fmt.Errorf("invalid attachment max size: '%d'", cfg.AttachmentMaxSize)
+	}
+
+	grpcTLS, err := newServerTLS(cfg.GRPCCertFile, cfg.GRPCKeyFile, cfg.GRPCClientCAFile)
+	if err != nil {
+		return fmt.Errorf("failed to load gRPC TLS files: %v", err)
+	}
+	httpTLS, err := newServerTLS(cfg.HTTPCertFile, cfg.HTTPKeyFile, cfg.HTTPClientCAFile)
+	if err != nil {
+		return fmt.Errorf("failed to load HTTP TLS files: %v", err)
+	}
+	gatewayCreds, err := newGatewayCredentials(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load gateway TLS files: %v", err)
+	}
+
+	blobs, err := blob.NewFileStore(cfg.AttachmentDir)
+	if err != nil {
+		return fmt.Errorf("failed to open attachment store: %v", err)
+	}
+
 	// Add MySQL driver specifc parameter to parse date/time
 	// Drop it for another database
 	param := "parseTime=true"
@@ -73,12 +269,50 @@ func RunServer() error {
 	}
 	defer db.Close()
 
-	v1API := v1.NewToDoServiceServer(db)
+	var admins []string
+	for _, subject := range strings.Split(cfg.Admins, ",") {
+		admins = append(admins, strings.TrimSpace(subject))
+	}
+	v1API := v1.NewToDoServiceServer(db, v1.WithAttachments(blobs, cfg.AttachmentMaxSize, strings.Split(cfg.AttachmentTypes, ",")),
+		v1.WithAdmins(admins))
+	v1WebhookAPI := v1.NewWebhookServiceServer(db)
+	v1ApiKeyAPI := v1.NewApiKeyServiceServer(db)
+
+	authenticator, err := newAuthenticator(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	notifiers, err := newNotifiers(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	// purge expired tasks from trash
+	go v1.RunTrashPurger(ctx, v1API, cfg.TrashRetention)
+
+	// deliver due reminders
+	go v1.RunReminderScheduler(ctx, db, notifiers)
+
+	// post task events to webhooks
+	go v1.RunWebhookDispatcher(ctx, db)
+
+	// attachments are sent in a single message
+	maxMsgSize := int(cfg.AttachmentMaxSize) + messageOverhead
 
 	// run HTTP gateway
+	var httpTLSConfig *tls.Config
+	if httpTLS != nil {
+		httpTLSConfig = httpTLS.ServerConfig()
+	}
 	go func() {
-		_ = rest.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort)
+		_ = rest.RunServer(ctx, cfg.GRPCPort, cfg.HTTPPort, cfg.AttachmentMaxSize, httpTLSConfig, gatewayCreds,
+			ggrpc.WithDefaultCallOptions(ggrpc.MaxCallRecvMsgSize(maxMsgSize), ggrpc.MaxCallSendMsgSize(maxMsgSize)))
 	}()
 
-	return grpc.RunServer(ctx, v1API, cfg.GRPCPort)
+	grpcOpts := []ggrpc.ServerOption{ggrpc.MaxRecvMsgSize(maxMsgSize), ggrpc.MaxSendMsgSize(maxMsgSize)}
+	if grpcTLS != nil {
+		grpcOpts = append(grpcOpts, ggrpc.Creds(certs.NewServerCredentials(grpcTLS)))
+	}
+	return grpc.RunServer(ctx, v1API, v1WebhookAPI, v1ApiKeyAPI, cfg.GRPCPort, authenticator, grpcOpts...)
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// logNotifier writes reminders to the server log
+type logNotifier struct{}
+
+// NewLogNotifier creates notifier writing reminders to the server log
+func NewLogNotifier() Notifier {
+	return logNotifier{}
+}
+
+// Notify logs the reminder
+func (logNotifier) Notify(ctx context.Context, r Reminder) error {
+	log.Printf("reminder of task %d '%s' at %s", r.ToDo.Id, r.ToDo.Title, r.At.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,30 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// Reminder is a due reminder of a task
+type Reminder struct {
+	// ToDo is the task to remind of
+	ToDo *v1.ToDo
+	// At is the reminder time of the task
+	At time.Time
+}
+
+// Notifier delivers reminders to users
+type Notifier interface {
+	// Notify delivers the reminder, a reminder is delivered again if it returns an error
+	Notify(ctx context.Context, r Reminder) error
+}
+
+// Named is a notifier with the name deliveries through it are tracked by,
+// a reminder failed through some notifiers is delivered again through these only
+type Named struct {
+	// Name of the notifier, unique among the notifiers reminders are delivered through
+	Name string
+	Notifier
+}
@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	at := time.Date(2020, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "OK", status: http.StatusNoContent},
+		{name: "Server error", status: http.StatusInternalServerError, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("webhook Content-Type = %v, want application/json", ct)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("failed to decode webhook body: %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			err := NewWebhookNotifier(srv.URL).Notify(context.Background(), Reminder{ToDo: &v1.ToDo{Id: 3, Title: "title"}, At: at})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookNotifier.Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			want := map[string]interface{}{
+				"at":   "2020-03-01T09:00:00Z",
+				"toDo": map[string]interface{}{"id": "3", "title": "title"},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("webhook body = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// webhookTimeout is how long a webhook call may take
+const webhookTimeout = 10 * time.Second
+
+// webhookPayload is the JSON body posted to webhook
+type webhookPayload struct {
+	// At is the reminder time
+	At time.Time `json:"at"`
+	// ToDo is the task in the JSON format of the HTTP gateway
+	ToDo json.RawMessage `json:"toDo"`
+}
+
+// webhookNotifier posts reminders to an HTTP endpoint
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates notifier posting reminders as JSON to url
+func NewWebhookNotifier(url string) Notifier {
+	return &webhookNotifier{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Notify posts the reminder, any response status other than 2xx is an error
+func (n *webhookNotifier) Notify(ctx context.Context, r Reminder) error {
+	td, err := (&jsonpb.Marshaler{}).MarshalToString(r.ToDo)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %v", err)
+	}
+	body, err := json.Marshal(webhookPayload{At: r.At.UTC(), ToDo: json.RawMessage(td)})
+	if err != nil {
+		return fmt.Errorf("failed to encode reminder: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
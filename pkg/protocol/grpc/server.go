@@ -6,23 +6,82 @@ import (
 	"os"
 	"net"
 	"context"
+	"time"
 	
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 
 
 	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
 )
 
-// RunServer runs the gRPC service to publich the ToDo service
-func RunServer(ctx context.Context, v1API v1.ToDoServiceServer, port string) error {
+const (
+	// keepaliveTime is how long a connection is idle before the server pings the client
+	keepaliveTime = time.Minute
+	// keepaliveTimeout is how long the server waits for the ping ack before closing the connection
+	keepaliveTimeout = 20 * time.Second
+	// keepaliveMinTime is the shortest interval clients are allowed to ping the server at
+	keepaliveMinTime = 10 * time.Second
+)
+
+// serverStream is a server stream with a context ended on server shutdown
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// endStreams returns interceptor canceling streams when done is closed,
+// so that long lived streams like Watch don't block graceful shutdown
+func endStreams(done <-chan struct{}) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+		go func() {
+			select {
+			case <-done:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// RunServer runs the gRPC service to publich the ToDo, Webhook and ApiKey services, opts are added to the server options.
+// Calls must carry credentials accepted by authenticator, unless it is nil.
+func RunServer(ctx context.Context, v1API v1.ToDoServiceServer, v1WebhookAPI v1.WebhookServiceServer, v1ApiKeyAPI v1.ApiKeyServiceServer,
+	port string, authenticator *auth.Authenticator, opts ...grpc.ServerOption) error {
 	listen, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return err
 	}
 
 	//register service
-	server := grpc.NewServer()
+	done := make(chan struct{})
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if authenticator != nil {
+		// authenticate first so that unauthenticated calls are rejected before anything else runs
+		unary = append(unary, auth.UnaryServerInterceptor(authenticator))
+		stream = append(stream, auth.StreamServerInterceptor(authenticator))
+	}
+	stream = append(stream, endStreams(done))
+	server := grpc.NewServer(append([]grpc.ServerOption{
+		// ping idle clients so that dead ones don't hold Watch streams open
+		grpc.KeepaliveParams(keepalive.ServerParameters{Time: keepaliveTime, Timeout: keepaliveTimeout}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: keepaliveMinTime, PermitWithoutStream: true}),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}, opts...)...)
 	v1.RegisterToDoServiceServer(server, v1API)
+	v1.RegisterWebhookServiceServer(server, v1WebhookAPI)
+	v1.RegisterApiKeyServiceServer(server, v1ApiKeyAPI)
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
@@ -31,6 +90,7 @@ func RunServer(ctx context.Context, v1API v1.ToDoServiceServer, port string) err
 		for range c {
 			// sig  is a ^C, handle it
 			log.Println("shutting down gRPC server...")
+			close(done)
 			server.GracefulStop()
 
 			<-ctx.Done()
@@ -41,4 +101,3 @@ func RunServer(ctx context.Context, v1API v1.ToDoServiceServer, port string) err
 	log.Println("starting gRPC server...")
 	return server.Serve(listen)
 }
-
@@ -7,27 +7,133 @@ import (
 	"net/http"
 	"log"
 	"context"
+	"strconv"
+	"io"
+	"io/ioutil"
+	"crypto/tls"
 	
+	"github.com/golang/protobuf/proto"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
 )
 
-// RunServer runs HTTP/REST gateway
-func RunServer(ctx context.Context, grpcPort, httpPort string) error {
+// incomingHeader passes the If-Match header to ToDo service, which checks it against the task etag,
+// the X-Actor header, which is recorded in task history, and the X-Api-Key header authenticating scripts.
+// The Authorization header is passed as authorization metadata by the gateway itself, so that the
+// gRPC server verifies the bearer token, it is not copied to grpcgateway-authorization too.
+func incomingHeader(key string) (string, bool) {
+	switch http.CanonicalHeaderKey(key) {
+	case "If-Match":
+		return "if-match", true
+	case "X-Actor":
+		return "x-actor", true
+	case "X-Api-Key":
+		return "x-api-key", true
+	case "Authorization":
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// etagHeader sets the ETag header to the etag of the task in the response
+func etagHeader(ctx context.Context, w http.ResponseWriter, m proto.Message) error {
+	var etag string
+	switch resp := m.(type) {
+	case interface{ GetEtag() string }:
+		etag = resp.GetEtag()
+	case interface{ GetToDo() *v1.ToDo }:
+		etag = resp.GetToDo().GetEtag()
+	}
+	if len(etag) > 0 {
+		w.Header().Set("ETag", strconv.Quote(etag))
+	}
+	return nil
+}
+
+// httpBodyMarshaler reads request bodies decoded into google.api.HttpBody as raw bytes,
+// so that files are uploaded as they are, other requests are decoded as JSON
+type httpBodyMarshaler struct {
+	runtime.HTTPBodyMarshaler
+}
+
+// httpBodyDecoder decodes the request body into google.api.HttpBody or as JSON
+type httpBodyDecoder struct {
+	r    io.Reader
+	json runtime.Decoder
+}
+
+// Decode reads the request body into v
+func (d *httpBodyDecoder) Decode(v interface{}) error {
+	body, ok := v.(**httpbody.HttpBody)
+	if !ok {
+		return d.json.Decode(v)
+	}
+	data, err := ioutil.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	*body = &httpbody.HttpBody{Data: data}
+	return nil
+}
+
+// NewDecoder returns decoder reading the request body from r
+func (m *httpBodyMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	return &httpBodyDecoder{r: r, json: m.Marshaler.NewDecoder(r)}
+}
+
+// limitBody rejects requests with bodies longer than maxSize bytes, so that the gateway does not read
+// an upload larger than the largest attachment into memory before the service checks its size.
+// Bodies of unknown length are cut at maxSize bytes and fail to read past it.
+func limitBody(h http.Handler, maxSize int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxSize {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// RunServer runs HTTP/REST gateway, opts are added to the options the gateway dials gRPC server with.
+// The gateway listens with tlsConfig and dials gRPC server with creds, in plaintext if they are nil.
+// Request bodies longer than maxBodySize bytes are rejected.
+func RunServer(ctx context.Context, grpcPort, httpPort string, maxBodySize int64, tlsConfig *tls.Config,
+	creds credentials.TransportCredentials, opts ...grpc.DialOption) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithInsecure()}
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithForwardResponseOption(etagHeader),
+		// attachments are uploaded and downloaded as raw bytes of their content type
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &httpBodyMarshaler{
+			runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{OrigName: true}},
+		}),
+	)
+	if creds != nil {
+		opts = append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, opts...)
+	} else {
+		opts = append([]grpc.DialOption{grpc.WithInsecure()}, opts...)
+	}
 	if err := v1.RegisterToDoServiceHandlerFromEndpoint(ctx, mux, "localhost:"+grpcPort, opts); err != nil{
 		log.Fatalf("failed to start HTTP gateway: %v", err)
 	}
+	if err := v1.RegisterWebhookServiceHandlerFromEndpoint(ctx, mux, "localhost:"+grpcPort, opts); err != nil{
+		log.Fatalf("failed to start HTTP gateway: %v", err)
+	}
+	if err := v1.RegisterApiKeyServiceHandlerFromEndpoint(ctx, mux, "localhost:"+grpcPort, opts); err != nil{
+		log.Fatalf("failed to start HTTP gateway: %v", err)
+	}
 
 	srv := &http.Server{
 		Addr: ":"+ httpPort,
-		Handler: mux,
+		Handler: limitBody(mux, maxBodySize),
+		TLSConfig: tlsConfig,
 	}
 
 	// graceful shutdown
@@ -45,5 +151,9 @@ func RunServer(ctx context.Context, grpcPort, httpPort string) error {
 	}()
 
 	log.Println("starting HTTP/REST gateway...")
+	if tlsConfig != nil {
+		// the certificate comes from tlsConfig
+		return srv.ListenAndServeTLS("", "")
+	}
 	return srv.ListenAndServe()
 }
\ No newline at end of file
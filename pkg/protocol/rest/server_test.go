@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_limitBody(t *testing.T) {
+	// read the body like the gateway does before decoding it
+	h := limitBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := ioutil.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}), 4)
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		want          int
+	}{
+		{name: "OK", body: "file", contentLength: 4, want: http.StatusOK},
+		{name: "Oversized body", body: "files", contentLength: 5, want: http.StatusRequestEntityTooLarge},
+		{name: "Oversized body of unknown length", body: "files", contentLength: -1, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/todo/1/attachments", strings.NewReader(tt.body))
+			r.ContentLength = tt.contentLength
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("limitBody() status = %v, want %v", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package search
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// HighlightStart is inserted before a matched term in snippets
+	HighlightStart = "<em>"
+	// HighlightEnd is inserted after a matched term in snippets
+	HighlightEnd = "</em>"
+)
+
+// Document is the searchable content of a task
+type Document struct {
+	// ID of the task
+	ID int64
+	// Title of the task
+	Title string
+	// Description of the task
+	Description string
+	// OwnerID is the subject owning the task, empty if it was created without authentication
+	OwnerID string
+}
+
+// Filter limits a search to the tasks of an owner and the ones shared with it,
+// the zero Filter matches tasks of all owners
+type Filter struct {
+	// OwnerID matches tasks owned by the subject
+	OwnerID string
+	// Shared are IDs of tasks of other owners shared with the subject, they match too
+	Shared []int64
+}
+
+// unlimited reports whether the filter matches tasks of all owners
+func (f Filter) unlimited() bool {
+	return len(f.OwnerID) == 0
+}
+
+// Hit is a task matching a search query
+type Hit struct {
+	// ID of the task
+	ID int64
+	// Score is the relevance of the task, higher is better
+	Score float64
+}
+
+// Index finds tasks by keywords in their title and description
+type Index interface {
+	// Put adds or replaces a task in the index
+	Put(ctx context.Context, doc Document) error
+	// Remove deletes a task from the index
+	Remove(ctx context.Context, id int64) error
+	// Search returns tasks matching any term of query and the filter, most relevant first.
+	// The filter is applied before limit and offset, so that pages are full.
+	Search(ctx context.Context, query string, filter Filter, limit, offset int) ([]Hit, error)
+}
+
+// Terms splits text into lower case words
+func Terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSeparator)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// Snippet returns a fragment of text around the first term of query found in it,
+// at most width runes long plus highlighting, with matched terms highlighted.
+// The text is HTML-escaped so that only the highlighting is markup.
+// It returns an empty string if text does not contain any term.
+func Snippet(text, query string, width int) string {
+	terms := map[string]bool{}
+	for _, t := range Terms(query) {
+		terms[t] = true
+	}
+
+	// find words and the matching ones
+	type word struct{ start, end int }
+	runes := []rune(text)
+	var matches []word
+	for i := 0; i < len(runes); {
+		if isSeparator(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && !isSeparator(runes[i]) {
+			i++
+		}
+		if terms[strings.ToLower(string(runes[start:i]))] {
+			matches = append(matches, word{start, i})
+		}
+	}
+	if len(matches) == 0 {
+		return ""
+	}
+
+	// center the window on the first match
+	from := matches[0].start - width/4
+	if from < 0 {
+		from = 0
+	}
+	to := from + width
+	if to > len(runes) {
+		to = len(runes)
+		if from = to - width; from < 0 {
+			from = 0
+		}
+	}
+
+	// do not cut words at the window edges
+	for from > 0 && !isSeparator(runes[from-1]) && !isSeparator(runes[from]) {
+		from++
+	}
+	for to > from && to < len(runes) && !isSeparator(runes[to-1]) && !isSeparator(runes[to]) {
+		to--
+	}
+	for from < to && unicode.IsSpace(runes[from]) {
+		from++
+	}
+	for to > from && unicode.IsSpace(runes[to-1]) {
+		to--
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("...")
+	}
+	pos := from
+	for _, m := range matches {
+		if m.start < from || m.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:m.start])))
+		b.WriteString(HighlightStart)
+		b.WriteString(html.EscapeString(string(runes[m.start:m.end])))
+		b.WriteString(HighlightEnd)
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:to])))
+	if to < len(runes) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
@@ -0,0 +1,128 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+// titleBoost is the weight of a term found in title relative to description
+const titleBoost = 2
+
+// memoryIndex is an embedded inverted index kept in process memory
+type memoryIndex struct {
+	mu sync.RWMutex
+	// postings maps a term to weighted term frequency per task ID
+	postings map[string]map[int64]float64
+	// terms maps a task ID to terms indexed for it
+	terms map[int64][]string
+	// owners maps a task ID to its owner
+	owners map[int64]string
+}
+
+// NewMemoryIndex creates an embedded index, intended for tests and single instance deployments
+func NewMemoryIndex() Index {
+	return &memoryIndex{
+		postings: map[string]map[int64]float64{},
+		terms:    map[int64][]string{},
+		owners:   map[int64]string{},
+	}
+}
+
+// Put adds or replaces a task in the index
+func (m *memoryIndex) Put(ctx context.Context, doc Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(doc.ID)
+	freq := map[string]float64{}
+	for _, t := range Terms(doc.Title) {
+		freq[t] += titleBoost
+	}
+	for _, t := range Terms(doc.Description) {
+		freq[t]++
+	}
+	terms := make([]string, 0, len(freq))
+	for t, f := range freq {
+		if m.postings[t] == nil {
+			m.postings[t] = map[int64]float64{}
+		}
+		m.postings[t][doc.ID] = f
+		terms = append(terms, t)
+	}
+	m.terms[doc.ID] = terms
+	m.owners[doc.ID] = doc.OwnerID
+	return nil
+}
+
+// Remove deletes a task from the index
+func (m *memoryIndex) Remove(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(id)
+	return nil
+}
+
+func (m *memoryIndex) remove(id int64) {
+	for _, t := range m.terms[id] {
+		delete(m.postings[t], id)
+		if len(m.postings[t]) == 0 {
+			delete(m.postings, t)
+		}
+	}
+	delete(m.terms, id)
+	delete(m.owners, id)
+}
+
+// Search returns tasks matching any term of query and the filter ranked by TF-IDF,
+// terms are weighted by their frequency in tasks of all owners
+func (m *memoryIndex) Search(ctx context.Context, query string, filter Filter, limit, offset int) ([]Hit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	shared := make(map[int64]bool, len(filter.Shared))
+	for _, id := range filter.Shared {
+		shared[id] = true
+	}
+
+	scores := map[int64]float64{}
+	seen := map[string]bool{}
+	for _, t := range Terms(query) {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		docs := m.postings[t]
+		if len(docs) == 0 {
+			continue
+		}
+		idf := math.Log(1 + float64(len(m.terms))/float64(len(docs)))
+		for id, tf := range docs {
+			if filter.unlimited() || m.owners[id] == filter.OwnerID || shared[id] {
+				scores[id] += tf * idf
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	if offset >= len(hits) {
+		return []Hit{}, nil
+	}
+	hits = hits[offset:]
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
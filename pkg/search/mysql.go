@@ -0,0 +1,63 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// mysqlIndex searches the ToDo table using MySQL full-text search
+//
+// It requires the FULLTEXT index on `Title` and `Description` from sql/todo-service.sql.
+// MySQL maintains the index on every write, so Put and Remove do nothing.
+type mysqlIndex struct {
+	db *sql.DB
+}
+
+// NewMySQLIndex creates an index backed by MySQL full-text search on the ToDo table
+func NewMySQLIndex(db *sql.DB) Index {
+	return &mysqlIndex{db: db}
+}
+
+// Put does nothing, the FULLTEXT index is updated by MySQL
+func (m *mysqlIndex) Put(ctx context.Context, doc Document) error {
+	return nil
+}
+
+// Remove does nothing, the FULLTEXT index is updated by MySQL
+func (m *mysqlIndex) Remove(ctx context.Context, id int64) error {
+	return nil
+}
+
+// Search returns tasks out of trash matching query in natural language mode and the filter, most relevant first
+func (m *mysqlIndex) Search(ctx context.Context, query string, filter Filter, limit, offset int) ([]Hit, error) {
+	where := "MATCH(`Title`, `Description`) AGAINST(?) AND `DeletedAt` IS NULL"
+	args := []interface{}{query, query}
+	if !filter.unlimited() {
+		args = append(args, filter.OwnerID)
+		if len(filter.Shared) == 0 {
+			where += " AND `OwnerID`=?"
+		} else {
+			where += " AND (`OwnerID`=? OR `ID` IN (?" + strings.Repeat(",?", len(filter.Shared)-1) + "))"
+			for _, id := range filter.Shared {
+				args = append(args, id)
+			}
+		}
+	}
+	rows, err := m.db.QueryContext(ctx, "SELECT `ID`, MATCH(`Title`, `Description`) AGAINST(?) AS `Score` FROM ToDo "+
+		"WHERE "+where+" ORDER BY `Score` DESC, `ID` LIMIT ? OFFSET ?", append(args, limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []Hit{}
+	for rows.Next() {
+		var h Hit
+		if err := rows.Scan(&h.ID, &h.Score); err != nil {
+			return nil, err
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,186 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		width int
+		want  string
+	}{
+		{
+			name:  "Whole text",
+			text:  "Send the Report to Bob",
+			query: "report bob",
+			width: 100,
+			want:  "Send the <em>Report</em> to <em>Bob</em>",
+		},
+		{
+			name:  "Window around first match",
+			text:  "one two three four five six seven eight",
+			query: "five",
+			width: 12,
+			want:  "...<em>five</em> six...",
+		},
+		{
+			name:  "Whole words only",
+			text:  "reporting",
+			query: "report",
+			width: 100,
+			want:  "",
+		},
+		{
+			name:  "Markup escaped",
+			text:  "<script>alert(1)</script> & <b>bold</b>",
+			query: "bold",
+			width: 100,
+			want:  "&lt;script&gt;alert(1)&lt;/script&gt; &amp; &lt;b&gt;<em>bold</em>&lt;/b&gt;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Snippet(tt.text, tt.query, tt.width); got != tt.want {
+				t.Errorf("Snippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIndex(t *testing.T) {
+	ctx := context.Background()
+	index := NewMemoryIndex()
+	_ = index.Put(ctx, Document{ID: 1, Title: "backup", Description: "database backup"})
+	_ = index.Put(ctx, Document{ID: 2, Title: "restore", Description: "restore from backup"})
+	_ = index.Put(ctx, Document{ID: 3, Title: "lunch", Description: ""})
+
+	ids := func(hits []Hit) []int64 {
+		list := []int64{}
+		for _, h := range hits {
+			list = append(list, h.ID)
+		}
+		return list
+	}
+
+	hits, _ := index.Search(ctx, "backup restore", Filter{}, 10, 0)
+	if got, want := ids(hits), []int64{1, 2}; !reflect.DeepEqual(got, want) && !reflect.DeepEqual(got, []int64{2, 1}) {
+		t.Errorf("Search() = %v, want %v in any order", got, want)
+	}
+
+	hits, _ = index.Search(ctx, "backup", Filter{}, 10, 0)
+	if got, want := ids(hits), []int64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Search() = %v, want %v ranked by term frequency", got, want)
+	}
+
+	hits, _ = index.Search(ctx, "backup", Filter{}, 1, 1)
+	if got, want := ids(hits), []int64{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Search() with offset = %v, want %v", got, want)
+	}
+
+	_ = index.Put(ctx, Document{ID: 1, Title: "cleanup"})
+	_ = index.Remove(ctx, 2)
+	hits, _ = index.Search(ctx, "backup", Filter{}, 10, 0)
+	if got, want := ids(hits), []int64{}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Search() after update = %v, want %v", got, want)
+	}
+}
+
+func TestMemoryIndex_filter(t *testing.T) {
+	ctx := context.Background()
+	index := NewMemoryIndex()
+	_ = index.Put(ctx, Document{ID: 1, Title: "backup", OwnerID: "bob"})
+	_ = index.Put(ctx, Document{ID: 2, Title: "backup", OwnerID: "bob"})
+	_ = index.Put(ctx, Document{ID: 3, Title: "backup", OwnerID: "alice"})
+	_ = index.Put(ctx, Document{ID: 4, Title: "backup", OwnerID: "alice"})
+	_ = index.Put(ctx, Document{ID: 5, Title: "backup", OwnerID: "bob"})
+
+	tests := []struct {
+		name          string
+		filter        Filter
+		limit, offset int
+		want          []int64
+	}{
+		{"All owners", Filter{}, 10, 0, []int64{1, 2, 3, 4, 5}},
+		{"Owner", Filter{OwnerID: "alice"}, 10, 0, []int64{3, 4}},
+		{"Page of owner", Filter{OwnerID: "alice"}, 1, 1, []int64{4}},
+		{"Owner and shared", Filter{OwnerID: "alice", Shared: []int64{5}}, 2, 1, []int64{4, 5}},
+		{"Owner without tasks", Filter{OwnerID: "carol"}, 10, 0, []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := index.Search(ctx, "backup", tt.filter, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			got := []int64{}
+			for _, h := range hits {
+				got = append(got, h.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMySQLIndex_Search(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	index := NewMySQLIndex(db)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		mock   func()
+	}{
+		{
+			name:   "All owners",
+			filter: Filter{},
+			mock: func() {
+				mock.ExpectQuery("WHERE MATCH\\(`Title`, `Description`\\) AGAINST\\(\\?\\) AND `DeletedAt` IS NULL ORDER BY").
+					WithArgs("backup", "backup", 2, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"ID", "Score"}).AddRow(1, 0.5))
+			},
+		},
+		{
+			name:   "Owner",
+			filter: Filter{OwnerID: "alice"},
+			mock: func() {
+				mock.ExpectQuery("AND `DeletedAt` IS NULL AND `OwnerID`=\\? ORDER BY").
+					WithArgs("backup", "backup", "alice", 2, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"ID", "Score"}).AddRow(1, 0.5))
+			},
+		},
+		{
+			name:   "Owner and shared",
+			filter: Filter{OwnerID: "alice", Shared: []int64{7, 9}},
+			mock: func() {
+				mock.ExpectQuery("AND `DeletedAt` IS NULL AND \\(`OwnerID`=\\? OR `ID` IN \\(\\?,\\?\\)\\) ORDER BY").
+					WithArgs("backup", "backup", "alice", 7, 9, 2, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"ID", "Score"}).AddRow(1, 0.5))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			hits, err := index.Search(context.Background(), "backup", tt.filter, 2, 4)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if want := []Hit{{ID: 1, Score: 0.5}}; !reflect.DeepEqual(hits, want) {
+				t.Errorf("Search() = %v, want %v", hits, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+const (
+	// apiKeySize is the number of random bytes in a minted API key
+	apiKeySize = 32
+	// apiKeyPrefix starts every API key so that leaked keys are easy to recognize
+	apiKeyPrefix = "tdk_"
+	// apiKeyPrefixSize is the number of leading characters of a key stored in clear to recognize it by
+	apiKeyPrefixSize = 12
+	// maxApiKeyName is the longest API key name
+	maxApiKeyName = 200
+	// lastUsedInterval is how often the last used time of a key is updated at most
+	lastUsedInterval = time.Minute
+)
+
+// apiKeyColumns are the ApiKey table columns read by scanApiKey
+const apiKeyColumns = "`ID`, `Name`, `Scope`, `Prefix`, `CreatedAt`, `LastUsedAt`, `RevokedAt`"
+
+// apiKeyServiceServer is the implementation of v1.ApiKeyServiceServer proto interface
+type apiKeyServiceServer struct {
+	dbService
+}
+
+// NewApiKeyServiceServer creates ApiKey Service
+func NewApiKeyServiceServer(db *sql.DB) v1.ApiKeyServiceServer {
+	return &apiKeyServiceServer{dbService: dbService{db: db}}
+}
+
+// NewApiKeyStore creates store looking up API keys minted by ApiKey Service
+func NewApiKeyStore(db *sql.DB) auth.APIKeyStore {
+	return &apiKeyServiceServer{dbService: dbService{db: db}}
+}
+
+// hashApiKey returns the hash keys are stored and looked up by.
+// Keys are random, so a fast hash is enough to make a leaked table useless.
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiKeyOwner returns who owns keys minted and managed by the request, empty if authentication is disabled
+func apiKeyOwner(ctx context.Context) string {
+	owner, _ := auth.Subject(ctx)
+	return owner
+}
+
+// scanApiKey reads an API key from the current row of a query selecting apiKeyColumns
+func scanApiKey(rows *sql.Rows) (*v1.ApiKey, error) {
+	var k v1.ApiKey
+	var scope int32
+	var createdAt time.Time
+	var lastUsedAt, revokedAt sql.NullTime
+	if err := rows.Scan(&k.Id, &k.Name, &scope, &k.Prefix, &createdAt, &lastUsedAt, &revokedAt); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from ApiKey row-> "+err.Error())
+	}
+	k.Scope = v1.ApiKeyScope(scope)
+	var err error
+	if k.CreatedAt, err = ptypes.TimestampProto(createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+	if lastUsedAt.Valid {
+		if k.LastUsedAt, err = ptypes.TimestampProto(lastUsedAt.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "last_used_at field has invalid format-> "+err.Error())
+		}
+	}
+	if revokedAt.Valid {
+		if k.RevokedAt, err = ptypes.TimestampProto(revokedAt.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "revoked_at field has invalid format-> "+err.Error())
+		}
+	}
+	return &k, nil
+}
+
+// CreateApiKey mints an API key owned by the caller
+func (s *apiKeyServiceServer) CreateApiKey(ctx context.Context, req *v1.CreateApiKeyRequest) (*v1.CreateApiKeyResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if req.Scope != v1.ApiKeyScope_API_KEY_SCOPE_READ_ONLY && req.Scope != v1.ApiKeyScope_API_KEY_SCOPE_READ_WRITE {
+		return nil, status.Errorf(codes.InvalidArgument, "scope must be read-only or read-write, got %d", req.Scope)
+	}
+	if len(req.Name) > maxApiKeyName {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be at most %d characters", maxApiKeyName)
+	}
+
+	b := make([]byte, apiKeySize)
+	if _, err := rand.Read(b); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to generate API key-> "+err.Error())
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	res, err := c.ExecContext(ctx, "INSERT INTO ApiKey(`Name`, `Hash`, `Prefix`, `Scope`, `Owner`, `CreatedAt`) VALUES(?,?,?,?,?,?)",
+		req.Name, hashApiKey(key), key[:apiKeyPrefixSize], int32(req.Scope), apiKeyOwner(ctx), now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into ApiKey-> "+err.Error())
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve id for created ApiKey-> "+err.Error())
+	}
+
+	createdAt, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "created_at field has invalid format-> "+err.Error())
+	}
+
+	return &v1.CreateApiKeyResponse{
+		Api: apiVersion,
+		ApiKey: &v1.ApiKey{
+			Id:        id,
+			Name:      req.Name,
+			Scope:     req.Scope,
+			Prefix:    key[:apiKeyPrefixSize],
+			CreatedAt: createdAt,
+		},
+		Key: key,
+	}, nil
+}
+
+// ListApiKeys returns API keys of the caller without the keys themselves
+func (s *apiKeyServiceServer) ListApiKeys(ctx context.Context, req *v1.ListApiKeysRequest) (*v1.ListApiKeysResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	query := "SELECT " + apiKeyColumns + " FROM ApiKey WHERE `Owner`=?"
+	if !req.ShowRevoked {
+		query += " AND `RevokedAt` IS NULL"
+	}
+	rows, err := c.QueryContext(ctx, query+" ORDER BY `ID`", apiKeyOwner(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ApiKey-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.ApiKey{}
+	for rows.Next() {
+		k, err := scanApiKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ApiKey-> "+err.Error())
+	}
+
+	return &v1.ListApiKeysResponse{
+		Api:     apiVersion,
+		ApiKeys: list,
+	}, nil
+}
+
+// RevokeApiKey revokes an API key of the caller
+func (s *apiKeyServiceServer) RevokeApiKey(ctx context.Context, req *v1.RevokeApiKeyRequest) (*v1.RevokeApiKeyResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	res, err := c.ExecContext(ctx, "UPDATE ApiKey SET `RevokedAt`=? WHERE `ID`=? AND `Owner`=? AND `RevokedAt` IS NULL",
+		time.Now().UTC(), req.Id, apiKeyOwner(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to update ApiKey-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ApiKey with ID='%d' is not found", req.Id))
+	}
+
+	return &v1.RevokeApiKeyResponse{
+		Api:     apiVersion,
+		Revoked: rows,
+	}, nil
+}
+
+// LookupAPIKey returns what a key which is not revoked authenticates and records that it was used.
+// A key acts for its owner, or for itself if it was minted while authentication was disabled.
+func (s *apiKeyServiceServer) LookupAPIKey(ctx context.Context, key string) (auth.APIKey, error) {
+	var id int64
+	var scope int32
+	var owner string
+	var lastUsedAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, "SELECT `ID`, `Scope`, `Owner`, `LastUsedAt` FROM ApiKey WHERE `Hash`=? AND `RevokedAt` IS NULL",
+		hashApiKey(key)).Scan(&id, &scope, &owner, &lastUsedAt)
+	if err == sql.ErrNoRows {
+		return auth.APIKey{}, auth.ErrUnknownAPIKey
+	}
+	if err != nil {
+		return auth.APIKey{}, status.Error(codes.Unknown, "failed to select from ApiKey-> "+err.Error())
+	}
+
+	// a failed update must not fail the call the key authenticates
+	now := time.Now().UTC()
+	if !lastUsedAt.Valid || now.Sub(lastUsedAt.Time) >= lastUsedInterval {
+		if _, err := s.db.ExecContext(ctx, "UPDATE ApiKey SET `LastUsedAt`=? WHERE `ID`=?", now, id); err != nil {
+			log.Printf("failed to record use of API key %d: %v", id, err)
+		}
+	}
+
+	if len(owner) == 0 {
+		owner = fmt.Sprintf("apikey:%d", id)
+	}
+	return auth.APIKey{
+		Subject:  owner,
+		ReadOnly: v1.ApiKeyScope(scope) != v1.ApiKeyScope_API_KEY_SCOPE_READ_WRITE,
+	}, nil
+}
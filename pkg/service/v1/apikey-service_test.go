@@ -0,0 +1,381 @@
+package v1
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+func newApiKeyRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "Name", "Scope", "Prefix", "CreatedAt", "LastUsedAt", "RevokedAt"})
+}
+
+// captureArg matches any string argument and keeps it
+type captureArg struct {
+	value *string
+}
+
+// Match keeps v if it is a string
+func (a captureArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	*a.value = s
+	return ok
+}
+
+func Test_apiKeyServiceServer_CreateApiKey(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), "alice")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewApiKeyServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.CreateApiKeyRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ApiKeyServiceServer
+		args    args
+		mock    func(hash, prefix *string)
+		want    *v1.ApiKey
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateApiKeyRequest{
+					Api:   "v1",
+					Name:  "nightly export",
+					Scope: v1.ApiKeyScope_API_KEY_SCOPE_READ_ONLY,
+				},
+			},
+			mock: func(hash, prefix *string) {
+				mock.ExpectExec("INSERT INTO ApiKey").
+					WithArgs("nightly export", captureArg{hash}, captureArg{prefix}, 1, "alice", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			want: &v1.ApiKey{
+				Id:    1,
+				Name:  "nightly export",
+				Scope: v1.ApiKeyScope_API_KEY_SCOPE_READ_ONLY,
+			},
+		},
+		{
+			name: "Unspecified scope",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateApiKeyRequest{
+					Api:  "v1",
+					Name: "nightly export",
+				},
+			},
+			mock:    func(hash, prefix *string) {},
+			wantErr: true,
+		},
+		{
+			name: "INSERT failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateApiKeyRequest{
+					Api:   "v1",
+					Scope: v1.ApiKeyScope_API_KEY_SCOPE_READ_WRITE,
+				},
+			},
+			mock: func(hash, prefix *string) {
+				mock.ExpectExec("INSERT INTO ApiKey").WillReturnError(errors.New("INSERT failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateApiKeyRequest{
+					Api:   "v1000",
+					Scope: v1.ApiKeyScope_API_KEY_SCOPE_READ_WRITE,
+				},
+			},
+			mock:    func(hash, prefix *string) {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hash, prefix string
+			tt.mock(&hash, &prefix)
+			got, err := tt.s.CreateApiKey(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("apiKeyServiceServer.CreateApiKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !strings.HasPrefix(got.Key, apiKeyPrefix) || hash != hashApiKey(got.Key) || strings.Contains(hash, got.Key) {
+				t.Errorf("apiKeyServiceServer.CreateApiKey() key %q is not stored by its hash, got %q", got.Key, hash)
+			}
+			if prefix != got.Key[:apiKeyPrefixSize] || got.ApiKey.Prefix != prefix {
+				t.Errorf("apiKeyServiceServer.CreateApiKey() prefix = %q, stored %q, want %q", got.ApiKey.Prefix, prefix, got.Key[:apiKeyPrefixSize])
+			}
+			got.ApiKey.CreatedAt = nil
+			got.ApiKey.Prefix = ""
+			if !reflect.DeepEqual(got.ApiKey, tt.want) {
+				t.Errorf("apiKeyServiceServer.CreateApiKey() = %v, want %v", got.ApiKey, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiKeyServiceServer_ListApiKeys(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), "alice")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewApiKeyServiceServer(db)
+	tm := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListApiKeysRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ApiKeyServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListApiKeysResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListApiKeysRequest{Api: "v1"},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ApiKey WHERE `Owner`=\\? AND `RevokedAt` IS NULL ORDER BY `ID`").WithArgs("alice").
+					WillReturnRows(newApiKeyRows().
+						AddRow(1, "nightly export", 1, "tdk_abcdefgh", tm, tm, nil).
+						AddRow(2, "cleanup", 2, "tdk_ijklmnop", tm, nil, nil))
+			},
+			want: &v1.ListApiKeysResponse{
+				Api: "v1",
+				ApiKeys: []*v1.ApiKey{
+					{Id: 1, Name: "nightly export", Scope: v1.ApiKeyScope_API_KEY_SCOPE_READ_ONLY, Prefix: "tdk_abcdefgh", CreatedAt: ts, LastUsedAt: ts},
+					{Id: 2, Name: "cleanup", Scope: v1.ApiKeyScope_API_KEY_SCOPE_READ_WRITE, Prefix: "tdk_ijklmnop", CreatedAt: ts},
+				},
+			},
+		},
+		{
+			name: "Show revoked",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListApiKeysRequest{Api: "v1", ShowRevoked: true},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ApiKey WHERE `Owner`=\\? ORDER BY `ID`").WithArgs("alice").
+					WillReturnRows(newApiKeyRows().AddRow(1, "old", 2, "tdk_abcdefgh", tm, nil, tm))
+			},
+			want: &v1.ListApiKeysResponse{
+				Api: "v1",
+				ApiKeys: []*v1.ApiKey{
+					{Id: 1, Name: "old", Scope: v1.ApiKeyScope_API_KEY_SCOPE_READ_WRITE, Prefix: "tdk_abcdefgh", CreatedAt: ts, RevokedAt: ts},
+				},
+			},
+		},
+		{
+			name: "SELECT failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListApiKeysRequest{Api: "v1"},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ApiKey").WillReturnError(errors.New("SELECT failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListApiKeys(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("apiKeyServiceServer.ListApiKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apiKeyServiceServer.ListApiKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiKeyServiceServer_RevokeApiKey(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), "alice")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewApiKeyServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.RevokeApiKeyRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ApiKeyServiceServer
+		args    args
+		mock    func()
+		want    *v1.RevokeApiKeyResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RevokeApiKeyRequest{Api: "v1", Id: 1},
+			},
+			mock: func() {
+				mock.ExpectExec("UPDATE ApiKey SET `RevokedAt`=\\? WHERE `ID`=\\? AND `Owner`=\\? AND `RevokedAt` IS NULL").
+					WithArgs(sqlmock.AnyArg(), 1, "alice").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: &v1.RevokeApiKeyResponse{Api: "v1", Revoked: 1},
+		},
+		{
+			name: "Not found or owned by someone else",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RevokeApiKeyRequest{Api: "v1", Id: 2},
+			},
+			mock: func() {
+				mock.ExpectExec("UPDATE ApiKey SET `RevokedAt`").WithArgs(sqlmock.AnyArg(), 2, "alice").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.RevokeApiKey(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("apiKeyServiceServer.RevokeApiKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apiKeyServiceServer.RevokeApiKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiKeyServiceServer_LookupAPIKey(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewApiKeyStore(db)
+	recent := time.Now().UTC()
+	lookupRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"ID", "Scope", "Owner", "LastUsedAt"})
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		mock    func()
+		want    auth.APIKey
+		wantErr error
+	}{
+		{
+			name: "First use",
+			key:  "tdk_first",
+			mock: func() {
+				mock.ExpectQuery("SELECT `ID`, `Scope`, `Owner`, `LastUsedAt` FROM ApiKey WHERE `Hash`=\\? AND `RevokedAt` IS NULL").
+					WithArgs(hashApiKey("tdk_first")).WillReturnRows(lookupRows().AddRow(1, 1, "alice", nil))
+				mock.ExpectExec("UPDATE ApiKey SET `LastUsedAt`=\\? WHERE `ID`=\\?").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: auth.APIKey{Subject: "alice", ReadOnly: true},
+		},
+		{
+			name: "Used recently",
+			key:  "tdk_recent",
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ApiKey").WithArgs(hashApiKey("tdk_recent")).
+					WillReturnRows(lookupRows().AddRow(2, 2, "alice", recent))
+			},
+			want: auth.APIKey{Subject: "alice"},
+		},
+		{
+			name: "Minted without authentication",
+			key:  "tdk_anonymous",
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ApiKey").WithArgs(hashApiKey("tdk_anonymous")).
+					WillReturnRows(lookupRows().AddRow(3, 2, "", recent))
+			},
+			want: auth.APIKey{Subject: "apikey:3"},
+		},
+		{
+			name: "Last used update failed",
+			key:  "tdk_first",
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ApiKey").WithArgs(hashApiKey("tdk_first")).
+					WillReturnRows(lookupRows().AddRow(1, 2, "alice", nil))
+				mock.ExpectExec("UPDATE ApiKey SET `LastUsedAt`").WillReturnError(errors.New("UPDATE failed"))
+			},
+			want: auth.APIKey{Subject: "alice"},
+		},
+		{
+			name: "Unknown or revoked",
+			key:  "tdk_revoked",
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ApiKey").WithArgs(hashApiKey("tdk_revoked")).WillReturnRows(lookupRows())
+			},
+			wantErr: auth.ErrUnknownAPIKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := s.LookupAPIKey(ctx, tt.key)
+			if err != tt.wantErr {
+				t.Errorf("apiKeyServiceServer.LookupAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("apiKeyServiceServer.LookupAPIKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// firstVersion is the version of a created task, the default of the `Version` column
+	firstVersion = 1
+
+	// ifMatchMetadata is the metadata key the HTTP gateway passes the If-Match header in
+	ifMatchMetadata = "if-match"
+)
+
+// formatEtag returns the etag of a task version
+func formatEtag(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+// firstEtag returns the first non empty etag
+func firstEtag(etags ...string) string {
+	for _, etag := range etags {
+		if len(etag) > 0 {
+			return etag
+		}
+	}
+	return ""
+}
+
+// requestEtag returns the first non empty etag, or the If-Match header passed by the HTTP gateway.
+// It returns an empty string if the write is not conditional.
+func requestEtag(ctx context.Context, etags ...string) string {
+	if etag := firstEtag(etags...); len(etag) > 0 {
+		return etag
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(ifMatchMetadata) {
+		// If-Match holds quoted etags, "*" matches any version
+		v = strings.Trim(strings.TrimPrefix(strings.TrimSpace(v), "W/"), `"`)
+		if v != "*" {
+			return v
+		}
+	}
+	return ""
+}
+
+// checkEtag locks the task out of trash and returns Aborted error if etag is not empty
+// and differs from the current etag of the task
+func checkEtag(ctx context.Context, q queryer, id int64, etag string) error {
+	if len(etag) == 0 {
+		return nil
+	}
+	var version int64
+	err := q.QueryRowContext(ctx, "SELECT `Version` FROM ToDo WHERE `ID`=? AND `DeletedAt` IS NULL FOR UPDATE", id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", id))
+	}
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	if formatEtag(version) != etag {
+		return status.Error(codes.Aborted, fmt.Sprintf("ToDo with ID='%d' has changed, etag '%s' is stale", id, etag))
+	}
+	return nil
+}
+
+// touchToDo bumps the version of a task out of trash changed outside of the ToDo table and records the change,
+// it returns NotFound error if there is no such task
+func touchToDo(ctx context.Context, q queryer, id int64) error {
+	res, err := q.ExecContext(ctx, "UPDATE ToDo SET `Version`=`Version`+1 WHERE `ID`=? AND `DeletedAt` IS NULL", id)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	if rows == 0 {
+		return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", id))
+	}
+	return recordEvents(ctx, q, v1.EventType_EVENT_TYPE_UPDATED, "`ID`=?", id)
+}
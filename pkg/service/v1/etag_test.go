@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_requestEtag(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		etags []string
+		want  string
+	}{
+		{name: "Request field", ctx: ctx, etags: []string{"", "3"}, want: "3"},
+		{name: "Request field over header", ctx: metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", `"2"`)), etags: []string{"3"}, want: "3"},
+		{name: "Quoted header", ctx: metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", `"2"`)), want: "2"},
+		{name: "Weak header", ctx: metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", `W/"2"`)), want: "2"},
+		{name: "Any version", ctx: metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", "*")), want: ""},
+		{name: "Unconditional", ctx: ctx, etags: []string{""}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestEtag(tt.ctx, tt.etags...); got != tt.want {
+				t.Errorf("requestEtag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
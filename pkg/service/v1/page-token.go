@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"hash/fnv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultPageSize is the page size used when client does not specify one
+	defaultPageSize = 100
+	// maxPageSize is the largest page size server returns
+	maxPageSize = 1000
+)
+
+// pageToken is the position of the last task returned in the previous page
+// It is sent to clients as opaque base64 encoded JSON
+type pageToken struct {
+	// Query is the hash of filter and order_by the token was issued for
+	Query uint32 `json:"q"`
+	// Values are the order_by key values of the last task in the previous page
+	Values []string `json:"v"`
+}
+
+// queryHash returns the hash of the listing parameters a page token is bound to
+func queryHash(params ...string) uint32 {
+	h := fnv.New32a()
+	for _, p := range params {
+		_, _ = h.Write([]byte(p))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum32()
+}
+
+// encodePageToken returns the opaque string representation of token
+func encodePageToken(token pageToken) string {
+	b, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken parses a token returned by encodePageToken
+func decodePageToken(s string) (pageToken, error) {
+	var token pageToken
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return token, status.Error(codes.InvalidArgument, "page_token has invalid format-> "+err.Error())
+	}
+	if err := json.Unmarshal(b, &token); err != nil {
+		return token, status.Error(codes.InvalidArgument, "page_token has invalid format-> "+err.Error())
+	}
+	return token, nil
+}
+
+// pageSize validates requested page size and applies defaults and limits
+func pageSize(size int32) (int, error) {
+	switch {
+	case size < 0:
+		return 0, status.Errorf(codes.InvalidArgument, "page_size must not be negative, got %d", size)
+	case size == 0:
+		return defaultPageSize, nil
+	case size > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(size), nil
+}
@@ -0,0 +1,210 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Positions are fractional index keys ordering tasks by byte comparison. A key is an integer part,
+// a head letter giving the number of digits followed by the digits, and an optional fraction.
+// Appending after the last key increments the integer part so that keys stay short,
+// placing a task between two keys extends the fraction.
+
+const (
+	// positionDigits are the base 62 digits of positions in ascending byte order
+	positionDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// firstPosition is the position of the first task
+	firstPosition = "a0"
+)
+
+// smallestInteger is the integer part no key can be placed before
+var smallestInteger = "A" + strings.Repeat("0", 26)
+
+// positionBetween returns a position sorted after a and before b.
+// An empty a stands for the start of the order, an empty b for its end.
+func positionBetween(a, b string) (string, error) {
+	if len(a) > 0 && len(b) > 0 && a >= b {
+		return "", fmt.Errorf("position '%s' is not before '%s'", a, b)
+	}
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return firstPosition, nil
+	case len(a) == 0:
+		ib, err := positionInteger(b)
+		if err != nil {
+			return "", err
+		}
+		if ib == smallestInteger {
+			return ib + midpoint("", b[len(ib):]), nil
+		}
+		if ib < b {
+			return ib, nil
+		}
+		res, ok := decrementInteger(ib)
+		if !ok {
+			return "", errors.New("cannot decrement any more")
+		}
+		return res, nil
+	case len(b) == 0:
+		ia, err := positionInteger(a)
+		if err != nil {
+			return "", err
+		}
+		if i, ok := incrementInteger(ia); ok {
+			return i, nil
+		}
+		return ia + midpoint(a[len(ia):], ""), nil
+	}
+
+	ia, err := positionInteger(a)
+	if err != nil {
+		return "", err
+	}
+	ib, err := positionInteger(b)
+	if err != nil {
+		return "", err
+	}
+	if ia == ib {
+		return ia + midpoint(a[len(ia):], b[len(ib):]), nil
+	}
+	i, ok := incrementInteger(ia)
+	if !ok {
+		return "", errors.New("cannot increment any more")
+	}
+	if i < b {
+		return i, nil
+	}
+	return ia + midpoint(a[len(ia):], ""), nil
+}
+
+// positionInteger returns the integer part of a position
+func positionInteger(key string) (string, error) {
+	if len(key) == 0 {
+		return "", errors.New("position is empty")
+	}
+	var n int
+	switch head := key[0]; {
+	case head >= 'a' && head <= 'z':
+		n = int(head-'a') + 2
+	case head >= 'A' && head <= 'Z':
+		n = int('Z'-head) + 2
+	default:
+		return "", fmt.Errorf("position '%s' has invalid head", key)
+	}
+	if n > len(key) {
+		return "", fmt.Errorf("position '%s' is too short", key)
+	}
+	return key[:n], nil
+}
+
+// midpoint returns a fraction sorted between fractions a and b, an empty b is the end of the order.
+// Fractions never end with a zero digit so that there is always room before them.
+func midpoint(a, b string) string {
+	if len(b) > 0 {
+		// skip the common prefix, a is padded with zeros
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			if n < len(a) {
+				a = a[n:]
+			} else {
+				a = ""
+			}
+			return b[:n] + midpoint(a, b[n:])
+		}
+	}
+	da, db := 0, len(positionDigits)
+	if len(a) > 0 {
+		da = strings.IndexByte(positionDigits, a[0])
+	}
+	if len(b) > 0 {
+		db = strings.IndexByte(positionDigits, b[0])
+	}
+	if db-da > 1 {
+		return string(positionDigits[(da+db)/2])
+	}
+	// the first digits are consecutive
+	if len(b) > 1 {
+		return b[:1]
+	}
+	var rest string
+	if len(a) > 0 {
+		rest = a[1:]
+	}
+	return string(positionDigits[da]) + midpoint(rest, "")
+}
+
+// digitAt returns the digit of fraction a at index i, zero past its end
+func digitAt(a string, i int) byte {
+	if i < len(a) {
+		return a[i]
+	}
+	return positionDigits[0]
+}
+
+// incrementInteger returns the integer part following x, false if x is the largest one
+func incrementInteger(x string) (string, bool) {
+	head, digits := x[0], []byte(x[1:])
+	carry := true
+	for i := len(digits) - 1; carry && i >= 0; i-- {
+		d := strings.IndexByte(positionDigits, digits[i]) + 1
+		if d == len(positionDigits) {
+			digits[i] = positionDigits[0]
+		} else {
+			digits[i] = positionDigits[d]
+			carry = false
+		}
+	}
+	if !carry {
+		return string(head) + string(digits), true
+	}
+	switch head {
+	case 'Z':
+		return firstPosition, true
+	case 'z':
+		return "", false
+	}
+	head++
+	if head > 'a' {
+		digits = append(digits, positionDigits[0])
+	} else {
+		digits = digits[:len(digits)-1]
+	}
+	return string(head) + string(digits), true
+}
+
+// decrementInteger returns the integer part preceding x, false if x is the smallest one
+func decrementInteger(x string) (string, bool) {
+	last := positionDigits[len(positionDigits)-1]
+	head, digits := x[0], []byte(x[1:])
+	borrow := true
+	for i := len(digits) - 1; borrow && i >= 0; i-- {
+		d := strings.IndexByte(positionDigits, digits[i]) - 1
+		if d == -1 {
+			digits[i] = last
+		} else {
+			digits[i] = positionDigits[d]
+			borrow = false
+		}
+	}
+	if !borrow {
+		return string(head) + string(digits), true
+	}
+	switch head {
+	case 'a':
+		return "Z" + string(last), true
+	case 'A':
+		return "", false
+	}
+	head--
+	if head < 'Z' {
+		digits = append(digits, last)
+	} else {
+		digits = digits[:len(digits)-1]
+	}
+	return string(head) + string(digits), true
+}
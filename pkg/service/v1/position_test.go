@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"testing"
+)
+
+func Test_positionBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       string
+		b       string
+		want    string
+		wantErr bool
+	}{
+		{name: "Empty order", want: "a0"},
+		{name: "Append", a: "a0", want: "a1"},
+		{name: "Append with carry", a: "az", want: "b00"},
+		{name: "Append after fraction", a: "a0V", want: "a1"},
+		{name: "Prepend", b: "a0", want: "Zz"},
+		{name: "Prepend before fraction", b: "a0V", want: "a0"},
+		{name: "Prepend with borrow", b: "b00", want: "az"},
+		{name: "Between integers", a: "a0", b: "a2", want: "a1"},
+		{name: "Between consecutive integers", a: "a0", b: "a1", want: "a0V"},
+		{name: "Between fractions", a: "a0V", b: "a1", want: "a0k"},
+		{name: "Between consecutive fractions", a: "a0V", b: "a0W", want: "a0VV"},
+		{name: "Before fraction", a: "a0", b: "a01", want: "a00V"},
+		{name: "Not before", a: "a1", b: "a0", wantErr: true},
+		{name: "Equal", a: "a1", b: "a1", wantErr: true},
+		{name: "Invalid head", a: "!0", wantErr: true},
+		{name: "Too short", a: "c0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := positionBetween(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("positionBetween() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("positionBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_positionBetween_order(t *testing.T) {
+	// insert repeatedly at the start, the end and before the same key, keys must stay sorted
+	keys := []string{firstPosition}
+	for i := 0; i < 500; i++ {
+		first, err := positionBetween("", keys[0])
+		if err != nil {
+			t.Fatalf("positionBetween() error = %v", err)
+		}
+		last, err := positionBetween(keys[len(keys)-1], "")
+		if err != nil {
+			t.Fatalf("positionBetween() error = %v", err)
+		}
+		keys = append([]string{first}, append(keys, last)...)
+
+		mid := len(keys) / 2
+		between, err := positionBetween(keys[mid-1], keys[mid])
+		if err != nil {
+			t.Fatalf("positionBetween() error = %v", err)
+		}
+		keys = append(keys[:mid], append([]string{between}, keys[mid:]...)...)
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Fatalf("position %d '%s' is not before '%s'", i-1, keys[i-1], keys[i])
+		}
+	}
+	if n := len(keys[len(keys)-1]); n > 4 {
+		t.Errorf("appended position has %d characters, want at most 4", n)
+	}
+}
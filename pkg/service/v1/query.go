@@ -0,0 +1,614 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// fieldKind is the type of a ToDo field used in filter and order_by
+type fieldKind int
+
+const (
+	stringField fieldKind = iota
+	intField
+	timeField
+	boolField
+	enumField
+)
+
+// queryField describes a ToDo field clients can filter and order by
+type queryField struct {
+	// column is the quoted name of the ToDo table column
+	column string
+	// kind is the type of the field value
+	kind fieldKind
+	// nullable fields may be NULL, an empty page token value stands for NULL
+	nullable bool
+	// enum maps names of enumField values to numbers
+	enum map[string]int32
+	// value returns the field value of a task formatted for a page token
+	value func(td *v1.ToDo) string
+}
+
+// toDoFields are ToDo fields supported by ReadAll filter and order_by
+var toDoFields = map[string]queryField{
+	"id": {
+		column: "`ID`",
+		kind:   intField,
+		value:  func(td *v1.ToDo) string { return strconv.FormatInt(td.Id, 10) },
+	},
+	"title": {
+		column: "`Title`",
+		kind:   stringField,
+		value:  func(td *v1.ToDo) string { return td.Title },
+	},
+	"description": {
+		column: "`Description`",
+		kind:   stringField,
+		value:  func(td *v1.ToDo) string { return td.Description },
+	},
+	"reminder": {
+		column: "`Reminder`",
+		kind:   timeField,
+		value:  func(td *v1.ToDo) string { return formatTimestamp(td.Reminder) },
+	},
+	"completed": {
+		column: "`Completed`",
+		kind:   boolField,
+		value:  func(td *v1.ToDo) string { return strconv.FormatBool(td.Completed) },
+	},
+	"completed_at": {
+		column:   "`CompletedAt`",
+		kind:     timeField,
+		nullable: true,
+		value:    func(td *v1.ToDo) string { return formatTimestamp(td.CompletedAt) },
+	},
+	"due": {
+		column:   "`Due`",
+		kind:     timeField,
+		nullable: true,
+		value:    func(td *v1.ToDo) string { return formatTimestamp(td.Due) },
+	},
+	"priority": {
+		column: "`Priority`",
+		kind:   enumField,
+		enum:   v1.Priority_value,
+		value:  func(td *v1.ToDo) string { return strconv.Itoa(int(td.Priority)) },
+	},
+	"parent_id": {
+		column:   "`ParentID`",
+		kind:     intField,
+		nullable: true,
+		value: func(td *v1.ToDo) string {
+			if td.ParentId == 0 {
+				return ""
+			}
+			return strconv.FormatInt(td.ParentId, 10)
+		},
+	},
+	"recurrence": {
+		column: "`Recurrence`",
+		kind:   stringField,
+		value:  func(td *v1.ToDo) string { return td.Recurrence },
+	},
+	"time_zone": {
+		column: "`TimeZone`",
+		kind:   stringField,
+		value:  func(td *v1.ToDo) string { return td.TimeZone },
+	},
+	"position": {
+		column: "`Position`",
+		kind:   stringField,
+		value:  func(td *v1.ToDo) string { return td.Position },
+	},
+	"deleted_at": {
+		column:   "`DeletedAt`",
+		kind:     timeField,
+		nullable: true,
+		value:    func(td *v1.ToDo) string { return formatTimestamp(td.DeletedAt) },
+	},
+}
+
+// lookupField returns the ToDo field by name or InvalidArgument error
+func lookupField(name string) (queryField, error) {
+	f, ok := toDoFields[name]
+	if !ok {
+		return f, status.Errorf(codes.InvalidArgument, "unknown field '%s'", name)
+	}
+	return f, nil
+}
+
+// parseValue converts a literal from filter or page token to a query argument
+func (f queryField) parseValue(name, literal string) (interface{}, error) {
+	switch f.kind {
+	case enumField:
+		// accept numbers from page tokens and names like HIGH or PRIORITY_HIGH from filters
+		if v, err := strconv.ParseInt(literal, 10, 32); err == nil {
+			for _, n := range f.enum {
+				if n == int32(v) {
+					return v, nil
+				}
+			}
+		}
+		upper := strings.ToUpper(literal)
+		for n, v := range f.enum {
+			if n == upper || strings.HasSuffix(n, "_"+upper) {
+				return int64(v), nil
+			}
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "field '%s' has no value '%s'", name, literal)
+	case intField:
+		v, err := strconv.ParseInt(literal, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "field '%s' expects an integer, got '%s'", name, literal)
+		}
+		return v, nil
+	case timeField:
+		v, err := time.Parse(time.RFC3339Nano, literal)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "field '%s' expects an RFC 3339 timestamp, got '%s'", name, literal)
+		}
+		return v.UTC(), nil
+	case boolField:
+		v, err := strconv.ParseBool(literal)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "field '%s' expects true or false, got '%s'", name, literal)
+		}
+		return v, nil
+	}
+	return literal, nil
+}
+
+// condition is a part of SQL WHERE clause with its parameters
+type condition struct {
+	sql  string
+	args []interface{}
+}
+
+// whereSQL joins conditions with AND into a WHERE clause
+// It returns an empty clause if there are no conditions
+func whereSQL(conds []condition) (string, []interface{}) {
+	if len(conds) == 0 {
+		return "", nil
+	}
+	var args []interface{}
+	parts := make([]string, 0, len(conds))
+	for _, c := range conds {
+		parts = append(parts, c.sql)
+		args = append(args, c.args...)
+	}
+	return " WHERE " + strings.Join(parts, " AND "), args
+}
+
+// filterOperators maps filter comparison operators to SQL ones
+// The longer operators go first so that "<=" is not read as "<"
+var filterOperators = []struct {
+	op  string
+	sql string
+}{
+	{"!=", "<>"},
+	{"<=", "<="},
+	{">=", ">="},
+	{"=", "="},
+	{"<", "<"},
+	{">", ">"},
+	{":", "LIKE"},
+}
+
+// parseFilter parses a filter expression into SQL conditions
+//
+// The filter is a subset of https://google.aip.dev/160: comparisons joined with AND,
+// for example `title:"report" AND reminder>="2020-01-01T00:00:00Z" AND id<100`.
+// Supported operators are =, !=, <, <=, >, >= and : (has substring, strings only).
+// Values containing spaces or operator characters, like timestamps, must be quoted.
+func parseFilter(filter string) ([]condition, error) {
+	var conds []condition
+	p := filterParser{s: filter}
+	p.skipSpace()
+	for !p.eof() {
+		if len(conds) > 0 {
+			if !p.consume("AND") {
+				return nil, p.errorf("expected AND")
+			}
+			p.skipSpace()
+		}
+		cond, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+		p.skipSpace()
+	}
+	return conds, nil
+}
+
+// filterParser is a cursor over the filter expression
+type filterParser struct {
+	s   string
+	pos int
+}
+
+func (p *filterParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *filterParser) skipSpace() {
+	for !p.eof() && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *filterParser) consume(token string) bool {
+	if strings.HasPrefix(p.s[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) errorf(format string, args ...interface{}) error {
+	return status.Errorf(codes.InvalidArgument, "invalid filter at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// comparison parses `field op value`
+func (p *filterParser) comparison() (condition, error) {
+	start := p.pos
+	for !p.eof() && (p.s[p.pos] == '_' || p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z') {
+		p.pos++
+	}
+	name := p.s[start:p.pos]
+	if len(name) == 0 {
+		return condition{}, p.errorf("expected field name")
+	}
+	field, err := lookupField(name)
+	if err != nil {
+		return condition{}, err
+	}
+
+	p.skipSpace()
+	op := ""
+	for _, o := range filterOperators {
+		if p.consume(o.op) {
+			op = o.sql
+			break
+		}
+	}
+	if len(op) == 0 {
+		return condition{}, p.errorf("expected comparison operator after '%s'", name)
+	}
+
+	p.skipSpace()
+	literal, err := p.value()
+	if err != nil {
+		return condition{}, err
+	}
+
+	if op == "LIKE" {
+		if field.kind != stringField {
+			return condition{}, status.Errorf(codes.InvalidArgument, "operator ':' is not supported by field '%s'", name)
+		}
+		return condition{
+			sql:  field.column + " LIKE ?",
+			args: []interface{}{"%" + escapeLike(literal) + "%"},
+		}, nil
+	}
+
+	v, err := field.parseValue(name, literal)
+	if err != nil {
+		return condition{}, err
+	}
+	return condition{sql: field.column + op + "?", args: []interface{}{v}}, nil
+}
+
+// value parses a quoted string or a bare word
+func (p *filterParser) value() (string, error) {
+	if p.eof() {
+		return "", p.errorf("expected value")
+	}
+	if p.s[p.pos] != '"' {
+		start := p.pos
+		for !p.eof() && !strings.ContainsRune(` "=!<>:`, rune(p.s[p.pos])) {
+			p.pos++
+		}
+		if start == p.pos {
+			return "", p.errorf("expected value")
+		}
+		return p.s[start:p.pos], nil
+	}
+
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.s[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", p.errorf("unterminated string")
+			}
+			c = p.s[p.pos]
+			p.pos++
+		}
+		b.WriteByte(c)
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// escapeLike escapes LIKE wildcards so that s is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// orderKey is a field tasks are sorted by
+type orderKey struct {
+	name  string
+	field queryField
+	desc  bool
+}
+
+// parseOrderBy parses a comma separated list of fields, each optionally followed by
+// "asc" or "desc", for example "reminder desc, title".
+// Tasks are sorted by position if orderBy is empty.
+// ID is always added as the last key so that the order is total.
+func parseOrderBy(orderBy string) ([]orderKey, error) {
+	var keys []orderKey
+	seen := map[string]bool{}
+	if len(strings.TrimSpace(orderBy)) > 0 {
+		for _, part := range strings.Split(orderBy, ",") {
+			words := strings.Fields(part)
+			if len(words) == 0 || len(words) > 2 {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid order_by '%s'", orderBy)
+			}
+			field, err := lookupField(words[0])
+			if err != nil {
+				return nil, err
+			}
+			if seen[words[0]] {
+				return nil, status.Errorf(codes.InvalidArgument, "field '%s' is repeated in order_by", words[0])
+			}
+			seen[words[0]] = true
+			key := orderKey{name: words[0], field: field}
+			if len(words) == 2 {
+				switch words[1] {
+				case "asc":
+				case "desc":
+					key.desc = true
+				default:
+					return nil, status.Errorf(codes.InvalidArgument, "invalid sort direction '%s' in order_by", words[1])
+				}
+			}
+			keys = append(keys, key)
+		}
+	} else {
+		keys = append(keys, orderKey{name: "position", field: toDoFields["position"]})
+	}
+	if !seen["id"] {
+		keys = append(keys, orderKey{name: "id", field: toDoFields["id"]})
+	}
+	return keys, nil
+}
+
+// orderBySQL returns the SQL ORDER BY list for keys
+func orderBySQL(keys []orderKey) string {
+	cols := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k.desc {
+			cols = append(cols, k.field.column+" DESC")
+		} else {
+			cols = append(cols, k.field.column)
+		}
+	}
+	return strings.Join(cols, ", ")
+}
+
+// keysetValues returns the values of keys of td to continue the listing after it
+func keysetValues(keys []orderKey, td *v1.ToDo) []string {
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, k.field.value(td))
+	}
+	return values
+}
+
+// keysetCondition returns the condition selecting rows sorted after values
+func keysetCondition(keys []orderKey, values []string) (condition, error) {
+	if len(values) != len(keys) {
+		return condition{}, status.Error(codes.InvalidArgument, "page_token does not match order_by")
+	}
+	args := make([]interface{}, 0, len(keys))
+	for i, k := range keys {
+		if k.field.nullable && len(values[i]) == 0 {
+			args = append(args, nil)
+			continue
+		}
+		v, err := k.field.parseValue(k.name, values[i])
+		if err != nil {
+			return condition{}, status.Error(codes.InvalidArgument, "page_token has invalid format-> "+err.Error())
+		}
+		args = append(args, v)
+	}
+
+	// (k1>v1) OR (k1=v1 AND k2>v2) OR ...
+	var cond condition
+	var ors []string
+	for i, k := range keys {
+		after, ok := k.after(args[i])
+		if !ok {
+			continue
+		}
+		var ands []string
+		for j := 0; j < i; j++ {
+			eq := keys[j].equal(args[j])
+			ands = append(ands, eq.sql)
+			cond.args = append(cond.args, eq.args...)
+		}
+		ands = append(ands, after.sql)
+		cond.args = append(cond.args, after.args...)
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	if len(ors) == 0 {
+		cond.sql = "FALSE"
+		return cond, nil
+	}
+	cond.sql = "(" + strings.Join(ors, " OR ") + ")"
+	return cond, nil
+}
+
+// equal returns the condition selecting rows with key value v, nil is NULL
+func (k orderKey) equal(v interface{}) condition {
+	if v == nil {
+		return condition{sql: k.field.column + " IS NULL"}
+	}
+	return condition{sql: k.field.column + "=?", args: []interface{}{v}}
+}
+
+// after returns the condition selecting rows sorted after key value v, nil is NULL.
+// MySQL sorts NULL before any value. It returns false if no row can follow v.
+func (k orderKey) after(v interface{}) (condition, bool) {
+	switch {
+	case v == nil && k.desc:
+		return condition{}, false
+	case v == nil:
+		return condition{sql: k.field.column + " IS NOT NULL"}, true
+	case k.desc && k.field.nullable:
+		return condition{sql: "(" + k.field.column + "<? OR " + k.field.column + " IS NULL)", args: []interface{}{v}}, true
+	case k.desc:
+		return condition{sql: k.field.column + "<?", args: []interface{}{v}}, true
+	}
+	return condition{sql: k.field.column + ">?", args: []interface{}{v}}, true
+}
+
+// updatableFields are the ToDo fields Update writes, in SET clause order
+var updatableFields = []string{"title", "description", "reminder", "due", "priority", "parent_id", "recurrence", "time_zone"}
+
+// updateAssignments returns the SQL SET list and its arguments writing the fields of td
+// listed in mask, and the set of written fields. All updatable fields are written
+// if mask is empty or "*". The id and etag paths are ignored, as they select the task to update.
+func updateAssignments(td *v1.ToDo, mask *field_mask.FieldMask) (string, []interface{}, map[string]bool, error) {
+	paths := map[string]bool{}
+	for _, p := range mask.GetPaths() {
+		switch {
+		case p == "*":
+			for _, f := range updatableFields {
+				paths[f] = true
+			}
+		case p == "id" || p == "etag":
+		default:
+			if _, err := lookupUpdatable(p); err != nil {
+				return "", nil, nil, err
+			}
+			paths[p] = true
+		}
+	}
+	if len(mask.GetPaths()) == 0 {
+		for _, f := range updatableFields {
+			paths[f] = true
+		}
+	}
+	if len(paths) == 0 {
+		return "", nil, nil, status.Error(codes.InvalidArgument, "update_mask does not contain any field to update")
+	}
+
+	var set []string
+	var args []interface{}
+	for _, f := range updatableFields {
+		if !paths[f] {
+			continue
+		}
+		var v interface{}
+		switch f {
+		case "title":
+			v = td.Title
+		case "description":
+			v = td.Description
+		case "reminder":
+			reminder, err := ptypes.Timestamp(td.Reminder)
+			if err != nil {
+				return "", nil, nil, status.Error(codes.InvalidArgument, "reminder field has invalid format->"+err.Error())
+			}
+			v = reminder
+		case "due":
+			due, err := nullableTime(td.Due, "due")
+			if err != nil {
+				return "", nil, nil, err
+			}
+			v = due
+		case "priority":
+			if err := checkPriority(td.Priority); err != nil {
+				return "", nil, nil, err
+			}
+			v = int32(td.Priority)
+		case "parent_id":
+			v = nullableID(td.ParentId)
+		case "recurrence":
+			if err := checkRecurrence(td.Recurrence, ""); err != nil {
+				return "", nil, nil, err
+			}
+			v = td.Recurrence
+		case "time_zone":
+			if _, err := loadLocation(td.TimeZone); err != nil {
+				return "", nil, nil, err
+			}
+			v = td.TimeZone
+		}
+		set = append(set, toDoFields[f].column+"=?")
+		args = append(args, v)
+	}
+	// changing the schedule starts a new recurrence series at the reminder,
+	// MySQL assigns in order so the new reminder is used
+	if paths["reminder"] || paths["recurrence"] {
+		set = append(set, "`RecurrenceStart`=`Reminder`")
+	}
+	return strings.Join(set, ", "), args, paths, nil
+}
+
+// lookupUpdatable checks that Update can write the field
+func lookupUpdatable(name string) (string, error) {
+	for _, f := range updatableFields {
+		if f == name {
+			return f, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown field '%s' in update_mask", name)
+}
+
+// nullableTime converts an optional timestamp field to a query argument, nil if not set
+func nullableTime(ts *timestamp.Timestamp, name string) (interface{}, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, name+" field has invalid format->"+err.Error())
+	}
+	return t, nil
+}
+
+// checkPriority rejects values not defined by the Priority enum
+func checkPriority(p v1.Priority) error {
+	if _, ok := v1.Priority_name[int32(p)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "priority field has unknown value %d", p)
+	}
+	return nil
+}
+
+// formatTimestamp formats ts for a page token, not set timestamp is an empty string
+func formatTimestamp(ts *timestamp.Timestamp) string {
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
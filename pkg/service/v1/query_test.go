@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parseFilter(t *testing.T) {
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filter  string
+		want    []condition
+		wantErr bool
+	}{
+		{
+			name:   "Empty",
+			filter: "  ",
+		},
+		{
+			name:   "Substring",
+			filter: `title:"a_b"`,
+			want: []condition{
+				{sql: "`Title` LIKE ?", args: []interface{}{`%a\_b%`}},
+			},
+		},
+		{
+			name:   "Reminder window and ID range",
+			filter: `reminder >= "2020-01-02T03:04:05Z" AND reminder<"2020-01-02T03:04:05Z" AND id!=7`,
+			want: []condition{
+				{sql: "`Reminder`>=?", args: []interface{}{tm}},
+				{sql: "`Reminder`<?", args: []interface{}{tm}},
+				{sql: "`ID`<>?", args: []interface{}{int64(7)}},
+			},
+		},
+		{
+			name:   "Quoted escapes",
+			filter: `description="say \"hi\""`,
+			want: []condition{
+				{sql: "`Description`=?", args: []interface{}{`say "hi"`}},
+			},
+		},
+		{
+			name:   "Exclude completed",
+			filter: `completed=false`,
+			want: []condition{
+				{sql: "`Completed`=?", args: []interface{}{false}},
+			},
+		},
+		{
+			name:   "Priority and due",
+			filter: `priority>=high AND due<"2020-01-02T03:04:05Z"`,
+			want: []condition{
+				{sql: "`Priority`>=?", args: []interface{}{int64(3)}},
+				{sql: "`Due`<?", args: []interface{}{tm}},
+			},
+		},
+		{
+			name:    "Unknown priority",
+			filter:  `priority=critical`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown field",
+			filter:  `owner=1`,
+			wantErr: true,
+		},
+		{
+			name:    "Substring on ID",
+			filter:  `id:1`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid timestamp",
+			filter:  `reminder>yesterday`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing AND",
+			filter:  `id=1 id=2`,
+			wantErr: true,
+		},
+		{
+			name:    "Unterminated string",
+			filter:  `title="abc`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_keysetCondition(t *testing.T) {
+	keys, err := parseOrderBy("title desc, reminder")
+	if err != nil {
+		t.Fatalf("parseOrderBy() error = %v", err)
+	}
+	if got := orderBySQL(keys); got != "`Title` DESC, `Reminder`, `ID`" {
+		t.Errorf("orderBySQL() = %v", got)
+	}
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	got, err := keysetCondition(keys, []string{"t", tm.Format(time.RFC3339Nano), "5"})
+	if err != nil {
+		t.Fatalf("keysetCondition() error = %v", err)
+	}
+	want := condition{
+		sql:  "((`Title`<?) OR (`Title`=? AND `Reminder`>?) OR (`Title`=? AND `Reminder`=? AND `ID`>?))",
+		args: []interface{}{"t", "t", tm, "t", tm, int64(5)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("keysetCondition() = %v, want %v", got, want)
+	}
+
+	if _, err := keysetCondition(keys, []string{"5"}); err == nil {
+		t.Errorf("keysetCondition() expected error for mismatched values")
+	}
+
+	keys, err = parseOrderBy("due desc")
+	if err != nil {
+		t.Fatalf("parseOrderBy() error = %v", err)
+	}
+	got, err = keysetCondition(keys, []string{"", "5"})
+	if err != nil {
+		t.Fatalf("keysetCondition() error = %v", err)
+	}
+	want = condition{
+		sql:  "((`Due` IS NULL AND `ID`>?))",
+		args: []interface{}{int64(5)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("keysetCondition() after NULL descending = %v, want %v", got, want)
+	}
+
+	keys[0].desc = false
+	got, err = keysetCondition(keys, []string{"", "5"})
+	if err != nil {
+		t.Fatalf("keysetCondition() error = %v", err)
+	}
+	want = condition{
+		sql:  "((`Due` IS NOT NULL) OR (`Due` IS NULL AND `ID`>?))",
+		args: []interface{}{int64(5)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("keysetCondition() after NULL ascending = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,513 @@
+package v1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurrencePeriods is the number of consecutive periods without an occurrence
+// after which expansion of a rule gives up, for rules like FEBRUARY 30th
+const maxRecurrencePeriods = 10000
+
+// frequency is the FREQ part of a recurrence rule
+type frequency int
+
+const (
+	daily frequency = iota
+	weekly
+	monthly
+	yearly
+)
+
+// frequencies maps FREQ values to frequencies
+var frequencies = map[string]frequency{
+	"DAILY":   daily,
+	"WEEKLY":  weekly,
+	"MONTHLY": monthly,
+	"YEARLY":  yearly,
+}
+
+// weekdays maps BYDAY and WKST day names to weekdays
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// weekdayNum is a BYDAY entry, n is the occurrence of the weekday in the month
+// or year counted from the end if negative, 0 for every such weekday
+type weekdayNum struct {
+	n   int
+	day time.Weekday
+}
+
+// recurrenceRule is a parsed RFC 5545 RRULE
+//
+// Supported parts are FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL,
+// BYDAY, BYMONTHDAY, BYMONTH and WKST. Occurrences are computed in the time zone of
+// the start time, so a task at 9:00 stays at 9:00 when daylight saving time changes.
+// As in most implementations the start time is an occurrence only if it matches the rule.
+type recurrenceRule struct {
+	freq     frequency
+	interval int
+	count    int
+	// until is the last possible occurrence, zero if not limited.
+	// A floating UNTIL without time zone is kept in UTC and read as local time of the start.
+	until         time.Time
+	untilFloating bool
+	byDay         []weekdayNum
+	byMonthDay    []int
+	byMonth       []time.Month
+	weekStart     time.Weekday
+}
+
+// parseRecurrence parses an RRULE value, with or without the "RRULE:" prefix
+func parseRecurrence(s string) (*recurrenceRule, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	r := &recurrenceRule{freq: -1, interval: 1, weekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || len(kv[1]) == 0 {
+			return nil, fmt.Errorf("rule part '%s' is not NAME=VALUE", part)
+		}
+		name, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		if seen[name] {
+			return nil, fmt.Errorf("rule part %s is repeated", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			f, ok := frequencies[value]
+			if !ok {
+				return nil, fmt.Errorf("FREQ=%s is not supported", value)
+			}
+			r.freq = f
+		case "INTERVAL":
+			if r.interval, err = strconv.Atoi(value); err != nil || r.interval < 1 {
+				return nil, fmt.Errorf("INTERVAL must be a positive integer, got '%s'", value)
+			}
+		case "COUNT":
+			if r.count, err = strconv.Atoi(value); err != nil || r.count < 1 {
+				return nil, fmt.Errorf("COUNT must be a positive integer, got '%s'", value)
+			}
+		case "UNTIL":
+			if r.until, r.untilFloating, err = parseUntil(value); err != nil {
+				return nil, err
+			}
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				wd, err := parseWeekdayNum(v)
+				if err != nil {
+					return nil, err
+				}
+				r.byDay = append(r.byDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				d, err := strconv.Atoi(v)
+				if err != nil || d == 0 || d < -31 || d > 31 {
+					return nil, fmt.Errorf("BYMONTHDAY must be between 1 and 31 or -31 and -1, got '%s'", v)
+				}
+				r.byMonthDay = append(r.byMonthDay, d)
+			}
+		case "BYMONTH":
+			for _, v := range strings.Split(value, ",") {
+				m, err := strconv.Atoi(v)
+				if err != nil || m < 1 || m > 12 {
+					return nil, fmt.Errorf("BYMONTH must be between 1 and 12, got '%s'", v)
+				}
+				r.byMonth = append(r.byMonth, time.Month(m))
+			}
+		case "WKST":
+			wd, ok := weekdays[value]
+			if !ok {
+				return nil, fmt.Errorf("WKST has unknown day '%s'", value)
+			}
+			r.weekStart = wd
+		default:
+			return nil, fmt.Errorf("rule part %s is not supported", name)
+		}
+	}
+
+	switch {
+	case r.freq < 0:
+		return nil, fmt.Errorf("FREQ is required")
+	case r.count > 0 && !r.until.IsZero():
+		return nil, fmt.Errorf("COUNT and UNTIL must not be used together")
+	case r.freq == weekly && len(r.byMonthDay) > 0:
+		return nil, fmt.Errorf("BYMONTHDAY must not be used with FREQ=WEEKLY")
+	}
+	if r.freq == daily || r.freq == weekly {
+		for _, wd := range r.byDay {
+			if wd.n != 0 {
+				return nil, fmt.Errorf("BYDAY with a number is only supported with FREQ=MONTHLY or FREQ=YEARLY")
+			}
+		}
+	}
+	return r, nil
+}
+
+// parseUntil parses UNTIL as a UTC date-time, a floating date-time or a date.
+// A date includes the whole day.
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Nanosecond), true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("UNTIL has invalid format '%s'", value)
+}
+
+// parseWeekdayNum parses a BYDAY entry like MO, 2TU or -1FR
+func parseWeekdayNum(v string) (weekdayNum, error) {
+	if len(v) < 2 {
+		return weekdayNum{}, fmt.Errorf("BYDAY has invalid day '%s'", v)
+	}
+	day, ok := weekdays[v[len(v)-2:]]
+	if !ok {
+		return weekdayNum{}, fmt.Errorf("BYDAY has invalid day '%s'", v)
+	}
+	wd := weekdayNum{day: day}
+	if len(v) > 2 {
+		n, err := strconv.Atoi(v[:len(v)-2])
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return weekdayNum{}, fmt.Errorf("BYDAY has invalid day '%s'", v)
+		}
+		wd.n = n
+	}
+	return wd, nil
+}
+
+// next returns the first occurrence strictly after the time
+func (r *recurrenceRule) next(start, after time.Time) (time.Time, bool) {
+	it := r.iterate(start, after)
+	for {
+		t, ok := it.next()
+		if !ok {
+			return time.Time{}, false
+		}
+		if t.After(after) {
+			return t, true
+		}
+	}
+}
+
+// between returns at most limit occurrences in the window [from, to)
+func (r *recurrenceRule) between(start, from, to time.Time, limit int) []time.Time {
+	var list []time.Time
+	it := r.iterate(start, from)
+	for len(list) < limit {
+		t, ok := it.next()
+		if !ok || !t.Before(to) {
+			break
+		}
+		if !t.Before(from) {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+// recurrenceIterator generates occurrences of a rule in time order, period by period
+type recurrenceIterator struct {
+	rule  *recurrenceRule
+	start time.Time
+	until time.Time
+	// period is the index of the next period to generate, counted in INTERVAL steps
+	period  int
+	pending []time.Time
+	emitted int
+	done    bool
+}
+
+// iterate starts generating occurrences of the rule beginning at start, in its time zone.
+// If the rule has no COUNT, periods ending before from are skipped.
+func (r *recurrenceRule) iterate(start, from time.Time) *recurrenceIterator {
+	it := &recurrenceIterator{rule: r, start: start, until: r.until}
+	if r.untilFloating {
+		u := r.until
+		it.until = time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), u.Nanosecond(), start.Location())
+	}
+	if r.count == 0 && from.After(start) {
+		// the period containing from is at least this many steps away, one step back
+		// covers periods that start before their first occurrence
+		it.period = r.unitsBetween(start, from.In(start.Location()))/r.interval - 1
+		if it.period < 0 {
+			it.period = 0
+		}
+	}
+	return it
+}
+
+// next returns the next occurrence, false when the rule has no more
+func (it *recurrenceIterator) next() (time.Time, bool) {
+	for len(it.pending) == 0 {
+		if it.done {
+			return time.Time{}, false
+		}
+		it.generate()
+	}
+	t := it.pending[0]
+	it.pending = it.pending[1:]
+	it.emitted++
+	if it.rule.count > 0 && it.emitted >= it.rule.count {
+		it.pending = nil
+		it.done = true
+	}
+	return t, true
+}
+
+// generate fills pending with occurrences of the next periods
+func (it *recurrenceIterator) generate() {
+	r := it.rule
+	for empty := 0; empty < maxRecurrencePeriods; empty++ {
+		first, days := r.periodDays(it.start, it.period*r.interval)
+		it.period++
+		if !it.until.IsZero() && first.After(it.until) {
+			break
+		}
+		for _, d := range days {
+			t := time.Date(d.Year(), d.Month(), d.Day(), it.start.Hour(), it.start.Minute(), it.start.Second(), it.start.Nanosecond(), it.start.Location())
+			if t.Before(it.start) {
+				continue
+			}
+			if !it.until.IsZero() && t.After(it.until) {
+				it.done = true
+				break
+			}
+			it.pending = append(it.pending, t)
+		}
+		if len(it.pending) > 0 || it.done {
+			return
+		}
+	}
+	it.done = true
+}
+
+// unitsBetween returns the number of whole FREQ units between the periods of two times
+func (r *recurrenceRule) unitsBetween(a, b time.Time) int {
+	switch r.freq {
+	case daily:
+		return civilDays(a, b)
+	case weekly:
+		return (civilDays(r.weekOf(a), r.weekOf(b))) / 7
+	case monthly:
+		return (b.Year()-a.Year())*12 + int(b.Month()) - int(a.Month())
+	}
+	return b.Year() - a.Year()
+}
+
+// civilDays returns the number of calendar days from the date of a to the date of b
+func civilDays(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
+
+// weekOf returns the first day of the week containing t
+func (r *recurrenceRule) weekOf(t time.Time) time.Time {
+	back := (int(t.Weekday()) - int(r.weekStart) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-back, 0, 0, 0, 0, t.Location())
+}
+
+// periodDays returns the midnight starting the period which is units FREQ units
+// after the period of start, and the sorted candidate days in it
+func (r *recurrenceRule) periodDays(start time.Time, units int) (time.Time, []time.Time) {
+	loc := start.Location()
+	var first time.Time
+	var days []time.Time
+	switch r.freq {
+	case daily:
+		first = time.Date(start.Year(), start.Month(), start.Day()+units, 0, 0, 0, 0, loc)
+		if r.matchMonth(first.Month()) && r.matchMonthDay(first) && r.matchWeekday(first) {
+			days = append(days, first)
+		}
+	case weekly:
+		week := r.weekOf(start)
+		first = time.Date(week.Year(), week.Month(), week.Day()+7*units, 0, 0, 0, 0, loc)
+		for i := 0; i < 7; i++ {
+			d := time.Date(first.Year(), first.Month(), first.Day()+i, 0, 0, 0, 0, loc)
+			if !r.matchMonth(d.Month()) {
+				continue
+			}
+			if len(r.byDay) > 0 && r.matchWeekday(d) || len(r.byDay) == 0 && d.Weekday() == start.Weekday() {
+				days = append(days, d)
+			}
+		}
+	case monthly:
+		first = time.Date(start.Year(), start.Month()+time.Month(units), 1, 0, 0, 0, 0, loc)
+		if r.matchMonth(first.Month()) {
+			days = r.monthDays(start, first)
+		}
+	case yearly:
+		first = time.Date(start.Year()+units, time.January, 1, 0, 0, 0, 0, loc)
+		switch {
+		case len(r.byMonth) > 0:
+			for m := time.January; m <= time.December; m++ {
+				if r.matchMonth(m) {
+					days = append(days, r.monthDays(start, time.Date(first.Year(), m, 1, 0, 0, 0, 0, loc))...)
+				}
+			}
+		case len(r.byMonthDay) == 0 && len(r.byDay) > 0:
+			days = r.yearDays(first)
+		case len(r.byMonthDay) > 0:
+			for m := time.January; m <= time.December; m++ {
+				days = append(days, r.monthDays(start, time.Date(first.Year(), m, 1, 0, 0, 0, 0, loc))...)
+			}
+		default:
+			d := time.Date(first.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+			if d.Day() == start.Day() {
+				days = append(days, d)
+			}
+		}
+	}
+	return first, days
+}
+
+// monthDays returns days of the month starting at first selected by BYMONTHDAY and BYDAY,
+// or the day of month of start if there are neither
+func (r *recurrenceRule) monthDays(start, first time.Time) []time.Time {
+	n := first.AddDate(0, 1, -1).Day()
+	selected := make([]bool, n+1)
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		if start.Day() <= n {
+			selected[start.Day()] = true
+		}
+	}
+
+	var byDay []bool
+	if len(r.byDay) > 0 {
+		byDay = make([]bool, n+1)
+		for _, wd := range r.byDay {
+			// day of month of the first such weekday
+			d := 1 + (int(wd.day)-int(first.Weekday())+7)%7
+			var matches []int
+			for ; d <= n; d += 7 {
+				matches = append(matches, d)
+			}
+			for _, d := range pickNth(matches, wd.n) {
+				byDay[d] = true
+			}
+		}
+	}
+
+	if len(r.byMonthDay) > 0 {
+		for _, md := range r.byMonthDay {
+			d := md
+			if d < 0 {
+				d = n + 1 + d
+			}
+			if d >= 1 && d <= n && (byDay == nil || byDay[d]) {
+				selected[d] = true
+			}
+		}
+	} else if byDay != nil {
+		selected = byDay
+	}
+
+	var days []time.Time
+	for d := 1; d <= n; d++ {
+		if selected[d] {
+			days = append(days, time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, first.Location()))
+		}
+	}
+	return days
+}
+
+// yearDays returns days of the year starting at first selected by BYDAY,
+// numbered weekdays count within the whole year
+func (r *recurrenceRule) yearDays(first time.Time) []time.Time {
+	n := time.Date(first.Year(), time.December, 31, 0, 0, 0, 0, first.Location()).YearDay()
+	selected := map[int]bool{}
+	for _, wd := range r.byDay {
+		d := 1 + (int(wd.day)-int(first.Weekday())+7)%7
+		var matches []int
+		for ; d <= n; d += 7 {
+			matches = append(matches, d)
+		}
+		for _, d := range pickNth(matches, wd.n) {
+			selected[d] = true
+		}
+	}
+	list := make([]int, 0, len(selected))
+	for d := range selected {
+		list = append(list, d)
+	}
+	sort.Ints(list)
+	days := make([]time.Time, 0, len(list))
+	for _, d := range list {
+		days = append(days, time.Date(first.Year(), time.January, d, 0, 0, 0, 0, first.Location()))
+	}
+	return days
+}
+
+// pickNth returns the nth element of list counted from the end if n is negative,
+// or the whole list if n is 0
+func pickNth(list []int, n int) []int {
+	switch {
+	case n == 0:
+		return list
+	case n > 0 && n <= len(list):
+		return list[n-1 : n]
+	case n < 0 && -n <= len(list):
+		return list[len(list)+n : len(list)+n+1]
+	}
+	return nil
+}
+
+// matchMonth reports whether BYMONTH allows the month
+func (r *recurrenceRule) matchMonth(m time.Month) bool {
+	if len(r.byMonth) == 0 {
+		return true
+	}
+	for _, bm := range r.byMonth {
+		if bm == m {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMonthDay reports whether BYMONTHDAY allows the day
+func (r *recurrenceRule) matchMonthDay(t time.Time) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	n := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	for _, md := range r.byMonthDay {
+		if md == t.Day() || md < 0 && n+1+md == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekday reports whether BYDAY allows the day, numbered entries are not checked
+func (r *recurrenceRule) matchWeekday(t time.Time) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	for _, wd := range r.byDay {
+		if wd.day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
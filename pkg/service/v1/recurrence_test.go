@@ -0,0 +1,205 @@
+package v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parseRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{name: "Weekly", rule: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{name: "Prefix and lower case", rule: "RRULE:freq=monthly;byday=-1fr"},
+		{name: "Until", rule: "FREQ=DAILY;UNTIL=20200110T000000Z"},
+		{name: "Missing FREQ", rule: "INTERVAL=2", wantErr: true},
+		{name: "Unsupported FREQ", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "Unsupported part", rule: "FREQ=DAILY;BYSETPOS=1", wantErr: true},
+		{name: "COUNT and UNTIL", rule: "FREQ=DAILY;COUNT=2;UNTIL=20200110", wantErr: true},
+		{name: "Numbered BYDAY in weekly rule", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "Invalid BYDAY", rule: "FREQ=MONTHLY;BYDAY=XX", wantErr: true},
+		{name: "Zero INTERVAL", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "Repeated part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{name: "Empty", rule: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRecurrence(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRecurrence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_recurrenceRule_between(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %v", err)
+	}
+	utc := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("invalid test time %s: %v", s, err)
+		}
+		return tm
+	}
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		limit int
+		want  []string
+	}{
+		{
+			name:  "Weekly standup with count",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+			start: utc("2020-01-06T09:00:00Z"),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2021-01-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-01-06T09:00:00Z", "2020-01-08T09:00:00Z", "2020-01-13T09:00:00Z", "2020-01-15T09:00:00Z"},
+		},
+		{
+			name:  "Last Friday of month",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: utc("2020-01-01T17:00:00Z"),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2020-04-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-01-31T17:00:00Z", "2020-02-28T17:00:00Z", "2020-03-27T17:00:00Z"},
+		},
+		{
+			name:  "Months without the day are skipped",
+			rule:  "FREQ=MONTHLY",
+			start: utc("2020-01-31T08:00:00Z"),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2020-06-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-01-31T08:00:00Z", "2020-03-31T08:00:00Z", "2020-05-31T08:00:00Z"},
+		},
+		{
+			name:  "Last day of month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: utc("2020-01-15T08:00:00Z"),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2020-04-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-01-31T08:00:00Z", "2020-02-29T08:00:00Z", "2020-03-31T08:00:00Z"},
+		},
+		{
+			name:  "Daily across daylight saving time keeps local time",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2020, time.March, 7, 9, 0, 0, 0, newYork),
+			from:  utc("2020-03-07T00:00:00Z"),
+			to:    utc("2020-03-10T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-03-07T14:00:00Z", "2020-03-08T13:00:00Z", "2020-03-09T13:00:00Z"},
+		},
+		{
+			name:  "Floating until is local time",
+			rule:  "FREQ=DAILY;UNTIL=20200102T090000",
+			start: time.Date(2020, time.January, 1, 9, 0, 0, 0, newYork),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2021-01-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-01-01T14:00:00Z", "2020-01-02T14:00:00Z"},
+		},
+		{
+			name:  "Window far from start",
+			rule:  "FREQ=WEEKLY;INTERVAL=2",
+			start: utc("2020-01-06T09:00:00Z"),
+			from:  utc("2021-01-01T00:00:00Z"),
+			to:    utc("2021-02-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2021-01-04T09:00:00Z", "2021-01-18T09:00:00Z"},
+		},
+		{
+			name:  "Limit",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: utc("2020-01-01T09:00:00Z"),
+			from:  utc("2020-01-02T00:00:00Z"),
+			to:    utc("2021-01-01T00:00:00Z"),
+			limit: 2,
+			want:  []string{"2020-01-04T09:00:00Z", "2020-01-07T09:00:00Z"},
+		},
+		{
+			name:  "Leap day",
+			rule:  "FREQ=YEARLY",
+			start: utc("2020-02-29T09:00:00Z"),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2029-01-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-02-29T09:00:00Z", "2024-02-29T09:00:00Z", "2028-02-29T09:00:00Z"},
+		},
+		{
+			name:  "Numbered weekday of year",
+			rule:  "FREQ=YEARLY;BYDAY=20MO",
+			start: utc("2020-01-01T09:00:00Z"),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2022-01-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-05-18T09:00:00Z", "2021-05-17T09:00:00Z"},
+		},
+		{
+			name:  "Thanksgiving",
+			rule:  "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			start: utc("2020-01-01T12:00:00Z"),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2022-01-01T00:00:00Z"),
+			limit: 10,
+			want:  []string{"2020-11-26T12:00:00Z", "2021-11-25T12:00:00Z"},
+		},
+		{
+			name:  "Never matching rule",
+			rule:  "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			start: utc("2020-01-01T12:00:00Z"),
+			from:  utc("2020-01-01T00:00:00Z"),
+			to:    utc("2100-01-01T00:00:00Z"),
+			limit: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRecurrence() error = %v", err)
+			}
+			var got []string
+			for _, o := range r.between(tt.start, tt.from, tt.to, tt.limit) {
+				got = append(got, o.UTC().Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("recurrenceRule.between() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_recurrenceRule_next(t *testing.T) {
+	r, err := parseRecurrence("FREQ=WEEKLY;BYDAY=MO,FR;COUNT=3")
+	if err != nil {
+		t.Fatalf("parseRecurrence() error = %v", err)
+	}
+	start := time.Date(2020, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	got, ok := r.next(start, start)
+	if want := time.Date(2020, time.January, 10, 9, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("recurrenceRule.next() = %v, %v, want %v", got, ok, want)
+	}
+
+	got, ok = r.next(start, time.Date(2020, time.January, 10, 9, 0, 0, 0, time.UTC))
+	if want := time.Date(2020, time.January, 13, 9, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("recurrenceRule.next() = %v, %v, want %v", got, ok, want)
+	}
+
+	if got, ok = r.next(start, time.Date(2020, time.January, 13, 9, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("recurrenceRule.next() = %v after the last occurrence", got)
+	}
+}
@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/notify"
+)
+
+const (
+	// reminderPollInterval is how often RunReminderScheduler looks for due reminders
+	reminderPollInterval = 15 * time.Second
+
+	// reminderBatchSize is the largest number of reminders dispatched at once
+	reminderBatchSize = 100
+
+	// reminderLease is how long a server owns a reminder it is delivering,
+	// a reminder not delivered by then is delivered again
+	reminderLease = time.Minute
+)
+
+// RunReminderScheduler delivers due reminders of open tasks through the notifiers,
+// checking every reminderPollInterval until ctx is done.
+// A reminder is marked sent in the database only after every notifier has delivered it,
+// so it is delivered at least once even if the server stops in between.
+func RunReminderScheduler(ctx context.Context, db *sql.DB, notifiers []notify.Named) {
+	ticker := time.NewTicker(reminderPollInterval)
+	defer ticker.Stop()
+	for {
+		// keep dispatching while there may be more due reminders
+		for {
+			sent, err := dispatchReminders(ctx, db, notifiers, time.Now().UTC())
+			if err != nil {
+				log.Printf("failed to dispatch reminders: %v", err)
+			}
+			if err != nil || sent < reminderBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchReminders delivers reminders due at now which are not sent or being delivered by another server,
+// it returns the number of reminders it has tried to deliver
+func dispatchReminders(ctx context.Context, q queryer, notifiers []notify.Named, now time.Time) (int, error) {
+	// reminder changed after it has been sent is due again
+	rows, err := q.QueryContext(ctx, "SELECT "+toDoColumns+" FROM ToDo WHERE `Reminder`<=? AND (`ReminderSent` IS NULL OR `ReminderSent`<>`Reminder`) "+
+		"AND NOT `Completed` AND `DeletedAt` IS NULL AND (`ReminderLease` IS NULL OR `ReminderLease`<?) ORDER BY `Reminder`, `ID` LIMIT ?",
+		now, now, reminderBatchSize)
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	var list []*v1.ToDo
+	for rows.Next() {
+		td, err := scanToDo(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		list = append(list, td)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+
+	if err := loadTags(ctx, q, list); err != nil {
+		return 0, err
+	}
+
+	for _, td := range list {
+		at, err := ptypes.Timestamp(td.Reminder)
+		if err != nil {
+			return 0, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+		}
+
+		claimed, err := claimReminder(ctx, q, td.Id, at, now)
+		if err != nil {
+			return 0, err
+		}
+		if !claimed {
+			continue
+		}
+
+		delivered, err := reminderDeliveries(ctx, q, td.Id, at)
+		if err != nil {
+			return 0, err
+		}
+		var failed bool
+		var names []string
+		for _, n := range notifiers {
+			if delivered[n.Name] {
+				continue
+			}
+			if err := n.Notify(ctx, notify.Reminder{ToDo: td, At: at}); err != nil {
+				log.Printf("failed to deliver reminder of task %d through %s notifier: %v", td.Id, n.Name, err)
+				failed = true
+				continue
+			}
+			names = append(names, n.Name)
+		}
+
+		// the lease expires if delivery fails, and the reminder is tried again through the notifiers that failed
+		if failed {
+			if err := recordReminderDeliveries(ctx, q, td.Id, at, names); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if _, err := q.ExecContext(ctx, "UPDATE ToDo SET `ReminderSent`=?, `ReminderLease`=NULL WHERE `ID`=?", at, td.Id); err != nil {
+			return 0, status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+		}
+		if len(delivered) > 0 {
+			if _, err := q.ExecContext(ctx, "DELETE FROM ReminderDelivery WHERE `ToDoID`=?", td.Id); err != nil {
+				return 0, status.Error(codes.Unknown, "failed to delete from ReminderDelivery-> "+err.Error())
+			}
+		}
+	}
+	return len(list), nil
+}
+
+// reminderDeliveries returns names of the notifiers that have delivered the reminder of the task at the time
+func reminderDeliveries(ctx context.Context, q queryer, id int64, at time.Time) (map[string]bool, error) {
+	rows, err := q.QueryContext(ctx, "SELECT `Notifier` FROM ReminderDelivery WHERE `ToDoID`=? AND `Reminder`=?", id, at)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ReminderDelivery-> "+err.Error())
+	}
+	defer rows.Close()
+
+	delivered := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ReminderDelivery row-> "+err.Error())
+		}
+		delivered[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ReminderDelivery-> "+err.Error())
+	}
+	return delivered, nil
+}
+
+// recordReminderDeliveries records that the notifiers have delivered the reminder of the task at the time,
+// replacing deliveries of an earlier reminder time
+func recordReminderDeliveries(ctx context.Context, q queryer, id int64, at time.Time, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, 3*len(names))
+	for _, name := range names {
+		args = append(args, id, name, at)
+	}
+	values := strings.TrimPrefix(strings.Repeat(",(?,?,?)", len(names)), ",")
+	if _, err := q.ExecContext(ctx, "INSERT INTO ReminderDelivery(`ToDoID`, `Notifier`, `Reminder`) VALUES"+values+
+		" ON DUPLICATE KEY UPDATE `Reminder`=VALUES(`Reminder`)", args...); err != nil {
+		return status.Error(codes.Unknown, "failed to insert into ReminderDelivery-> "+err.Error())
+	}
+	return nil
+}
+
+// claimReminder leases the reminder of the task to this server, it returns false if another server holds the lease
+// or the reminder has changed
+func claimReminder(ctx context.Context, q queryer, id int64, at, now time.Time) (bool, error) {
+	res, err := q.ExecContext(ctx, "UPDATE ToDo SET `ReminderLease`=? WHERE `ID`=? AND `Reminder`=? AND (`ReminderLease` IS NULL OR `ReminderLease`<?)",
+		now.Add(reminderLease), id, at, now)
+	if err != nil {
+		return false, status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	return rows > 0, nil
+}
+
+// watchNotifier delivers reminders to clients of Watch stream
+type watchNotifier struct {
+	db *sql.DB
+}
+
+// NewWatchNotifier creates notifier delivering reminders to Watch streams as EVENT_TYPE_REMINDER events,
+// clients not connected get them when they resume
+func NewWatchNotifier(db *sql.DB) notify.Notifier {
+	return &watchNotifier{db: db}
+}
+
+// Notify records the reminder event
+func (n *watchNotifier) Notify(ctx context.Context, r notify.Reminder) error {
+	return recordEvents(ctx, n.db, v1.EventType_EVENT_TYPE_REMINDER, "`ID`=?", r.ToDo.Id)
+}
@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/notify"
+)
+
+// reminderRecorder is a notifier remembering IDs of tasks it has been given reminders of
+type reminderRecorder struct {
+	ids []int64
+	err error
+}
+
+func (r *reminderRecorder) Notify(ctx context.Context, rem notify.Reminder) error {
+	r.ids = append(r.ids, rem.ToDo.Id)
+	return r.err
+}
+
+// newReminderDeliveryRows returns rows of notifiers that have delivered a reminder
+func newReminderDeliveryRows(names ...string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"Notifier"})
+	for _, name := range names {
+		rows.AddRow(name)
+	}
+	return rows
+}
+
+func Test_dispatchReminders(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	now := time.Now().In(time.UTC)
+	tm := now.Add(-time.Minute)
+	unavailable := errors.New("unavailable")
+
+	tests := []struct {
+		name      string
+		notifiers map[string]*reminderRecorder
+		mock      func()
+		want      int
+		wantIDs   map[string][]int64
+		wantErr   bool
+	}{
+		{
+			name:      "Deliver",
+			notifiers: map[string]*reminderRecorder{"log": {}},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Reminder`<=\\?").WithArgs(now, now, reminderBatchSize).
+					WillReturnRows(newToDoRows().
+						AddRow(toDoRow(1, "title 1", "", tm)...).
+						AddRow(toDoRow(2, "title 2", "", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1, 2).WillReturnRows(newTagRows())
+				mock.ExpectExec("UPDATE ToDo SET `ReminderLease`=\\?").WithArgs(now.Add(reminderLease), 1, tm, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `Notifier` FROM ReminderDelivery").WithArgs(1, tm).WillReturnRows(newReminderDeliveryRows())
+				mock.ExpectExec("UPDATE ToDo SET `ReminderSent`=\\?, `ReminderLease`=NULL").WithArgs(tm, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				// reminder of task 2 is being delivered by another server
+				mock.ExpectExec("UPDATE ToDo SET `ReminderLease`=\\?").WithArgs(now.Add(reminderLease), 2, tm, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want:    2,
+			wantIDs: map[string][]int64{"log": {1}},
+		},
+		{
+			name:      "Delivery failed",
+			notifiers: map[string]*reminderRecorder{"log": {err: unavailable}},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Reminder`<=\\?").WithArgs(now, now, reminderBatchSize).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title 1", "", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectExec("UPDATE ToDo SET `ReminderLease`=\\?").WithArgs(now.Add(reminderLease), 1, tm, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `Notifier` FROM ReminderDelivery").WithArgs(1, tm).WillReturnRows(newReminderDeliveryRows())
+			},
+			want:    1,
+			wantIDs: map[string][]int64{"log": {1}},
+		},
+		{
+			name:      "Delivery failed through some notifiers",
+			notifiers: map[string]*reminderRecorder{"log": {}, "webhook": {err: unavailable}},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Reminder`<=\\?").WithArgs(now, now, reminderBatchSize).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title 1", "", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectExec("UPDATE ToDo SET `ReminderLease`=\\?").WithArgs(now.Add(reminderLease), 1, tm, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `Notifier` FROM ReminderDelivery").WithArgs(1, tm).WillReturnRows(newReminderDeliveryRows())
+				mock.ExpectExec("INSERT INTO ReminderDelivery\\(`ToDoID`, `Notifier`, `Reminder`\\) VALUES\\(\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE").
+					WithArgs(1, "log", tm).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:    1,
+			wantIDs: map[string][]int64{"log": {1}, "webhook": {1}},
+		},
+		{
+			name:      "Retry through failed notifiers only",
+			notifiers: map[string]*reminderRecorder{"log": {}, "webhook": {}},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Reminder`<=\\?").WithArgs(now, now, reminderBatchSize).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title 1", "", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectExec("UPDATE ToDo SET `ReminderLease`=\\?").WithArgs(now.Add(reminderLease), 1, tm, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `Notifier` FROM ReminderDelivery").WithArgs(1, tm).WillReturnRows(newReminderDeliveryRows("log"))
+				mock.ExpectExec("UPDATE ToDo SET `ReminderSent`=\\?, `ReminderLease`=NULL").WithArgs(tm, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM ReminderDelivery WHERE `ToDoID`=\\?").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:    1,
+			wantIDs: map[string][]int64{"webhook": {1}},
+		},
+		{
+			name:      "Nothing due",
+			notifiers: map[string]*reminderRecorder{"log": {}},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Reminder`<=\\?").WithArgs(now, now, reminderBatchSize).
+					WillReturnRows(newToDoRows())
+			},
+		},
+		{
+			name:      "SELECT failed",
+			notifiers: map[string]*reminderRecorder{"log": {}},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Reminder`<=\\?").WithArgs(now, now, reminderBatchSize).
+					WillReturnError(errors.New("SELECT failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			var notifiers []notify.Named
+			for _, name := range []string{"log", "webhook"} {
+				if r, ok := tt.notifiers[name]; ok {
+					notifiers = append(notifiers, notify.Named{Name: name, Notifier: r})
+				}
+			}
+			got, err := dispatchReminders(ctx, db, notifiers, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("dispatchReminders() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("dispatchReminders() = %v, want %v", got, tt.want)
+			}
+			for name, r := range tt.notifiers {
+				if !reflect.DeepEqual(r.ids, tt.wantIDs[name]) {
+					t.Errorf("dispatchReminders() delivered %v through %s, want %v", r.ids, name, tt.wantIDs[name])
+				}
+			}
+		})
+	}
+}
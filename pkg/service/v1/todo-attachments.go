@@ -0,0 +1,376 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/blob"
+)
+
+const (
+	// maxFileNameLength is the longest attachment file name in bytes
+	maxFileNameLength = 255
+
+	// contentTypeMetadata is the metadata key the HTTP gateway passes the Content-Type header in
+	contentTypeMetadata = "grpcgateway-content-type"
+
+	// attachmentColumns are the Attachment table columns read by scanAttachment
+	attachmentColumns = "a.`ID`, a.`ToDoID`, a.`FileName`, a.`ContentType`, a.`Size`, a.`CreatedAt`"
+)
+
+// Option configures ToDo service
+type Option func(*toDoServiceServer)
+
+// WithAttachments stores contents of task attachments in store, accepting files up to maxSize bytes
+// of the content types listed, a type may be given as "image/*" to accept all of its subtypes
+func WithAttachments(store blob.Store, maxSize int64, contentTypes []string) Option {
+	return func(s *toDoServiceServer) {
+		s.blobs = store
+		s.maxAttachmentSize = maxSize
+		s.attachmentTypes = contentTypes
+	}
+}
+
+// attachmentKey returns the key contents of the attachment are stored under
+func attachmentKey(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// checkAttachments checks if attachments are configured
+func (s *toDoServiceServer) checkAttachments() error {
+	if s.blobs == nil {
+		return status.Error(codes.Unimplemented, "attachments are not configured")
+	}
+	return nil
+}
+
+// attachmentType returns the content type of the uploaded file if it is allowed
+func (s *toDoServiceServer) attachmentType(ctx context.Context, body *httpbody.HttpBody) (string, error) {
+	contentType := body.ContentType
+	if len(contentType) == 0 {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get(contentTypeMetadata); len(v) > 0 {
+			contentType = v[0]
+		}
+	}
+	if len(contentType) == 0 {
+		return "", status.Error(codes.InvalidArgument, "content type is required")
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "content type has invalid format-> "+err.Error())
+	}
+	for _, t := range s.attachmentTypes {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == mediaType || (strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(t, "*"))) {
+			return contentType, nil
+		}
+	}
+	return "", status.Error(codes.InvalidArgument, fmt.Sprintf("content type '%s' is not allowed", mediaType))
+}
+
+// attachmentFileName validates the file name of the uploaded file
+func attachmentFileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return "", status.Error(codes.InvalidArgument, "file_name field is required")
+	}
+	if len(name) > maxFileNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "file_name must be at most %d bytes", maxFileNameLength)
+	}
+	if strings.ContainsAny(name, "/\\") {
+		return "", status.Error(codes.InvalidArgument, "file_name must not contain path separators")
+	}
+	return name, nil
+}
+
+// scanAttachment reads an Attachment row selected by attachmentColumns
+func scanAttachment(rows *sql.Rows) (*v1.Attachment, error) {
+	var a v1.Attachment
+	var createdAt time.Time
+	if err := rows.Scan(&a.Id, &a.ToDoId, &a.FileName, &a.ContentType, &a.Size, &createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from Attachment row-> "+err.Error())
+	}
+
+	var err error
+	if a.CreatedAt, err = ptypes.TimestampProto(createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+	return &a, nil
+}
+
+// attachmentIDs returns IDs of the attachments of the tasks
+func attachmentIDs(ctx context.Context, q queryer, toDoIDs []interface{}) ([]int64, error) {
+	rows, err := q.QueryContext(ctx, "SELECT `ID` FROM Attachment WHERE `ToDoID` IN ("+placeholders(len(toDoIDs))+")", toDoIDs...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Attachment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from Attachment row-> "+err.Error())
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from Attachment-> "+err.Error())
+	}
+	return ids, nil
+}
+
+// deleteBlobs deletes contents of the attachments, the rows of which are already deleted,
+// a failure only leaves an orphaned blob behind so it is logged
+func (s *toDoServiceServer) deleteBlobs(ctx context.Context, ids []int64) {
+	for _, id := range ids {
+		if err := s.blobs.Delete(ctx, attachmentKey(id)); err != nil {
+			log.Printf("failed to delete contents of attachment %d: %v", id, err)
+		}
+	}
+}
+
+// UploadAttachment attaches a file to a task
+func (s *toDoServiceServer) UploadAttachment(ctx context.Context, req *v1.UploadAttachmentRequest) (*v1.UploadAttachmentResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAttachments(); err != nil {
+		return nil, err
+	}
+
+	fileName, err := attachmentFileName(req.FileName)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body == nil || len(req.Body.Data) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "body field is required")
+	}
+	size := int64(len(req.Body.Data))
+	if size > s.maxAttachmentSize {
+		return nil, status.Errorf(codes.InvalidArgument, "attachment must be at most %d bytes", s.maxAttachmentSize)
+	}
+	contentType, err := s.attachmentType(ctx, req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	// contents are stored before the row is committed, so a listed attachment can always be downloaded
+	var id int64
+	var stored bool
+	now := time.Now().UTC().Truncate(time.Second)
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := callerScope(ctx).require(ctx, tx, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+			return err
+		}
+
+		// tasks in trash can't be attached to
+		res, err := tx.ExecContext(ctx, "INSERT INTO Attachment(`ToDoID`, `FileName`, `ContentType`, `Size`, `CreatedAt`) "+
+			"SELECT `ID`, ?, ?, ?, ? FROM ToDo WHERE `ID`=? AND `DeletedAt` IS NULL", fileName, contentType, size, now, req.ToDoId)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to insert into Attachment-> "+err.Error())
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		}
+		if rows == 0 {
+			return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.ToDoId))
+		}
+		if id, err = res.LastInsertId(); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve id for created Attachment-> "+err.Error())
+		}
+
+		if err := s.blobs.Put(ctx, attachmentKey(id), req.Body.Data); err != nil {
+			return status.Error(codes.Unknown, "failed to store attachment contents-> "+err.Error())
+		}
+		stored = true
+		return nil
+	})
+	if err != nil {
+		if stored {
+			s.deleteBlobs(ctx, []int64{id})
+		}
+		return nil, err
+	}
+
+	createdAt, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+
+	return &v1.UploadAttachmentResponse{
+		Api: apiVersion,
+		Attachment: &v1.Attachment{
+			Id:          id,
+			ToDoId:      req.ToDoId,
+			FileName:    fileName,
+			ContentType: contentType,
+			Size:        size,
+			CreatedAt:   createdAt,
+		},
+	}, nil
+}
+
+// ListAttachments returns metadata of the files attached to a task, oldest first
+func (s *toDoServiceServer) ListAttachments(ctx context.Context, req *v1.ListAttachmentsRequest) (*v1.ListAttachmentsResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+		return nil, err
+	}
+	// attachments of a task in trash are hidden with the task
+	if err := checkToDoExists(ctx, c, req.ToDoId); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.QueryContext(ctx, "SELECT "+attachmentColumns+" FROM Attachment a WHERE a.`ToDoID`=? ORDER BY a.`ID`", req.ToDoId)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Attachment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Attachment{}
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from Attachment-> "+err.Error())
+	}
+
+	return &v1.ListAttachmentsResponse{
+		Api:         apiVersion,
+		Attachments: list,
+	}, nil
+}
+
+// DownloadAttachment returns contents of a file attached to a task with its content type
+func (s *toDoServiceServer) DownloadAttachment(ctx context.Context, req *v1.DownloadAttachmentRequest) (*httpbody.HttpBody, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAttachments(); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.QueryContext(ctx, "SELECT "+attachmentColumns+" FROM Attachment a JOIN ToDo t ON t.`ID`=a.`ToDoID` "+
+		"WHERE a.`ID`=? AND a.`ToDoID`=? AND t.`DeletedAt` IS NULL", req.Id, req.ToDoId)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Attachment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve data from Attachment-> "+err.Error())
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Attachment with ID='%d' is not found", req.Id))
+	}
+	a, err := scanAttachment(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.blobs.Get(ctx, attachmentKey(a.Id))
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to read attachment contents-> "+err.Error())
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: a.ContentType,
+		Data:        data,
+	}, nil
+}
+
+// DeleteAttachment deletes a file attached to a task
+func (s *toDoServiceServer) DeleteAttachment(ctx context.Context, req *v1.DeleteAttachmentRequest) (*v1.DeleteAttachmentResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAttachments(); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+		return nil, err
+	}
+
+	res, err := c.ExecContext(ctx, "DELETE a FROM Attachment a JOIN ToDo t ON t.`ID`=a.`ToDoID` "+
+		"WHERE a.`ID`=? AND a.`ToDoID`=? AND t.`DeletedAt` IS NULL", req.Id, req.ToDoId)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete Attachment-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Attachment with ID='%d' is not found", req.Id))
+	}
+
+	s.deleteBlobs(ctx, []int64{req.Id})
+
+	return &v1.DeleteAttachmentResponse{
+		Api:     apiVersion,
+		Deleted: rows,
+	}, nil
+}
@@ -0,0 +1,603 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/blob"
+)
+
+// attachmentTypes are the content types test servers accept
+var attachmentTypes = []string{"image/*", "text/plain"}
+
+// newBlobStore creates Store in a temporary directory removed by the returned function
+func newBlobStore(t *testing.T) (blob.Store, func()) {
+	dir, err := ioutil.TempDir("", "attachments")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating a temporary directory", err)
+	}
+	store, err := blob.NewFileStore(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("an error '%s' was not expected when creating a blob store", err)
+	}
+	return store, func() { os.RemoveAll(dir) }
+}
+
+func newAttachmentRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "ToDoID", "FileName", "ContentType", "Size", "CreatedAt"})
+}
+
+func Test_toDoServiceServer_UploadAttachment(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	store, cleanup := newBlobStore(t)
+	defer cleanup()
+	s := NewToDoServiceServer(db, WithAttachments(store, 8, attachmentTypes))
+
+	type args struct {
+		ctx context.Context
+		req *v1.UploadAttachmentRequest
+	}
+	tests := []struct {
+		name     string
+		s        v1.ToDoServiceServer
+		args     args
+		mock     func()
+		want     *v1.Attachment
+		wantBlob string
+		wantErr  bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "notes.txt",
+					Body:     &httpbody.HttpBody{ContentType: "text/plain; charset=utf-8", Data: []byte("notes")},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO Attachment\\(`ToDoID`, `FileName`, `ContentType`, `Size`, `CreatedAt`\\) SELECT `ID`, \\?, \\?, \\?, \\? FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL").
+					WithArgs("notes.txt", "text/plain; charset=utf-8", 5, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.Attachment{
+				Id:          3,
+				ToDoId:      1,
+				FileName:    "notes.txt",
+				ContentType: "text/plain; charset=utf-8",
+				Size:        5,
+			},
+			wantBlob: "notes",
+		},
+		{
+			name: "Content type from HTTP gateway",
+			s:    s,
+			args: args{
+				ctx: metadata.NewIncomingContext(ctx, metadata.Pairs(contentTypeMetadata, "image/png")),
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "logo.png",
+					Body:     &httpbody.HttpBody{Data: []byte("png")},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO Attachment").WithArgs("logo.png", "image/png", 3, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.Attachment{
+				Id:          4,
+				ToDoId:      1,
+				FileName:    "logo.png",
+				ContentType: "image/png",
+				Size:        3,
+			},
+			wantBlob: "png",
+		},
+		{
+			name: "Task not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "notes.txt",
+					Body:     &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("notes")},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO Attachment").WithArgs("notes.txt", "text/plain", 5, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "COMMIT failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "notes.txt",
+					Body:     &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("notes")},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO Attachment").WithArgs("notes.txt", "text/plain", 5, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectCommit().WillReturnError(errors.New("COMMIT failed"))
+			},
+			want:    &v1.Attachment{Id: 5},
+			wantErr: true,
+		},
+		{
+			name: "Content type not allowed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "run.sh",
+					Body:     &httpbody.HttpBody{ContentType: "application/x-sh", Data: []byte("ls")},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Too large",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "notes.txt",
+					Body:     &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("long notes")},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Empty body",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "notes.txt",
+					Body:     &httpbody.HttpBody{ContentType: "text/plain"},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "File name with path",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "../notes.txt",
+					Body:     &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("notes")},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Attachments not configured",
+			s:    NewToDoServiceServer(db),
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1",
+					ToDoId:   1,
+					FileName: "notes.txt",
+					Body:     &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("notes")},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UploadAttachmentRequest{
+					Api:      "v1000",
+					ToDoId:   1,
+					FileName: "notes.txt",
+					Body:     &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("notes")},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.UploadAttachment(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.UploadAttachment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				got.Attachment.CreatedAt = nil
+				if !reflect.DeepEqual(got.Attachment, tt.want) {
+					t.Errorf("toDoServiceServer.UploadAttachment() = %v, want %v", got.Attachment, tt.want)
+				}
+			}
+			if tt.want != nil {
+				data, err := store.Get(ctx, attachmentKey(tt.want.Id))
+				if len(tt.wantBlob) == 0 && err != blob.ErrNotFound {
+					t.Errorf("toDoServiceServer.UploadAttachment() left contents %q, error = %v", data, err)
+				}
+				if len(tt.wantBlob) > 0 && string(data) != tt.wantBlob {
+					t.Errorf("toDoServiceServer.UploadAttachment() stored %q, want %q", data, tt.wantBlob)
+				}
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_ListAttachments(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListAttachmentsRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListAttachmentsResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListAttachmentsRequest{
+					Api:    "v1",
+					ToDoId: 1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM Attachment a WHERE a.`ToDoID`=\\? ORDER BY a.`ID`").WithArgs(1).
+					WillReturnRows(newAttachmentRows().
+						AddRow(1, 1, "notes.txt", "text/plain", 5, tm).
+						AddRow(2, 1, "logo.png", "image/png", 3, tm))
+			},
+			want: &v1.ListAttachmentsResponse{
+				Api: "v1",
+				Attachments: []*v1.Attachment{
+					{Id: 1, ToDoId: 1, FileName: "notes.txt", ContentType: "text/plain", Size: 5, CreatedAt: ts},
+					{Id: 2, ToDoId: 1, FileName: "logo.png", ContentType: "image/png", Size: 3, CreatedAt: ts},
+				},
+			},
+		},
+		{
+			name: "Empty",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListAttachmentsRequest{
+					Api:    "v1",
+					ToDoId: 1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM Attachment a").WithArgs(1).
+					WillReturnRows(newAttachmentRows())
+			},
+			want: &v1.ListAttachmentsResponse{
+				Api:         "v1",
+				Attachments: []*v1.Attachment{},
+			},
+		},
+		{
+			name: "Task not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListAttachmentsRequest{
+					Api:    "v1",
+					ToDoId: 1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListAttachmentsRequest{
+					Api:    "v1000",
+					ToDoId: 1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListAttachments(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ListAttachments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ListAttachments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_DownloadAttachment(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	store, cleanup := newBlobStore(t)
+	defer cleanup()
+	s := NewToDoServiceServer(db, WithAttachments(store, 8, attachmentTypes))
+	tm := time.Now().In(time.UTC)
+	if err := store.Put(ctx, attachmentKey(1), []byte("notes")); err != nil {
+		t.Fatalf("an error '%s' was not expected when storing a blob", err)
+	}
+
+	type args struct {
+		ctx context.Context
+		req *v1.DownloadAttachmentRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *httpbody.HttpBody
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DownloadAttachmentRequest{
+					Api:    "v1",
+					ToDoId: 1,
+					Id:     1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Attachment a JOIN ToDo t ON t.`ID`=a.`ToDoID` WHERE a.`ID`=\\? AND a.`ToDoID`=\\? AND t.`DeletedAt` IS NULL").
+					WithArgs(1, 1).
+					WillReturnRows(newAttachmentRows().AddRow(1, 1, "notes.txt", "text/plain", 5, tm))
+			},
+			want: &httpbody.HttpBody{
+				ContentType: "text/plain",
+				Data:        []byte("notes"),
+			},
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DownloadAttachmentRequest{
+					Api:    "v1",
+					ToDoId: 1,
+					Id:     2,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Attachment a JOIN ToDo t").WithArgs(2, 1).
+					WillReturnRows(newAttachmentRows())
+			},
+			wantErr: true,
+		},
+		{
+			name: "Contents missing",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DownloadAttachmentRequest{
+					Api:    "v1",
+					ToDoId: 1,
+					Id:     2,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Attachment a JOIN ToDo t").WithArgs(2, 1).
+					WillReturnRows(newAttachmentRows().AddRow(2, 1, "logo.png", "image/png", 3, tm))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Attachments not configured",
+			s:    NewToDoServiceServer(db),
+			args: args{
+				ctx: ctx,
+				req: &v1.DownloadAttachmentRequest{
+					Api:    "v1",
+					ToDoId: 1,
+					Id:     1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.DownloadAttachment(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.DownloadAttachment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.DownloadAttachment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_DeleteAttachment(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	store, cleanup := newBlobStore(t)
+	defer cleanup()
+	s := NewToDoServiceServer(db, WithAttachments(store, 8, attachmentTypes))
+	if err := store.Put(ctx, attachmentKey(1), []byte("notes")); err != nil {
+		t.Fatalf("an error '%s' was not expected when storing a blob", err)
+	}
+
+	type args struct {
+		ctx context.Context
+		req *v1.DeleteAttachmentRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.DeleteAttachmentResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteAttachmentRequest{
+					Api:    "v1",
+					ToDoId: 1,
+					Id:     1,
+				},
+			},
+			mock: func() {
+				mock.ExpectExec("DELETE a FROM Attachment a JOIN ToDo t ON t.`ID`=a.`ToDoID` WHERE a.`ID`=\\? AND a.`ToDoID`=\\? AND t.`DeletedAt` IS NULL").
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: &v1.DeleteAttachmentResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteAttachmentRequest{
+					Api:    "v1",
+					ToDoId: 1,
+					Id:     2,
+				},
+			},
+			mock: func() {
+				mock.ExpectExec("DELETE a FROM Attachment a").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteAttachmentRequest{
+					Api:    "v1000",
+					ToDoId: 1,
+					Id:     1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.DeleteAttachment(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.DeleteAttachment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.DeleteAttachment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := store.Get(ctx, attachmentKey(1)); err != blob.ErrNotFound {
+		t.Errorf("toDoServiceServer.DeleteAttachment() left contents of deleted attachment, error = %v", err)
+	}
+}
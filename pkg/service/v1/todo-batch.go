@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/search"
+)
+
+// maxBatchSize is the largest number of requests in a batch
+const maxBatchSize = 500
+
+// checkBatchSize checks the batch has requests and is not larger than maxBatchSize
+func checkBatchSize(n int) error {
+	if n == 0 {
+		return status.Error(codes.InvalidArgument, "requests field is empty")
+	}
+	if n > maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch has %d requests, the maximum is %d", n, maxBatchSize)
+	}
+	return nil
+}
+
+// batchItemError prefixes the error of the i-th request in a batch with its index keeping its code
+func batchItemError(i int, err error) error {
+	st := status.Convert(err)
+	return status.Error(st.Code(), fmt.Sprintf("requests[%d]: %s", i, st.Message()))
+}
+
+// BatchCreate creates tasks in one transaction, none is created if any fails
+func (s *toDoServiceServer) BatchCreate(ctx context.Context, req *v1.BatchCreateRequest) (*v1.BatchCreateResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := checkBatchSize(len(req.Requests)); err != nil {
+		return nil, err
+	}
+
+	inserts := make([]*toDoInsert, len(req.Requests))
+	for i, r := range req.Requests {
+		if err := s.checkAPI(r.Api); err != nil {
+			return nil, batchItemError(i, err)
+		}
+		ins, err := newToDoInsert(r.ToDo)
+		if err != nil {
+			return nil, batchItemError(i, err)
+		}
+		inserts[i] = ins
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	responses := make([]*v1.CreateResponse, len(inserts))
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		for i, ins := range inserts {
+			id, err := ins.exec(ctx, tx)
+			if err != nil {
+				return batchItemError(i, err)
+			}
+			responses[i] = &v1.CreateResponse{Api: apiVersion, Id: id}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// update search index
+	for i, ins := range inserts {
+		doc := search.Document{ID: responses[i].Id, Title: ins.toDo.Title, Description: ins.toDo.Description, OwnerID: callerOwner(ctx)}
+		if err := s.search.Put(ctx, doc); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to index ToDo-> "+err.Error())
+		}
+	}
+
+	return &v1.BatchCreateResponse{
+		Api:       apiVersion,
+		Responses: responses,
+	}, nil
+}
+
+// BatchUpdate updates tasks in one transaction, none is updated if any fails
+func (s *toDoServiceServer) BatchUpdate(ctx context.Context, req *v1.BatchUpdateRequest) (*v1.BatchUpdateResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := checkBatchSize(len(req.Requests)); err != nil {
+		return nil, err
+	}
+
+	// the If-Match header can not tell which of the tasks it is for, so only etags in requests are used
+	updates := make([]*toDoUpdate, len(req.Requests))
+	for i, r := range req.Requests {
+		if err := s.checkAPI(r.Api); err != nil {
+			return nil, batchItemError(i, err)
+		}
+		scope, err := s.requestScope(ctx, r.AllOwners)
+		if err != nil {
+			return nil, batchItemError(i, err)
+		}
+		upd, err := newToDoUpdate(r.ToDo, r.UpdateMask, firstEtag(r.Etag, r.GetToDo().GetEtag()), scope)
+		if err != nil {
+			return nil, batchItemError(i, err)
+		}
+		updates[i] = upd
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	responses := make([]*v1.UpdateResponse, len(updates))
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		for i, upd := range updates {
+			rows, etag, err := upd.exec(ctx, tx)
+			if err != nil {
+				return batchItemError(i, err)
+			}
+			responses[i] = &v1.UpdateResponse{Api: apiVersion, Updated: rows, Etag: etag}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, upd := range updates {
+		if err := s.indexUpdate(ctx, c, upd); err != nil {
+			return nil, err
+		}
+	}
+
+	return &v1.BatchUpdateResponse{
+		Api:       apiVersion,
+		Responses: responses,
+	}, nil
+}
+
+// BatchDelete moves tasks to trash in one transaction, none is deleted if any fails
+func (s *toDoServiceServer) BatchDelete(ctx context.Context, req *v1.BatchDeleteRequest) (*v1.BatchDeleteResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := checkBatchSize(len(req.Requests)); err != nil {
+		return nil, err
+	}
+
+	scopes := make([]taskScope, len(req.Requests))
+	for i, r := range req.Requests {
+		if err := s.checkAPI(r.Api); err != nil {
+			return nil, batchItemError(i, err)
+		}
+		scope, err := s.requestScope(ctx, r.AllOwners)
+		if err != nil {
+			return nil, batchItemError(i, err)
+		}
+		scopes[i] = scope
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	responses := make([]*v1.DeleteResponse, len(req.Requests))
+	var levels [][]interface{}
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		for i, r := range req.Requests {
+			rows, deleted, err := deleteToDo(ctx, tx, r.Id, r.Cascade, r.Etag, scopes[i])
+			if err != nil {
+				return batchItemError(i, err)
+			}
+			levels = append(levels, deleted...)
+			responses[i] = &v1.DeleteResponse{Api: apiVersion, Deleted: rows}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.unindex(ctx, levels); err != nil {
+		return nil, err
+	}
+
+	return &v1.BatchDeleteResponse{
+		Api:       apiVersion,
+		Responses: responses,
+	}, nil
+}
@@ -0,0 +1,525 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_batchItemError(t *testing.T) {
+	err := batchItemError(2, status.Error(codes.NotFound, "ToDo with ID='5' is not found"))
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("batchItemError() code = %v, want %v", status.Code(err), codes.NotFound)
+	}
+	if msg := status.Convert(err).Message(); msg != "requests[2]: ToDo with ID='5' is not found" {
+		t.Errorf("batchItemError() message = %v", msg)
+	}
+}
+
+func Test_toDoServiceServer_BatchCreate(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.BatchCreateRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.BatchCreateResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchCreateRequest{
+					Api: "v1",
+					Requests: []*v1.CreateRequest{
+						{Api: "v1", ToDo: &v1.ToDo{Title: "title 1", Reminder: reminder}},
+						{ToDo: &v1.ToDo{Title: "title 2", Reminder: reminder, Tags: []string{"backend"}}},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLastPosition(mock, "")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title 1", "", tm, nil, 0, nil, nil, "", "", tm, "a0", "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 1, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_CREATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectLastPosition(mock, "a0")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title 2", "", tm, nil, 0, nil, nil, "", "", tm, "a1", "").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("INSERT IGNORE INTO Tag").WithArgs("backend").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT IGNORE INTO ToDoTag").WithArgs(2, "backend").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 1, 2)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_CREATE, 2)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.BatchCreateResponse{
+				Api: "v1",
+				Responses: []*v1.CreateResponse{
+					{Api: "v1", Id: 1},
+					{Api: "v1", Id: 2},
+				},
+			},
+		},
+		{
+			name: "INSERT failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchCreateRequest{
+					Api: "v1",
+					Requests: []*v1.CreateRequest{
+						{ToDo: &v1.ToDo{Title: "title 1", Reminder: reminder}},
+						{ToDo: &v1.ToDo{Title: "title 2", Reminder: reminder}},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLastPosition(mock, "")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title 1", "", tm, nil, 0, nil, nil, "", "", tm, "a0", "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 1, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_CREATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectLastPosition(mock, "a0")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title 2", "", tm, nil, 0, nil, nil, "", "", tm, "a1", "").
+					WillReturnError(errors.New("INSERT failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid item",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchCreateRequest{
+					Api: "v1",
+					Requests: []*v1.CreateRequest{
+						{ToDo: &v1.ToDo{Title: "title 1", Reminder: reminder}},
+						{},
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Empty batch",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchCreateRequest{
+					Api: "v1",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Batch too large",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchCreateRequest{
+					Api:      "v1",
+					Requests: make([]*v1.CreateRequest, maxBatchSize+1),
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported item API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchCreateRequest{
+					Api: "v1",
+					Requests: []*v1.CreateRequest{
+						{Api: "v1000", ToDo: &v1.ToDo{Title: "title 1", Reminder: reminder}},
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchCreateRequest{
+					Api: "v1000",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.BatchCreate(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.BatchCreate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.BatchCreate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_BatchUpdate(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.BatchUpdateRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.BatchUpdateResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				// the If-Match header is not applied to batch items
+				ctx: metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", `"7"`)),
+				req: &v1.BatchUpdateRequest{
+					Api: "v1",
+					Requests: []*v1.UpdateRequest{
+						{ToDo: &v1.ToDo{Id: 1, Title: "title 1", Reminder: reminder}},
+						{ToDo: &v1.ToDo{Id: 2, Title: "title 2", Reminder: reminder}, Etag: "3"},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("title 1", "", tm, nil, 0, nil, "", "", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `Version` FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL FOR UPDATE").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(3))
+				expectSnapshot(mock, 3, 2)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("title 2", "", tm, nil, 0, nil, "", "", 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 4, 2)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 2)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.BatchUpdateResponse{
+				Api: "v1",
+				Responses: []*v1.UpdateResponse{
+					{Api: "v1", Updated: 1, Etag: "2"},
+					{Api: "v1", Updated: 1, Etag: "4"},
+				},
+			},
+		},
+		{
+			name: "Stale etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchUpdateRequest{
+					Api: "v1",
+					Requests: []*v1.UpdateRequest{
+						{ToDo: &v1.ToDo{Id: 1, Title: "title 1", Reminder: reminder}},
+						{ToDo: &v1.ToDo{Id: 2, Title: "title 2", Reminder: reminder, Etag: "3"}},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("title 1", "", tm, nil, 0, nil, "", "", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `Version` FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL FOR UPDATE").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(4))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchUpdateRequest{
+					Api: "v1",
+					Requests: []*v1.UpdateRequest{
+						{ToDo: &v1.ToDo{Id: 1, Title: "title 1", Reminder: reminder}},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE ToDo").WithArgs("title 1", "", tm, nil, 0, nil, "", "", 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing task",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchUpdateRequest{
+					Api:      "v1",
+					Requests: []*v1.UpdateRequest{{Api: "v1"}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Empty batch",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchUpdateRequest{
+					Api: "v1",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchUpdateRequest{
+					Api: "v1000",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.BatchUpdate(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.BatchUpdate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.BatchUpdate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_BatchDelete(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.BatchDeleteRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.BatchDeleteResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchDeleteRequest{
+					Api: "v1",
+					Requests: []*v1.DeleteRequest{
+						{Id: 1},
+						{Id: 2, Cascade: true},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_DELETE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(3))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 2, 3)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 2, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectSnapshot(mock, 2, 2, 3)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_DELETE, 2, 3)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(3, 2, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			want: &v1.BatchDeleteResponse{
+				Api: "v1",
+				Responses: []*v1.DeleteResponse{
+					{Api: "v1", Deleted: 1},
+					{Api: "v1", Deleted: 2},
+				},
+			},
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchDeleteRequest{
+					Api: "v1",
+					Requests: []*v1.DeleteRequest{
+						{Id: 1},
+						{Id: 2},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_DELETE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(2).WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty batch",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchDeleteRequest{
+					Api: "v1",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported item API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchDeleteRequest{
+					Api:      "v1",
+					Requests: []*v1.DeleteRequest{{Api: "v1000", Id: 1}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.BatchDeleteRequest{
+					Api: "v1000",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.BatchDelete(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.BatchDelete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.BatchDelete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
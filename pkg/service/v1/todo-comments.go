@@ -0,0 +1,302 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// maxCommentLength is the longest comment body in characters
+	maxCommentLength = 4096
+
+	// commentColumns are the Comment table columns read by scanComment
+	commentColumns = "c.`ID`, c.`ToDoID`, c.`Author`, c.`Body`, c.`CreatedAt`, c.`UpdatedAt`"
+)
+
+// commentBody validates the body of a comment in request
+func commentBody(c *v1.Comment) (string, error) {
+	if c == nil {
+		return "", status.Error(codes.InvalidArgument, "comment field is required")
+	}
+	body := strings.TrimSpace(c.Body)
+	if len(body) == 0 {
+		return "", status.Error(codes.InvalidArgument, "body field is required")
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return "", status.Errorf(codes.InvalidArgument, "body must be at most %d characters", maxCommentLength)
+	}
+	return body, nil
+}
+
+// scanComment reads a Comment row selected by commentColumns
+func scanComment(rows *sql.Rows) (*v1.Comment, error) {
+	var c v1.Comment
+	var createdAt time.Time
+	var updatedAt sql.NullTime
+	if err := rows.Scan(&c.Id, &c.ToDoId, &c.Author, &c.Body, &createdAt, &updatedAt); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from Comment row-> "+err.Error())
+	}
+
+	var err error
+	if c.CreatedAt, err = ptypes.TimestampProto(createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+	if updatedAt.Valid {
+		if c.UpdatedAt, err = ptypes.TimestampProto(updatedAt.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "updatedAt field has invalid format-> "+err.Error())
+		}
+	}
+	return &c, nil
+}
+
+// readComment selects a comment on a task out of trash, locking it for update
+func readComment(ctx context.Context, q queryer, toDoID, id int64) (*v1.Comment, error) {
+	rows, err := q.QueryContext(ctx, "SELECT "+commentColumns+" FROM Comment c JOIN ToDo t ON t.`ID`=c.`ToDoID` "+
+		"WHERE c.`ID`=? AND c.`ToDoID`=? AND t.`DeletedAt` IS NULL FOR UPDATE", id, toDoID)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Comment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve data from Comment-> "+err.Error())
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Comment with ID='%d' is not found", id))
+	}
+	return scanComment(rows)
+}
+
+// CreateComment adds a comment by the actor of the request to a task
+func (s *toDoServiceServer) CreateComment(ctx context.Context, req *v1.CreateCommentRequest) (*v1.CreateCommentResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	body, err := commentBody(req.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+		return nil, err
+	}
+
+	// tasks in trash can't be commented on
+	now := time.Now().UTC().Truncate(time.Second)
+	author := requestActor(ctx)
+	res, err := c.ExecContext(ctx, "INSERT INTO Comment(`ToDoID`, `Author`, `Body`, `CreatedAt`) "+
+		"SELECT `ID`, ?, ?, ? FROM ToDo WHERE `ID`=? AND `DeletedAt` IS NULL", author, body, now, req.ToDoId)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into Comment-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.ToDoId))
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve id for created Comment-> "+err.Error())
+	}
+
+	createdAt, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+
+	return &v1.CreateCommentResponse{
+		Api: apiVersion,
+		Comment: &v1.Comment{
+			Id:        id,
+			ToDoId:    req.ToDoId,
+			Author:    author,
+			Body:      body,
+			CreatedAt: createdAt,
+		},
+	}, nil
+}
+
+// ListComments returns comments on a task, oldest first
+func (s *toDoServiceServer) ListComments(ctx context.Context, req *v1.ListCommentsRequest) (*v1.ListCommentsResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// page token holds ID of the last comment in the previous page
+	query := queryHash(strconv.FormatInt(req.ToDoId, 10))
+	conds := []condition{{sql: "c.`ToDoID`=?", args: []interface{}{req.ToDoId}}}
+	if len(req.PageToken) > 0 {
+		last, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if last.Query != query || len(last.Values) != 1 {
+			return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different task")
+		}
+		id, err := strconv.ParseInt(last.Values[0], 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "page_token has invalid format-> "+err.Error())
+		}
+		conds = append(conds, condition{sql: "c.`ID`>?", args: []interface{}{id}})
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+		return nil, err
+	}
+	// comments of a task in trash are hidden with the task
+	if err := checkToDoExists(ctx, c, req.ToDoId); err != nil {
+		return nil, err
+	}
+
+	// one extra row tells if there is a next page
+	sqlWhere, args := whereSQL(conds)
+	rows, err := c.QueryContext(ctx, "SELECT "+commentColumns+" FROM Comment c"+sqlWhere+" ORDER BY c.`ID` LIMIT ?", append(args, size+1)...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Comment-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Comment{}
+	for rows.Next() {
+		cm, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, cm)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from Comment-> "+err.Error())
+	}
+
+	var nextPageToken string
+	if len(list) > size {
+		list = list[:size]
+		nextPageToken = encodePageToken(pageToken{Query: query, Values: []string{strconv.FormatInt(list[size-1].Id, 10)}})
+	}
+
+	return &v1.ListCommentsResponse{
+		Api:           apiVersion,
+		Comments:      list,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// UpdateComment replaces the body of a comment
+func (s *toDoServiceServer) UpdateComment(ctx context.Context, req *v1.UpdateCommentRequest) (*v1.UpdateCommentResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	body, err := commentBody(req.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var cm *v1.Comment
+	now := time.Now().UTC().Truncate(time.Second)
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := callerScope(ctx).require(ctx, tx, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+			return err
+		}
+		if cm, err = readComment(ctx, tx, req.ToDoId, req.Id); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE Comment SET `Body`=?, `UpdatedAt`=? WHERE `ID`=?", body, now, req.Id); err != nil {
+			return status.Error(codes.Unknown, "failed to update Comment-> "+err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cm.Body = body
+	if cm.UpdatedAt, err = ptypes.TimestampProto(now); err != nil {
+		return nil, status.Error(codes.Unknown, "updatedAt field has invalid format-> "+err.Error())
+	}
+
+	return &v1.UpdateCommentResponse{
+		Api:     apiVersion,
+		Comment: cm,
+	}, nil
+}
+
+// DeleteComment deletes a comment
+func (s *toDoServiceServer) DeleteComment(ctx context.Context, req *v1.DeleteCommentRequest) (*v1.DeleteCommentResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+		return nil, err
+	}
+
+	res, err := c.ExecContext(ctx, "DELETE c FROM Comment c JOIN ToDo t ON t.`ID`=c.`ToDoID` "+
+		"WHERE c.`ID`=? AND c.`ToDoID`=? AND t.`DeletedAt` IS NULL", req.Id, req.ToDoId)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete Comment-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Comment with ID='%d' is not found", req.Id))
+	}
+
+	return &v1.DeleteCommentResponse{
+		Api:     apiVersion,
+		Deleted: rows,
+	}, nil
+}
@@ -0,0 +1,433 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func newCommentRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "ToDoID", "Author", "Body", "CreatedAt", "UpdatedAt"})
+}
+
+func Test_toDoServiceServer_CreateComment(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(actorMetadata, "alice"))
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.CreateCommentRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.Comment
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateCommentRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Comment: &v1.Comment{Body: " looks good "},
+				},
+			},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO Comment\\(`ToDoID`, `Author`, `Body`, `CreatedAt`\\) SELECT `ID`, \\?, \\?, \\? FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL").
+					WithArgs("alice", "looks good", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			want: &v1.Comment{
+				Id:     5,
+				ToDoId: 1,
+				Author: "alice",
+				Body:   "looks good",
+			},
+		},
+		{
+			name: "Task not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateCommentRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Comment: &v1.Comment{Body: "looks good"},
+				},
+			},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO Comment").WithArgs("alice", "looks good", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty body",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateCommentRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Comment: &v1.Comment{Body: "  "},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Body too long",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateCommentRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Comment: &v1.Comment{Body: strings.Repeat("x", maxCommentLength+1)},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateCommentRequest{
+					Api:     "v1000",
+					ToDoId:  1,
+					Comment: &v1.Comment{Body: "looks good"},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.CreateComment(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.CreateComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				got.Comment.CreatedAt = nil
+				if !reflect.DeepEqual(got.Comment, tt.want) {
+					t.Errorf("toDoServiceServer.CreateComment() = %v, want %v", got.Comment, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_ListComments(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+	nextPageToken := encodePageToken(pageToken{Query: queryHash("1"), Values: []string{"2"}})
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListCommentsRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListCommentsResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListCommentsRequest{Api: "v1", ToDoId: 1, PageSize: 2},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM Comment c WHERE c.`ToDoID`=\\? ORDER BY c.`ID` LIMIT \\?").WithArgs(1, 3).
+					WillReturnRows(newCommentRows().
+						AddRow(1, 1, "alice", "first", tm, tm).
+						AddRow(2, 1, "", "second", tm, nil).
+						AddRow(3, 1, "bob", "third", tm, nil))
+			},
+			want: &v1.ListCommentsResponse{
+				Api: "v1",
+				Comments: []*v1.Comment{
+					{Id: 1, ToDoId: 1, Author: "alice", Body: "first", CreatedAt: ts, UpdatedAt: ts},
+					{Id: 2, ToDoId: 1, Body: "second", CreatedAt: ts},
+				},
+				NextPageToken: nextPageToken,
+			},
+		},
+		{
+			name: "Next page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListCommentsRequest{Api: "v1", ToDoId: 1, PageSize: 2, PageToken: nextPageToken},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM Comment c WHERE c.`ToDoID`=\\? AND c.`ID`>\\?").WithArgs(1, 2, 3).
+					WillReturnRows(newCommentRows().AddRow(3, 1, "bob", "third", tm, nil))
+			},
+			want: &v1.ListCommentsResponse{
+				Api: "v1",
+				Comments: []*v1.Comment{
+					{Id: 3, ToDoId: 1, Author: "bob", Body: "third", CreatedAt: ts},
+				},
+			},
+		},
+		{
+			name: "Task in trash",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListCommentsRequest{Api: "v1", ToDoId: 1},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Page token of another task",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListCommentsRequest{Api: "v1", ToDoId: 2, PageToken: nextPageToken},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListCommentsRequest{Api: "v1000", ToDoId: 1},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListComments(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ListComments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ListComments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_UpdateComment(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.UpdateCommentRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.Comment
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateCommentRequest{Api: "v1", ToDoId: 1, Id: 2, Comment: &v1.Comment{Body: "edited"}},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Comment c JOIN ToDo t ON t.`ID`=c.`ToDoID` WHERE c.`ID`=\\? AND c.`ToDoID`=\\? AND t.`DeletedAt` IS NULL FOR UPDATE").
+					WithArgs(2, 1).
+					WillReturnRows(newCommentRows().AddRow(2, 1, "alice", "original", tm, nil))
+				mock.ExpectExec("UPDATE Comment SET `Body`=\\?, `UpdatedAt`=\\? WHERE `ID`=\\?").WithArgs("edited", sqlmock.AnyArg(), 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.Comment{Id: 2, ToDoId: 1, Author: "alice", Body: "edited", CreatedAt: ts},
+		},
+		{
+			name: "Not Found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateCommentRequest{Api: "v1", ToDoId: 1, Id: 2, Comment: &v1.Comment{Body: "edited"}},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Comment c").WithArgs(2, 1).WillReturnRows(newCommentRows())
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing comment",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateCommentRequest{Api: "v1", ToDoId: 1, Id: 2},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateCommentRequest{Api: "v1000", ToDoId: 1, Id: 2, Comment: &v1.Comment{Body: "edited"}},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.UpdateComment(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.UpdateComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				if got.Comment.UpdatedAt == nil {
+					t.Errorf("toDoServiceServer.UpdateComment() updatedAt is not set")
+				}
+				got.Comment.UpdatedAt = nil
+				if !reflect.DeepEqual(got.Comment, tt.want) {
+					t.Errorf("toDoServiceServer.UpdateComment() = %v, want %v", got.Comment, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_DeleteComment(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.DeleteCommentRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.DeleteCommentResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteCommentRequest{Api: "v1", ToDoId: 1, Id: 2},
+			},
+			mock: func() {
+				mock.ExpectExec("DELETE c FROM Comment c JOIN ToDo t ON t.`ID`=c.`ToDoID` WHERE c.`ID`=\\? AND c.`ToDoID`=\\? AND t.`DeletedAt` IS NULL").
+					WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: &v1.DeleteCommentResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Not Found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteCommentRequest{Api: "v1", ToDoId: 1, Id: 2},
+			},
+			mock: func() {
+				mock.ExpectExec("DELETE c FROM Comment c").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteCommentRequest{Api: "v1000", ToDoId: 1, Id: 2},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.DeleteComment(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.DeleteComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.DeleteComment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
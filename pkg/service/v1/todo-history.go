@@ -0,0 +1,215 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+// actorMetadata is the metadata key holding who makes the request, the HTTP gateway passes the X-Actor header in it
+const actorMetadata = "x-actor"
+
+// requestActor returns who makes the request, empty if unknown.
+// The subject of a verified token takes precedence over the actor the client claims to be.
+func requestActor(ctx context.Context) string {
+	if subject, ok := auth.Subject(ctx); ok {
+		return subject
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(actorMetadata) {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			return v
+		}
+	}
+	return ""
+}
+
+// snapshotToDos reads tasks with their tags, including tasks in trash, and returns them by ID
+func snapshotToDos(ctx context.Context, q queryer, ids []interface{}) (map[int64]*v1.ToDo, error) {
+	rows, err := q.QueryContext(ctx, "SELECT "+toDoColumns+" FROM ToDo WHERE `ID` IN ("+placeholders(len(ids))+")", ids...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	var list []*v1.ToDo
+	for rows.Next() {
+		td, err := scanToDo(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		list = append(list, td)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+
+	if err := loadTags(ctx, q, list); err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*v1.ToDo, len(list))
+	for _, td := range list {
+		byID[td.Id] = td
+	}
+	return byID, nil
+}
+
+// formatSnapshot returns the task as stored in the `Before` and `After` columns, nil if there is no task
+func formatSnapshot(td *v1.ToDo) (interface{}, error) {
+	if td == nil {
+		return nil, nil
+	}
+	var m jsonpb.Marshaler
+	s, err := m.MarshalToString(td)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to marshal ToDo-> "+err.Error())
+	}
+	return s, nil
+}
+
+// parseSnapshot parses the `Before` or `After` column
+func parseSnapshot(s sql.NullString) (*v1.ToDo, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	var td v1.ToDo
+	if err := jsonpb.UnmarshalString(s.String, &td); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to unmarshal ToDo-> "+err.Error())
+	}
+	return &td, nil
+}
+
+// recordHistory records the change of the tasks by the actor of the request with their snapshots before and after it,
+// it runs in the transaction of the change so that history has exactly the committed changes
+func recordHistory(ctx context.Context, q queryer, action v1.HistoryAction, ids []interface{}, before, after map[int64]*v1.ToDo) error {
+	actor := requestActor(ctx)
+	args := make([]interface{}, 0, 5*len(ids))
+	for _, v := range ids {
+		id := v.(int64)
+		b, err := formatSnapshot(before[id])
+		if err != nil {
+			return err
+		}
+		a, err := formatSnapshot(after[id])
+		if err != nil {
+			return err
+		}
+		args = append(args, id, int32(action), actor, b, a)
+	}
+
+	values := strings.TrimPrefix(strings.Repeat(",(?,?,?,?,?)", len(ids)), ",")
+	if _, err := q.ExecContext(ctx, "INSERT INTO ToDoHistory(`ToDoID`, `Action`, `Actor`, `Before`, `After`) VALUES"+values, args...); err != nil {
+		return status.Error(codes.Unknown, "failed to insert into ToDoHistory-> "+err.Error())
+	}
+	return nil
+}
+
+// ListTaskHistory returns changes of a task, latest first
+func (s *toDoServiceServer) ListTaskHistory(ctx context.Context, req *v1.ListTaskHistoryRequest) (*v1.ListTaskHistoryResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// page token holds ID of the last entry in the previous page
+	query := queryHash(strconv.FormatInt(req.Id, 10))
+	conds := []condition{{sql: "`ToDoID`=?", args: []interface{}{req.Id}}}
+	if len(req.PageToken) > 0 {
+		last, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if last.Query != query || len(last.Values) != 1 {
+			return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different task")
+		}
+		id, err := strconv.ParseInt(last.Values[0], 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "page_token has invalid format-> "+err.Error())
+		}
+		conds = append(conds, condition{sql: "`ID`<?", args: []interface{}{id}})
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.Id, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+		return nil, err
+	}
+
+	// history of a task in trash is kept, a purged task is not found
+	var count int64
+	if err := c.QueryRowContext(ctx, "SELECT COUNT(*) FROM ToDo WHERE `ID`=?", req.Id).Scan(&count); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	if count == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.Id))
+	}
+
+	// one extra row tells if there is a next page
+	sqlWhere, args := whereSQL(conds)
+	rows, err := c.QueryContext(ctx, "SELECT `ID`, `ToDoID`, `Action`, `Actor`, `Before`, `After`, `CreatedAt` FROM ToDoHistory"+sqlWhere+
+		" ORDER BY `ID` DESC LIMIT ?", append(args, size+1)...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoHistory-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.TaskHistoryEntry{}
+	for rows.Next() {
+		var e v1.TaskHistoryEntry
+		var action int32
+		var before, after sql.NullString
+		var createdAt time.Time
+		if err := rows.Scan(&e.Id, &e.ToDoId, &action, &e.Actor, &before, &after, &createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDoHistory row-> "+err.Error())
+		}
+		e.Action = v1.HistoryAction(action)
+		if e.Before, err = parseSnapshot(before); err != nil {
+			return nil, err
+		}
+		if e.After, err = parseSnapshot(after); err != nil {
+			return nil, err
+		}
+		if e.Time, err = ptypes.TimestampProto(createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+		}
+		list = append(list, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoHistory-> "+err.Error())
+	}
+
+	var nextPageToken string
+	if len(list) > size {
+		list = list[:size]
+		nextPageToken = encodePageToken(pageToken{Query: query, Values: []string{strconv.FormatInt(list[size-1].Id, 10)}})
+	}
+
+	return &v1.ListTaskHistoryResponse{
+		Api:           apiVersion,
+		Entries:       list,
+		NextPageToken: nextPageToken,
+	}, nil
+}
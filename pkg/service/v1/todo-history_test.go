@@ -0,0 +1,361 @@
+package v1
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+// expectSnapshot expects tasks to be read for their history, as they are at the version
+func expectSnapshot(mock sqlmock.Sqlmock, version int64, ids ...int64) {
+	rows := newToDoRows()
+	args := make([]driver.Value, len(ids))
+	for i, id := range ids {
+		row := toDoRow(id, "title", "description", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+		row[13] = version
+		rows.AddRow(row...)
+		args[i] = id
+	}
+	mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(args...).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(args...).WillReturnRows(newTagRows())
+}
+
+// expectHistory expects history entries of the tasks by an unknown actor
+func expectHistory(mock sqlmock.Sqlmock, action v1.HistoryAction, ids ...int64) {
+	expectHistoryBy(mock, "", action, ids...)
+}
+
+// expectHistoryBy expects history entries of the tasks by the actor
+func expectHistoryBy(mock sqlmock.Sqlmock, actor string, action v1.HistoryAction, ids ...int64) {
+	var args []driver.Value
+	for _, id := range ids {
+		args = append(args, id, int(action), actor, sqlmock.AnyArg(), sqlmock.AnyArg())
+	}
+	mock.ExpectExec("INSERT INTO ToDoHistory").WithArgs(args...).WillReturnResult(sqlmock.NewResult(1, int64(len(ids))))
+}
+
+func Test_toDoServiceServer_ListTaskHistory(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+	nextPageToken := encodePageToken(pageToken{Query: queryHash("1"), Values: []string{"3"}})
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListTaskHistoryRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListTaskHistoryResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListTaskHistoryRequest{Api: "v1", Id: 1, PageSize: 2},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT `ID`, `ToDoID`, `Action`, `Actor`, `Before`, `After`, `CreatedAt` FROM ToDoHistory WHERE `ToDoID`=\\? ORDER BY `ID` DESC LIMIT \\?").
+					WithArgs(1, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "Action", "Actor", "Before", "After", "CreatedAt"}).
+						AddRow(4, 1, 3, "alice", `{"id":"1","etag":"2","title":"new title"}`, `{"id":"1","etag":"3","title":"new title","deletedAt":"2020-01-01T00:00:00Z"}`, tm).
+						AddRow(3, 1, 2, "", `{"id":"1","etag":"1","title":"title"}`, `{"id":"1","etag":"2","title":"new title"}`, tm).
+						AddRow(1, 1, 1, "", nil, `{"id":"1","etag":"1","title":"title"}`, tm))
+			},
+			want: &v1.ListTaskHistoryResponse{
+				Api: "v1",
+				Entries: []*v1.TaskHistoryEntry{
+					{
+						Id:     4,
+						ToDoId: 1,
+						Action: v1.HistoryAction_HISTORY_ACTION_DELETE,
+						Actor:  "alice",
+						Before: &v1.ToDo{Id: 1, Etag: "2", Title: "new title"},
+						After:  &v1.ToDo{Id: 1, Etag: "3", Title: "new title", DeletedAt: &timestamp.Timestamp{Seconds: 1577836800}},
+						Time:   ts,
+					},
+					{
+						Id:     3,
+						ToDoId: 1,
+						Action: v1.HistoryAction_HISTORY_ACTION_UPDATE,
+						Before: &v1.ToDo{Id: 1, Etag: "1", Title: "title"},
+						After:  &v1.ToDo{Id: 1, Etag: "2", Title: "new title"},
+						Time:   ts,
+					},
+				},
+				NextPageToken: nextPageToken,
+			},
+		},
+		{
+			name: "Next page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListTaskHistoryRequest{Api: "v1", Id: 1, PageSize: 2, PageToken: nextPageToken},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoHistory WHERE `ToDoID`=\\? AND `ID`<\\?").WithArgs(1, 3, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"ID", "ToDoID", "Action", "Actor", "Before", "After", "CreatedAt"}).
+						AddRow(1, 1, 1, "", nil, `{"id":"1","etag":"1","title":"title"}`, tm))
+			},
+			want: &v1.ListTaskHistoryResponse{
+				Api: "v1",
+				Entries: []*v1.TaskHistoryEntry{
+					{
+						Id:     1,
+						ToDoId: 1,
+						Action: v1.HistoryAction_HISTORY_ACTION_CREATE,
+						After:  &v1.ToDo{Id: 1, Etag: "1", Title: "title"},
+						Time:   ts,
+					},
+				},
+			},
+		},
+		{
+			name: "Page token of another task",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListTaskHistoryRequest{Api: "v1", Id: 2, PageToken: nextPageToken},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Not Found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListTaskHistoryRequest{Api: "v1", Id: 1},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListTaskHistoryRequest{Api: "v1000", Id: 1},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListTaskHistory(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ListTaskHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ListTaskHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_requestActor(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "Unknown", ctx: ctx, want: ""},
+		{name: "Header", ctx: metadata.NewIncomingContext(ctx, metadata.Pairs(actorMetadata, " alice ")), want: "alice"},
+		{name: "Verified subject", ctx: auth.NewContext(ctx, "bob"), want: "bob"},
+		{name: "Verified subject over header", ctx: auth.NewContext(metadata.NewIncomingContext(ctx, metadata.Pairs(actorMetadata, "alice")), "bob"), want: "bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestActor(tt.ctx); got != tt.want {
+				t.Errorf("requestActor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_recordHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(actorMetadata, "alice"))
+
+	before := map[int64]*v1.ToDo{1: {Id: 1, Etag: "1", Title: "title"}}
+	after := map[int64]*v1.ToDo{1: {Id: 1, Etag: "2", Title: "new title"}}
+	mock.ExpectExec("INSERT INTO ToDoHistory\\(`ToDoID`, `Action`, `Actor`, `Before`, `After`\\) VALUES\\(\\?,\\?,\\?,\\?,\\?\\)$").
+		WithArgs(1, 2, "alice", `{"id":"1","title":"title","etag":"1"}`, `{"id":"1","title":"new title","etag":"2"}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := recordHistory(ctx, db, v1.HistoryAction_HISTORY_ACTION_UPDATE, []interface{}{int64(1)}, before, after); err != nil {
+		t.Errorf("recordHistory() error = %v", err)
+	}
+}
+
+func Test_toDoServiceServer_history(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+
+	// expectRead expects the task to be read at the version, completed at tm if done
+	expectRead := func(version int64, done bool, tags ...string) {
+		row := toDoRow(1, "title", "description", tm)
+		if done {
+			row[4], row[5] = true, tm
+		}
+		row[13] = version
+		tagRows := newTagRows()
+		for _, tag := range tags {
+			tagRows.AddRow(1, tag)
+		}
+		mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`").WithArgs(1).WillReturnRows(newToDoRows().AddRow(row...))
+		mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(tagRows)
+	}
+	// snapshot returns the task at the version as recorded in history
+	snapshot := func(version string, done bool, tags ...string) driver.Value {
+		td := &v1.ToDo{Id: 1, Etag: version, Title: "title", Description: "description", Reminder: ts, Tags: tags}
+		if done {
+			td.Completed, td.CompletedAt = true, ts
+		}
+		v, _ := formatSnapshot(td)
+		return v
+	}
+	expectUpdate := func(before, after driver.Value) {
+		mock.ExpectExec("INSERT INTO ToDoHistory").WithArgs(1, 2, "", before, after).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	expectEvent := func() {
+		mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		mock func()
+	}{
+		{
+			name: "Complete",
+			call: func() error {
+				_, err := s.Complete(ctx, &v1.CompleteRequest{Api: "v1", Id: 1})
+				return err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectRead(1, false)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=TRUE").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRead(2, true)
+				expectUpdate(snapshot("1", false), snapshot("2", true))
+				expectEvent()
+				expectRead(2, true)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Reopen",
+			call: func() error {
+				_, err := s.Reopen(ctx, &v1.ReopenRequest{Api: "v1", Id: 1})
+				return err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectRead(2, true)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=FALSE").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRead(3, false)
+				expectUpdate(snapshot("2", true), snapshot("3", false))
+				expectEvent()
+				expectRead(3, false)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "AddTags",
+			call: func() error {
+				_, err := s.AddTags(ctx, &v1.AddTagsRequest{Api: "v1", Id: 1, Tags: []string{"oncall"}})
+				return err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectRead(1, false)
+				mock.ExpectExec("UPDATE ToDo SET `Version`=`Version`\\+1").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectEvent()
+				mock.ExpectExec("INSERT IGNORE INTO Tag").WithArgs("oncall").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT IGNORE INTO ToDoTag").WithArgs(1, "oncall").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRead(2, false, "oncall")
+				expectUpdate(snapshot("1", false), snapshot("2", false, "oncall"))
+				expectRead(2, false, "oncall")
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "RemoveTags",
+			call: func() error {
+				_, err := s.RemoveTags(ctx, &v1.RemoveTagsRequest{Api: "v1", Id: 1, Tags: []string{"oncall"}})
+				return err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectRead(2, false, "oncall")
+				mock.ExpectExec("UPDATE ToDo SET `Version`=`Version`\\+1").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectEvent()
+				mock.ExpectExec("DELETE tt FROM ToDoTag").WithArgs(1, "oncall").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRead(3, false)
+				expectUpdate(snapshot("2", false, "oncall"), snapshot("3", false))
+				expectRead(3, false)
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			if err := tt.call(); err != nil {
+				t.Errorf("%s error = %v", tt.name, err)
+			}
+		})
+	}
+}
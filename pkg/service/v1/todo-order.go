@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// newPosition returns a position between a and b, see positionBetween
+func newPosition(a, b string) (string, error) {
+	position, err := positionBetween(a, b)
+	if err != nil {
+		return "", status.Error(codes.Unknown, "failed to compute position-> "+err.Error())
+	}
+	return position, nil
+}
+
+// lastPosition returns the position after all tasks, including tasks in trash.
+// The last task is locked so that concurrent inserts do not take the same position. InnoDB holds the lock
+// on the last `ToDo_Position` entry and the gap after it until the transaction ends, so creates wait for
+// each other: callers take the position right before the insert and keep the rest of the transaction short.
+// Reorder and next occurrences of recurring tasks lock their neighbours only.
+func lastPosition(ctx context.Context, q queryer) (string, error) {
+	var last string
+	err := q.QueryRowContext(ctx, "SELECT `Position` FROM ToDo ORDER BY `Position` DESC LIMIT 1 FOR UPDATE").Scan(&last)
+	if err != nil && err != sql.ErrNoRows {
+		return "", status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	return newPosition(last, "")
+}
+
+// neighbourPosition locks the task right before or after position, skipping the task with ID,
+// and returns its position. It returns an empty string if there is no such task.
+func neighbourPosition(ctx context.Context, q queryer, position string, id int64, after bool) (string, error) {
+	query := "SELECT `Position` FROM ToDo WHERE `Position`<? AND `ID`<>? ORDER BY `Position` DESC LIMIT 1 FOR UPDATE"
+	if after {
+		query = "SELECT `Position` FROM ToDo WHERE `Position`>? AND `ID`<>? ORDER BY `Position` LIMIT 1 FOR UPDATE"
+	}
+	var neighbour string
+	err := q.QueryRowContext(ctx, query, position, id).Scan(&neighbour)
+	if err != nil && err != sql.ErrNoRows {
+		return "", status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	return neighbour, nil
+}
+
+// positionOf returns the position of a task out of trash or NotFound error
+func positionOf(ctx context.Context, q queryer, id int64, forUpdate bool) (string, error) {
+	query := "SELECT `Position` FROM ToDo WHERE `ID`=? AND `DeletedAt` IS NULL"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	var position string
+	err := q.QueryRowContext(ctx, query, id).Scan(&position)
+	if err == sql.ErrNoRows {
+		return "", status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", id))
+	}
+	if err != nil {
+		return "", status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	return position, nil
+}
+
+// Reorder moves a task right before or after another task, only the position of the moved task changes
+func (s *toDoServiceServer) Reorder(ctx context.Context, req *v1.ReorderRequest) (*v1.ReorderResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if (req.BeforeId == 0) == (req.AfterId == 0) {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of before_id and after_id must be set")
+	}
+	target, placeAfter := req.BeforeId, false
+	if req.AfterId != 0 {
+		target, placeAfter = req.AfterId, true
+	}
+	if target == req.Id {
+		return nil, status.Error(codes.InvalidArgument, "task cannot be placed next to itself")
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	etag := requestEtag(ctx, req.Etag)
+
+	var position string
+	scope := callerScope(ctx)
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := scope.require(ctx, tx, req.Id, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+			return err
+		}
+		if err := scope.require(ctx, tx, target, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+			return err
+		}
+		if err := checkEtag(ctx, tx, req.Id, etag); err != nil {
+			return err
+		}
+		if _, err := positionOf(ctx, tx, req.Id, true); err != nil {
+			return err
+		}
+		anchor, err := positionOf(ctx, tx, target, false)
+		if err != nil {
+			return err
+		}
+		neighbour, err := neighbourPosition(ctx, tx, anchor, req.Id, placeAfter)
+		if err != nil {
+			return err
+		}
+		if placeAfter {
+			position, err = newPosition(anchor, neighbour)
+		} else {
+			position, err = newPosition(neighbour, anchor)
+		}
+		if err != nil {
+			return err
+		}
+
+		ids := []interface{}{req.Id}
+		before, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE ToDo SET `Position`=?, `Version`=`Version`+1 WHERE `ID`=?", position, req.Id); err != nil {
+			return status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+		}
+		after, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids, before, after); err != nil {
+			return err
+		}
+		if err := recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_UPDATED, "`ID`=?", req.Id); err != nil {
+			return err
+		}
+		etag = after[req.Id].GetEtag()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ReorderResponse{
+		Api:      apiVersion,
+		Position: position,
+		Etag:     etag,
+	}, nil
+}
@@ -0,0 +1,232 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// newPositionRows returns rows of a query selecting positions
+func newPositionRows(positions ...string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"Position"})
+	for _, position := range positions {
+		rows.AddRow(position)
+	}
+	return rows
+}
+
+func Test_toDoServiceServer_Reorder(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ReorderRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ReorderResponse
+		wantErr bool
+	}{
+		{
+			name: "Before",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReorderRequest{
+					Api:      "v1",
+					Id:       1,
+					BeforeId: 3,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL FOR UPDATE").WithArgs(1).
+					WillReturnRows(newPositionRows("a5"))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL$").WithArgs(3).
+					WillReturnRows(newPositionRows("a3"))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `Position`<\\? AND `ID`<>\\? ORDER BY `Position` DESC LIMIT 1 FOR UPDATE").
+					WithArgs("a3", 1).WillReturnRows(newPositionRows("a2"))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Position`=\\?, `Version`=`Version`\\+1 WHERE `ID`=\\?").WithArgs("a2V", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.ReorderResponse{
+				Api:      "v1",
+				Position: "a2V",
+				Etag:     "2",
+			},
+		},
+		{
+			name: "Before first",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReorderRequest{
+					Api:      "v1",
+					Id:       1,
+					BeforeId: 3,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `ID`=\\?").WithArgs(1).
+					WillReturnRows(newPositionRows("a5"))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `ID`=\\?").WithArgs(3).
+					WillReturnRows(newPositionRows("a0"))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `Position`<\\?").WithArgs("a0", 1).
+					WillReturnRows(newPositionRows())
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Position`=\\?").WithArgs("Zz", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.ReorderResponse{
+				Api:      "v1",
+				Position: "Zz",
+				Etag:     "2",
+			},
+		},
+		{
+			name: "After last",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReorderRequest{
+					Api:     "v1",
+					Id:      1,
+					AfterId: 3,
+					Etag:    "1",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Version` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(1))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `ID`=\\?").WithArgs(1).
+					WillReturnRows(newPositionRows("a0"))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `ID`=\\?").WithArgs(3).
+					WillReturnRows(newPositionRows("a5"))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `Position`>\\? AND `ID`<>\\? ORDER BY `Position` LIMIT 1 FOR UPDATE").
+					WithArgs("a5", 1).WillReturnRows(newPositionRows())
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Position`=\\?").WithArgs("a6", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.ReorderResponse{
+				Api:      "v1",
+				Position: "a6",
+				Etag:     "2",
+			},
+		},
+		{
+			name: "Stale etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReorderRequest{
+					Api:     "v1",
+					Id:      1,
+					AfterId: 3,
+					Etag:    "1",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Version` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(2))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Target not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReorderRequest{
+					Api:     "v1",
+					Id:      1,
+					AfterId: 3,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `ID`=\\?").WithArgs(1).
+					WillReturnRows(newPositionRows("a0"))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `ID`=\\?").WithArgs(3).
+					WillReturnRows(newPositionRows())
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Both before and after",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReorderRequest{
+					Api:      "v1",
+					Id:       1,
+					BeforeId: 2,
+					AfterId:  3,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Next to itself",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReorderRequest{
+					Api:      "v1",
+					Id:       1,
+					BeforeId: 1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.Reorder(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.Reorder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.Reorder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+// WithAdmins allows the subjects to reach tasks of all owners by setting all_owners in requests
+func WithAdmins(subjects []string) Option {
+	return func(s *toDoServiceServer) {
+		s.admins = map[string]bool{}
+		for _, subject := range subjects {
+			if len(subject) > 0 {
+				s.admins[subject] = true
+			}
+		}
+	}
+}
+
+// callerOwner returns the owner of tasks the caller creates, empty if authentication is disabled
+func callerOwner(ctx context.Context) string {
+	subject, _ := auth.Subject(ctx)
+	return subject
+}
+
+// taskScope is the tasks a caller reaches: its own ones and the ones shared with it.
+// The zero scope reaches every task, authentication is disabled.
+type taskScope struct {
+	// subject is the caller, empty if authentication is disabled
+	subject string
+	// all is set for admins reaching tasks of all owners
+	all bool
+}
+
+// callerScope returns the scope of the caller
+func callerScope(ctx context.Context) taskScope {
+	return taskScope{subject: callerOwner(ctx)}
+}
+
+// requestScope returns the scope of the caller, reaching every task if an admin asked for tasks of all owners,
+// which is PermissionDenied for other callers
+func (s *toDoServiceServer) requestScope(ctx context.Context, allOwners bool) (taskScope, error) {
+	scope := callerScope(ctx)
+	if allOwners && len(scope.subject) > 0 {
+		if !s.admins[scope.subject] {
+			return taskScope{}, status.Error(codes.PermissionDenied, "all_owners is allowed to admins only")
+		}
+		scope.all = true
+	}
+	return scope, nil
+}
+
+// unlimited reports whether the scope reaches every task with any access
+func (sc taskScope) unlimited() bool {
+	return len(sc.subject) == 0 || sc.all
+}
+
+// conditions limits tasks to the ones the scope reaches with at least the access
+func (sc taskScope) conditions(access v1.AccessLevel) []condition {
+	if sc.unlimited() {
+		return nil
+	}
+	if access >= v1.AccessLevel_ACCESS_LEVEL_OWNER {
+		return []condition{{sql: "`OwnerID`=?", args: []interface{}{sc.subject}}}
+	}
+	return []condition{{
+		sql:  "(`OwnerID`=? OR `ID` IN (SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=? AND `Access`>=?))",
+		args: []interface{}{sc.subject, sc.subject, int32(access)},
+	}}
+}
+
+// require returns NotFound error if the scope does not reach the task, so that tasks of other owners
+// can't be told from missing ones, and PermissionDenied error if it reaches the task with less than the access
+func (sc taskScope) require(ctx context.Context, q queryer, id int64, access v1.AccessLevel) error {
+	if sc.unlimited() {
+		return nil
+	}
+	var owner string
+	var shared int32
+	err := q.QueryRowContext(ctx, "SELECT t.`OwnerID`, COALESCE(s.`Access`, 0) FROM ToDo t "+
+		"LEFT JOIN ToDoShare s ON s.`ToDoID`=t.`ID` AND s.`Subject`=? WHERE t.`ID`=?", sc.subject, id).Scan(&owner, &shared)
+	if err != nil && err != sql.ErrNoRows {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	got := v1.AccessLevel(shared)
+	if err == nil && owner == sc.subject {
+		got = v1.AccessLevel_ACCESS_LEVEL_OWNER
+	}
+	if got == v1.AccessLevel_ACCESS_LEVEL_UNSPECIFIED {
+		return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", id))
+	}
+	if got < access {
+		return status.Errorf(codes.PermissionDenied, "%s access to ToDo with ID='%d' is required, the caller is %s", accessName(access), id, accessName(got))
+	}
+	return nil
+}
+
+// requireParent returns FailedPrecondition error if the scope does not reach the new parent of a task,
+// the same error checkParent returns for a missing parent. Subtasks are added by editors of the parent.
+func (sc taskScope) requireParent(ctx context.Context, q queryer, parent int64) error {
+	if parent == 0 {
+		return nil
+	}
+	if err := sc.require(ctx, q, parent, v1.AccessLevel_ACCESS_LEVEL_EDITOR); status.Code(err) == codes.NotFound {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("parent ToDo with ID='%d' is not found", parent))
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// accessName returns the access level in lower case without its prefix, e.g. "viewer"
+func accessName(access v1.AccessLevel) string {
+	return strings.ToLower(strings.TrimPrefix(access.String(), "ACCESS_LEVEL_"))
+}
+
+// loadAccess sets access of the scope to the tasks, it is left unspecified if authentication is disabled
+// and for tasks an admin reaches without them being shared
+func loadAccess(ctx context.Context, q queryer, sc taskScope, list []*v1.ToDo) error {
+	if len(sc.subject) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*v1.ToDo, len(list))
+	ids := []interface{}{sc.subject}
+	for _, td := range list {
+		if td.OwnerId == sc.subject {
+			td.Access = v1.AccessLevel_ACCESS_LEVEL_OWNER
+			continue
+		}
+		byID[td.Id] = td
+		ids = append(ids, td.Id)
+	}
+	if len(byID) == 0 {
+		return nil
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT `ToDoID`, `Access` FROM ToDoShare WHERE `Subject`=? AND `ToDoID` IN ("+placeholders(len(ids)-1)+")", ids...)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDoShare-> "+err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var access int32
+		if err := rows.Scan(&id, &access); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve field values from ToDoShare row-> "+err.Error())
+		}
+		if td, ok := byID[id]; ok {
+			td.Access = v1.AccessLevel(access)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve data from ToDoShare-> "+err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,447 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/search"
+)
+
+func Test_taskScope_conditions(t *testing.T) {
+	alice := taskScope{subject: "alice"}
+	tests := []struct {
+		name   string
+		scope  taskScope
+		access v1.AccessLevel
+		want   []condition
+	}{
+		{
+			name:   "Authentication disabled",
+			scope:  taskScope{},
+			access: v1.AccessLevel_ACCESS_LEVEL_OWNER,
+		},
+		{
+			name:   "Admin reaching all owners",
+			scope:  taskScope{subject: "alice", all: true},
+			access: v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+		},
+		{
+			name:   "Owner",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_OWNER,
+			want:   []condition{{sql: "`OwnerID`=?", args: []interface{}{"alice"}}},
+		},
+		{
+			name:   "Editor",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_EDITOR,
+			want: []condition{{
+				sql:  "(`OwnerID`=? OR `ID` IN (SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=? AND `Access`>=?))",
+				args: []interface{}{"alice", "alice", int32(v1.AccessLevel_ACCESS_LEVEL_EDITOR)},
+			}},
+		},
+		{
+			name:   "Viewer",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+			want: []condition{{
+				sql:  "(`OwnerID`=? OR `ID` IN (SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=? AND `Access`>=?))",
+				args: []interface{}{"alice", "alice", int32(v1.AccessLevel_ACCESS_LEVEL_VIEWER)},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.conditions(tt.access); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskScope.conditions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_taskScope_require(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	alice := taskScope{subject: "alice"}
+
+	tests := []struct {
+		name   string
+		scope  taskScope
+		access v1.AccessLevel
+		mock   func()
+		want   codes.Code
+	}{
+		{
+			name:   "Authentication disabled",
+			scope:  taskScope{},
+			access: v1.AccessLevel_ACCESS_LEVEL_OWNER,
+			mock:   func() {},
+			want:   codes.OK,
+		},
+		{
+			name:   "Own task",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_OWNER,
+			mock: func() {
+				expectAccess(mock, 1, "alice", "alice", v1.AccessLevel_ACCESS_LEVEL_UNSPECIFIED)
+			},
+			want: codes.OK,
+		},
+		{
+			name:   "Shared with enough access",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_EDITOR,
+			mock: func() {
+				expectAccess(mock, 1, "alice", "bob", v1.AccessLevel_ACCESS_LEVEL_EDITOR)
+			},
+			want: codes.OK,
+		},
+		{
+			name:   "Shared with less access",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_EDITOR,
+			mock: func() {
+				expectAccess(mock, 1, "alice", "bob", v1.AccessLevel_ACCESS_LEVEL_VIEWER)
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name:   "Task of another owner",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+			mock: func() {
+				expectAccess(mock, 1, "alice", "bob", v1.AccessLevel_ACCESS_LEVEL_UNSPECIFIED)
+			},
+			want: codes.NotFound,
+		},
+		{
+			name:   "Missing task",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+			mock: func() {
+				mock.ExpectQuery("SELECT t.`OwnerID`, COALESCE\\(s.`Access`, 0\\) FROM ToDo t").WithArgs("alice", 1).
+					WillReturnError(sql.ErrNoRows)
+			},
+			want: codes.NotFound,
+		},
+		{
+			name:   "SELECT failed",
+			scope:  alice,
+			access: v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+			mock: func() {
+				mock.ExpectQuery("SELECT t.`OwnerID`, COALESCE\\(s.`Access`, 0\\) FROM ToDo t").WithArgs("alice", 1).
+					WillReturnError(errors.New("SELECT failed"))
+			},
+			want: codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			if got := status.Code(tt.scope.require(ctx, db, 1, tt.access)); got != tt.want {
+				t.Errorf("taskScope.require() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_requestScope(t *testing.T) {
+	ctx := context.Background()
+	s := &toDoServiceServer{}
+	WithAdmins([]string{"root", ""})(s)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		allOwners bool
+		want      taskScope
+		wantErr   bool
+	}{
+		{name: "Authentication disabled", ctx: ctx, allOwners: true, want: taskScope{}},
+		{name: "Caller", ctx: auth.NewContext(ctx, "alice"), want: taskScope{subject: "alice"}},
+		{name: "Admin", ctx: auth.NewContext(ctx, "root"), allOwners: true, want: taskScope{subject: "root", all: true}},
+		{name: "Not an admin", ctx: auth.NewContext(ctx, "alice"), allOwners: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.requestScope(tt.ctx, tt.allOwners)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.requestScope() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("toDoServiceServer.requestScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadAccess(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tasks := func() []*v1.ToDo {
+		return []*v1.ToDo{{Id: 1, OwnerId: "alice"}, {Id: 2, OwnerId: "bob"}, {Id: 3, OwnerId: "bob"}}
+	}
+
+	tests := []struct {
+		name  string
+		scope taskScope
+		mock  func()
+		want  []v1.AccessLevel
+	}{
+		{
+			name:  "Authentication disabled",
+			scope: taskScope{},
+			mock:  func() {},
+			want:  []v1.AccessLevel{0, 0, 0},
+		},
+		{
+			name:  "Own and shared tasks",
+			scope: taskScope{subject: "alice"},
+			mock: func() {
+				mock.ExpectQuery("SELECT `ToDoID`, `Access` FROM ToDoShare WHERE `Subject`=\\? AND `ToDoID` IN \\(\\?,\\?\\)").WithArgs("alice", 2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Access"}).AddRow(2, int32(v1.AccessLevel_ACCESS_LEVEL_EDITOR)))
+			},
+			want: []v1.AccessLevel{v1.AccessLevel_ACCESS_LEVEL_OWNER, v1.AccessLevel_ACCESS_LEVEL_EDITOR, v1.AccessLevel_ACCESS_LEVEL_UNSPECIFIED},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			list := tasks()
+			if err := loadAccess(ctx, db, tt.scope, list); err != nil {
+				t.Errorf("loadAccess() error = %v", err)
+				return
+			}
+			var got []v1.AccessLevel
+			for _, td := range list {
+				got = append(got, td.Access)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadAccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_ownerIsolation checks that bob reaches none of the projects, tags, webhooks and search results of alice,
+// nor moves her tasks between projects
+func Test_ownerIsolation(t *testing.T) {
+	bob := auth.NewContext(context.Background(), "bob")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	index := search.NewMemoryIndex()
+	_ = index.Put(bob, search.Document{ID: 1, Title: "invoice", OwnerID: "alice"})
+	s := &toDoServiceServer{dbService: dbService{db: db}, search: index}
+	w := NewWebhookServiceServer(db)
+
+	tests := []struct {
+		name string
+		// call returns the number of items of alice it reaches
+		call func() (int, error)
+		mock func()
+		want codes.Code
+	}{
+		{
+			name: "Read project",
+			call: func() (int, error) {
+				_, err := s.ReadProject(bob, &v1.ReadProjectRequest{Api: "v1", Id: 1})
+				return 0, err
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? AND `OwnerID`=\\?$").WithArgs(1, "bob").
+					WillReturnRows(newProjectRows())
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "Delete project",
+			call: func() (int, error) {
+				_, err := s.DeleteProject(bob, &v1.DeleteProjectRequest{Api: "v1", Id: 1})
+				return 0, err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? AND `OwnerID`=\\? FOR UPDATE").WithArgs(1, "bob").
+					WillReturnRows(newProjectRows())
+				mock.ExpectRollback()
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "List projects",
+			call: func() (int, error) {
+				res, err := s.ListProjects(bob, &v1.ListProjectsRequest{Api: "v1"})
+				return len(res.GetProjects()), err
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `OwnerID`=\\? AND `ArchivedAt` IS NULL").WithArgs("bob", defaultPageSize+1).
+					WillReturnRows(newProjectRows())
+			},
+			want: codes.OK,
+		},
+		{
+			name: "Move shared task into own project",
+			call: func() (int, error) {
+				res, err := s.MoveTask(bob, &v1.MoveTaskRequest{Api: "v1", Id: 1, ProjectId: 2})
+				return int(res.GetMoved()), err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_EDITOR)
+				mock.ExpectRollback()
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "Move shared task out of projects",
+			call: func() (int, error) {
+				res, err := s.MoveTask(bob, &v1.MoveTaskRequest{Api: "v1", Id: 1})
+				return int(res.GetMoved()), err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_EDITOR)
+				mock.ExpectRollback()
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "List tags",
+			call: func() (int, error) {
+				res, err := s.ListTags(bob, &v1.ListTagsRequest{Api: "v1"})
+				return len(res.GetTags()), err
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT t.`Name`, COUNT\\(tt.`ToDoID`\\) FROM Tag t JOIN ToDoTag tt (.+) WHERE tt.`ToDoID` IN \\(SELECT `ID` FROM ToDo WHERE").
+					WithArgs(scopeArgs("bob", v1.AccessLevel_ACCESS_LEVEL_VIEWER)...).
+					WillReturnRows(sqlmock.NewRows([]string{"Name", "Count"}))
+			},
+			want: codes.OK,
+		},
+		{
+			name: "Rename tag",
+			call: func() (int, error) {
+				_, err := s.RenameTag(bob, &v1.RenameTagRequest{Api: "v1", Name: "backend", NewName: "server"})
+				return 0, err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ID` IN \\(SELECT tt.`ToDoID`").
+					WithArgs(scopeArgs("bob", v1.AccessLevel_ACCESS_LEVEL_VIEWER, "server")...).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+				expectTouchTagged(mock, "backend", "bob")
+				mock.ExpectRollback()
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "Delete tag",
+			call: func() (int, error) {
+				_, err := s.DeleteTag(bob, &v1.DeleteTagRequest{Api: "v1", Name: "backend"})
+				return 0, err
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectTouchTagged(mock, "backend", "bob")
+				mock.ExpectRollback()
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "List webhooks",
+			call: func() (int, error) {
+				res, err := w.ListWebhooks(bob, &v1.ListWebhooksRequest{Api: "v1"})
+				return len(res.GetWebhooks()), err
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT `ID`, `URL`, `EventTypes`, `CreatedAt`, `OwnerID` FROM Webhook WHERE `OwnerID`=\\? ORDER BY `ID`").WithArgs("bob").
+					WillReturnRows(newWebhookRows())
+			},
+			want: codes.OK,
+		},
+		{
+			name: "Delete webhook",
+			call: func() (int, error) {
+				_, err := w.DeleteWebhook(bob, &v1.DeleteWebhookRequest{Api: "v1", Id: 1})
+				return 0, err
+			},
+			mock: func() {
+				mock.ExpectExec("DELETE FROM Webhook WHERE `ID`=\\? AND `OwnerID`=\\?").WithArgs(1, "bob").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "List webhook deliveries",
+			call: func() (int, error) {
+				res, err := w.ListWebhookDeliveries(bob, &v1.ListWebhookDeliveriesRequest{Api: "v1", WebhookId: 1})
+				return len(res.GetDeliveries()), err
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Webhook WHERE `ID`=\\? AND `OwnerID`=\\?").WithArgs(1, "bob").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "Search",
+			call: func() (int, error) {
+				res, err := s.Search(bob, &v1.SearchRequest{Api: "v1", Q: "invoice"})
+				return len(res.GetResults()), err
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=\\?").WithArgs("bob").
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID"}))
+			},
+			want: codes.OK,
+		},
+		{
+			name: "Search task shared and unshared since indexing",
+			call: func() (int, error) {
+				res, err := s.Search(bob, &v1.SearchRequest{Api: "v1", Q: "invoice"})
+				return len(res.GetResults()), err
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=\\?").WithArgs("bob").
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN \\(\\?\\) AND `DeletedAt` IS NULL AND \\(`OwnerID`=\\? OR").
+					WithArgs(1, "bob", "bob", int32(v1.AccessLevel_ACCESS_LEVEL_VIEWER)).
+					WillReturnRows(newToDoRows())
+			},
+			want: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.call()
+			if code := status.Code(err); code != tt.want {
+				t.Errorf("%s code = %v, want %v, error = %v", tt.name, code, tt.want, err)
+			}
+			if got != 0 {
+				t.Errorf("%s reached %d items of another owner, want 0", tt.name, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,608 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// maxProjectNameLength is the longest project name in characters
+	maxProjectNameLength = 200
+
+	// maxProjectDescriptionLength is the longest project description in characters
+	maxProjectDescriptionLength = 1024
+
+	// projectColumns are the Project table columns read by scanProject
+	projectColumns = "`ID`, `Name`, `Description`, `ArchivedAt`, `CreatedAt`, `OwnerID`"
+)
+
+// projectFields are the fields of a project UpdateProject writes, in the order of SET list
+var projectFields = []string{"name", "description"}
+
+// projectConditions returns conditions selecting the project by ID if the scope reaches it, projects are reached by their owner only
+func projectConditions(scope taskScope, id int64) []condition {
+	return append([]condition{{sql: "`ID`=?", args: []interface{}{id}}}, scope.conditions(v1.AccessLevel_ACCESS_LEVEL_OWNER)...)
+}
+
+// checkProjectExists checks if a project the scope reaches exists, archived or not
+func checkProjectExists(ctx context.Context, q queryer, scope taskScope, id int64) error {
+	sqlWhere, args := whereSQL(projectConditions(scope, id))
+	var count int64
+	if err := q.QueryRowContext(ctx, "SELECT COUNT(*) FROM Project"+sqlWhere, args...).Scan(&count); err != nil {
+		return status.Error(codes.Unknown, "failed to select from Project-> "+err.Error())
+	}
+	if count == 0 {
+		return status.Error(codes.NotFound, fmt.Sprintf("Project with ID='%d' is not found", id))
+	}
+	return nil
+}
+
+// checkProjectActive checks that tasks can be added to a project, it must be reached by the scope and not be archived.
+// The project is locked so that it is not archived or deleted before the task is added.
+func checkProjectActive(ctx context.Context, q queryer, scope taskScope, id int64) error {
+	sqlWhere, args := whereSQL(projectConditions(scope, id))
+	var archivedAt sql.NullTime
+	err := q.QueryRowContext(ctx, "SELECT `ArchivedAt` FROM Project"+sqlWhere+" LOCK IN SHARE MODE", args...).Scan(&archivedAt)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Project with ID='%d' is not found", id))
+	}
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from Project-> "+err.Error())
+	}
+	if archivedAt.Valid {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Project with ID='%d' is archived", id))
+	}
+	return nil
+}
+
+// taskProject returns the project of a task created under parent, which must already be checked.
+// A subtask belongs to the project of its parent, project must be 0 or the same.
+// A top level task is added to a project the scope reaches, a subtask to the project of its parent whoever owns it.
+func taskProject(ctx context.Context, q queryer, scope taskScope, parent, project int64) (int64, error) {
+	if parent != 0 {
+		scope = taskScope{}
+		var parentProject sql.NullInt64
+		if err := q.QueryRowContext(ctx, "SELECT `ProjectID` FROM ToDo WHERE `ID`=?", parent).Scan(&parentProject); err != nil {
+			return 0, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		if project != 0 && project != parentProject.Int64 {
+			return 0, status.Error(codes.InvalidArgument, "project_id of a subtask must be the project of its parent")
+		}
+		project = parentProject.Int64
+	}
+	if project != 0 {
+		if err := checkProjectActive(ctx, q, scope, project); err != nil {
+			return 0, err
+		}
+	}
+	return project, nil
+}
+
+// checkSameProject checks that task id can become a subtask of parent without changing its project
+func checkSameProject(ctx context.Context, q queryer, id int64, parent int64) error {
+	if parent == 0 {
+		return nil
+	}
+	var same bool
+	err := q.QueryRowContext(ctx, "SELECT t.`ProjectID` <=> p.`ProjectID` FROM ToDo t JOIN ToDo p ON p.`ID`=? WHERE t.`ID`=?", parent, id).Scan(&same)
+	if err == sql.ErrNoRows {
+		// the update reports the missing task
+		return nil
+	}
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	if !same {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("parent ToDo with ID='%d' is in another project, use MoveTask first", parent))
+	}
+	return nil
+}
+
+// projectValues validates the name and description of a project
+func projectValues(p *v1.Project) (string, string, error) {
+	if p == nil {
+		return "", "", status.Error(codes.InvalidArgument, "project field is required")
+	}
+	name := strings.TrimSpace(p.Name)
+	if len(name) == 0 {
+		return "", "", status.Error(codes.InvalidArgument, "name field is required")
+	}
+	if utf8.RuneCountInString(name) > maxProjectNameLength {
+		return "", "", status.Errorf(codes.InvalidArgument, "name must be at most %d characters", maxProjectNameLength)
+	}
+	if utf8.RuneCountInString(p.Description) > maxProjectDescriptionLength {
+		return "", "", status.Errorf(codes.InvalidArgument, "description must be at most %d characters", maxProjectDescriptionLength)
+	}
+	return name, p.Description, nil
+}
+
+// projectMask returns the fields listed in mask, all of them if mask is empty or "*"
+func projectMask(mask *field_mask.FieldMask) (map[string]bool, error) {
+	paths := map[string]bool{}
+	for _, p := range mask.GetPaths() {
+		switch p {
+		case "*":
+			for _, f := range projectFields {
+				paths[f] = true
+			}
+		case "id":
+		case "name", "description":
+			paths[p] = true
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown field '%s' in update_mask", p)
+		}
+	}
+	if len(mask.GetPaths()) == 0 {
+		for _, f := range projectFields {
+			paths[f] = true
+		}
+	}
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask does not contain any field to update")
+	}
+	return paths, nil
+}
+
+// scanProject reads a Project row selected by projectColumns
+func scanProject(rows *sql.Rows) (*v1.Project, error) {
+	var p v1.Project
+	var archivedAt sql.NullTime
+	var createdAt time.Time
+	if err := rows.Scan(&p.Id, &p.Name, &p.Description, &archivedAt, &createdAt, &p.OwnerId); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from Project row-> "+err.Error())
+	}
+
+	var err error
+	if archivedAt.Valid {
+		if p.ArchivedAt, err = ptypes.TimestampProto(archivedAt.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "archivedAt field has invalid format-> "+err.Error())
+		}
+	}
+	if p.CreatedAt, err = ptypes.TimestampProto(createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+	return &p, nil
+}
+
+// readProject selects a project the scope reaches by ID, locking it for update if forUpdate is set
+func readProject(ctx context.Context, q queryer, scope taskScope, id int64, forUpdate bool) (*v1.Project, error) {
+	sqlWhere, args := whereSQL(projectConditions(scope, id))
+	query := "SELECT " + projectColumns + " FROM Project" + sqlWhere
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Project-> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve data from Project-> "+err.Error())
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Project with ID='%d' is not found", id))
+	}
+	return scanProject(rows)
+}
+
+// trashProjectTasks moves the tasks of a project out of trash to trash and out of the project,
+// it returns IDs of the moved tasks. The tasks must be owned by the scope, a project holding tasks
+// of other owners, e.g. subtasks collaborators added to shared tasks, is refused with FailedPrecondition.
+func trashProjectTasks(ctx context.Context, tx *sql.Tx, scope taskScope, id int64) ([]interface{}, error) {
+	if !scope.unlimited() {
+		var others int64
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM ToDo WHERE `ProjectID`=? AND `DeletedAt` IS NULL AND `OwnerID`<>?", id, scope.subject).Scan(&others); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		if others > 0 {
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Project with ID='%d' holds %d tasks of other owners, move or delete them first", id, others))
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT `ID` FROM ToDo WHERE `ProjectID`=? AND `DeletedAt` IS NULL FOR UPDATE", id)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	var ids []interface{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+		}
+		ids = append(ids, id)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	before, err := snapshotToDos(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	// the tasks leave the project now, so that their snapshots match what Restore brings back
+	args := append([]interface{}{time.Now().UTC()}, ids...)
+	if _, err := tx.ExecContext(ctx, "UPDATE ToDo SET `DeletedAt`=?, `ProjectID`=NULL, `Version`=`Version`+1 WHERE `ID` IN ("+placeholders(len(ids))+")", args...); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete Todo-> "+err.Error())
+	}
+	after, err := snapshotToDos(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_DELETE, ids, before, after); err != nil {
+		return nil, err
+	}
+	if err := recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_DELETED, "`ID` IN ("+placeholders(len(ids))+")", ids...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CreateProject creates a project
+func (s *toDoServiceServer) CreateProject(ctx context.Context, req *v1.CreateProjectRequest) (*v1.CreateProjectResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	name, description, err := projectValues(req.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	// the project belongs to the caller
+	now := time.Now().UTC().Truncate(time.Second)
+	owner := callerOwner(ctx)
+	res, err := c.ExecContext(ctx, "INSERT INTO Project(`Name`, `Description`, `CreatedAt`, `OwnerID`) VALUES(?,?,?,?)", name, description, now, owner)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into Project-> "+err.Error())
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve id for created Project-> "+err.Error())
+	}
+
+	createdAt, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+
+	return &v1.CreateProjectResponse{
+		Api: apiVersion,
+		Project: &v1.Project{
+			Id:          id,
+			Name:        name,
+			Description: description,
+			CreatedAt:   createdAt,
+			OwnerId:     owner,
+		},
+	}, nil
+}
+
+// ReadProject reads a project, archived or not
+func (s *toDoServiceServer) ReadProject(ctx context.Context, req *v1.ReadProjectRequest) (*v1.ReadProjectResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	p, err := readProject(ctx, c, callerScope(ctx), req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ReadProjectResponse{
+		Api:     apiVersion,
+		Project: p,
+	}, nil
+}
+
+// ListProjects returns projects, oldest first
+func (s *toDoServiceServer) ListProjects(ctx context.Context, req *v1.ListProjectsRequest) (*v1.ListProjectsResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	conds := callerScope(ctx).conditions(v1.AccessLevel_ACCESS_LEVEL_OWNER)
+	if !req.ShowArchived {
+		conds = append(conds, condition{sql: "`ArchivedAt` IS NULL"})
+	}
+
+	// page token holds ID of the last project in the previous page
+	query := queryHash(strconv.FormatBool(req.ShowArchived))
+	if len(req.PageToken) > 0 {
+		last, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if last.Query != query || len(last.Values) != 1 {
+			return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different query")
+		}
+		id, err := strconv.ParseInt(last.Values[0], 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "page_token has invalid format-> "+err.Error())
+		}
+		conds = append(conds, condition{sql: "`ID`>?", args: []interface{}{id}})
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	// one extra row tells if there is a next page
+	sqlWhere, args := whereSQL(conds)
+	rows, err := c.QueryContext(ctx, "SELECT "+projectColumns+" FROM Project"+sqlWhere+" ORDER BY `ID` LIMIT ?", append(args, size+1)...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Project-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Project{}
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from Project-> "+err.Error())
+	}
+
+	var nextPageToken string
+	if len(list) > size {
+		list = list[:size]
+		nextPageToken = encodePageToken(pageToken{Query: query, Values: []string{strconv.FormatInt(list[size-1].Id, 10)}})
+	}
+
+	return &v1.ListProjectsResponse{
+		Api:           apiVersion,
+		Projects:      list,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// UpdateProject changes the fields of a project listed in update mask
+func (s *toDoServiceServer) UpdateProject(ctx context.Context, req *v1.UpdateProjectRequest) (*v1.UpdateProjectResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	paths, err := projectMask(req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+	if req.Project == nil {
+		return nil, status.Error(codes.InvalidArgument, "project field is required")
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var p *v1.Project
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if p, err = readProject(ctx, tx, callerScope(ctx), req.Project.Id, true); err != nil {
+			return err
+		}
+		if paths["name"] {
+			p.Name = req.Project.Name
+		}
+		if paths["description"] {
+			p.Description = req.Project.Description
+		}
+		name, description, err := projectValues(p)
+		if err != nil {
+			return err
+		}
+		p.Name, p.Description = name, description
+
+		if _, err := tx.ExecContext(ctx, "UPDATE Project SET `Name`=?, `Description`=? WHERE `ID`=?", name, description, p.Id); err != nil {
+			return status.Error(codes.Unknown, "failed to update Project-> "+err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.UpdateProjectResponse{
+		Api:     apiVersion,
+		Project: p,
+	}, nil
+}
+
+// DeleteProject archives a project or deletes it moving its tasks to trash, as selected by mode
+func (s *toDoServiceServer) DeleteProject(ctx context.Context, req *v1.DeleteProjectRequest) (*v1.DeleteProjectResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if _, ok := v1.ProjectDeleteMode_name[int32(req.Mode)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "mode field has invalid value %d", req.Mode)
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	var trashed []interface{}
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		p, err := readProject(ctx, tx, scope, req.Id, true)
+		if err != nil {
+			return err
+		}
+
+		if req.Mode == v1.ProjectDeleteMode_PROJECT_DELETE_MODE_ARCHIVE {
+			if p.ArchivedAt != nil {
+				return status.Error(codes.FailedPrecondition, fmt.Sprintf("Project with ID='%d' is already archived", req.Id))
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE Project SET `ArchivedAt`=? WHERE `ID`=?", time.Now().UTC(), req.Id); err != nil {
+				return status.Error(codes.Unknown, "failed to update Project-> "+err.Error())
+			}
+			return nil
+		}
+
+		if trashed, err = trashProjectTasks(ctx, tx, scope, req.Id); err != nil {
+			return err
+		}
+		// tasks already in trash leave the project by the foreign key
+		if _, err := tx.ExecContext(ctx, "DELETE FROM Project WHERE `ID`=?", req.Id); err != nil {
+			return status.Error(codes.Unknown, "failed to delete Project-> "+err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.unindex(ctx, [][]interface{}{trashed}); err != nil {
+		return nil, err
+	}
+
+	return &v1.DeleteProjectResponse{
+		Api:          apiVersion,
+		Deleted:      1,
+		DeletedToDos: int64(len(trashed)),
+	}, nil
+}
+
+// MoveTask moves a top level task with its subtasks, including the ones in trash, to another project.
+// Only the owner moves a task, so that collaborators do not take it into their projects or out of the owner's.
+func (s *toDoServiceServer) MoveTask(ctx context.Context, req *v1.MoveTaskRequest) (*v1.MoveTaskResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	etag := requestEtag(ctx, req.Etag)
+
+	var rows int64
+	scope := callerScope(ctx)
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := scope.require(ctx, tx, req.Id, v1.AccessLevel_ACCESS_LEVEL_OWNER); err != nil {
+			return err
+		}
+		if err := checkEtag(ctx, tx, req.Id, etag); err != nil {
+			return err
+		}
+
+		var parent sql.NullInt64
+		err := tx.QueryRowContext(ctx, "SELECT `ParentID` FROM ToDo WHERE `ID`=? AND `DeletedAt` IS NULL FOR UPDATE", req.Id).Scan(&parent)
+		if err == sql.ErrNoRows {
+			return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.Id))
+		}
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		if parent.Valid {
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf("ToDo with ID='%d' is a subtask, move its top level task", req.Id))
+		}
+		if req.ProjectId != 0 {
+			if err := checkProjectActive(ctx, tx, scope, req.ProjectId); err != nil {
+				return err
+			}
+		}
+
+		// subtasks in trash move too, so that Restore brings them back to the project of their parent
+		ids := []interface{}{req.Id}
+		seen := map[int64]bool{req.Id: true}
+		for level := ids; len(level) > 0; {
+			if level, err = childIDs(ctx, tx, level, seen, "TRUE"); err != nil {
+				return err
+			}
+			ids = append(ids, level...)
+		}
+
+		before, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, "UPDATE ToDo SET `ProjectID`=?, `Version`=`Version`+1 WHERE `ID` IN ("+placeholders(len(ids))+")",
+			append([]interface{}{nullableID(req.ProjectId)}, ids...)...)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+		}
+		if rows, err = res.RowsAffected(); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		}
+		after, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids, before, after); err != nil {
+			return err
+		}
+		if err := recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_UPDATED, "`ID` IN ("+placeholders(len(ids))+")", ids...); err != nil {
+			return err
+		}
+		etag = after[req.Id].GetEtag()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.MoveTaskResponse{
+		Api:   apiVersion,
+		Moved: rows,
+		Etag:  etag,
+	}, nil
+}
@@ -0,0 +1,890 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+func newProjectRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "Name", "Description", "ArchivedAt", "CreatedAt", "OwnerID"})
+}
+
+func Test_toDoServiceServer_CreateProject(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.CreateProjectRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.Project
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateProjectRequest{
+					Api:     "v1",
+					Project: &v1.Project{Name: " Release ", Description: "tasks of the release"},
+				},
+			},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO Project\\(`Name`, `Description`, `CreatedAt`, `OwnerID`\\) VALUES\\(\\?,\\?,\\?,\\?\\)").
+					WithArgs("Release", "tasks of the release", sqlmock.AnyArg(), "").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+			},
+			want: &v1.Project{
+				Id:          2,
+				Name:        "Release",
+				Description: "tasks of the release",
+			},
+		},
+		{
+			name: "Owned by caller",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.CreateProjectRequest{
+					Api:     "v1",
+					Project: &v1.Project{Name: "Release", OwnerId: "bob"},
+				},
+			},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO Project").
+					WithArgs("Release", "", sqlmock.AnyArg(), "alice").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+			},
+			want: &v1.Project{
+				Id:      2,
+				Name:    "Release",
+				OwnerId: "alice",
+			},
+		},
+		{
+			name: "Empty name",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateProjectRequest{
+					Api:     "v1",
+					Project: &v1.Project{Name: " "},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateProjectRequest{
+					Api:     "v1000",
+					Project: &v1.Project{Name: "Release"},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.CreateProject(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.CreateProject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				got.Project.CreatedAt = nil
+				if !reflect.DeepEqual(got.Project, tt.want) {
+					t.Errorf("toDoServiceServer.CreateProject() = %v, want %v", got.Project, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_ReadProject(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ReadProjectRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ReadProjectResponse
+		wantErr bool
+	}{
+		{
+			name: "Archived",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadProjectRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\?$").WithArgs(2).
+					WillReturnRows(newProjectRows().AddRow(2, "Release", "", tm, tm, ""))
+			},
+			want: &v1.ReadProjectResponse{
+				Api: "v1",
+				Project: &v1.Project{
+					Id:         2,
+					Name:       "Release",
+					ArchivedAt: ts,
+					CreatedAt:  ts,
+				},
+			},
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadProjectRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project").WithArgs(2).
+					WillReturnRows(newProjectRows())
+			},
+			wantErr: true,
+		},
+		{
+			name: "Project of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.ReadProjectRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? AND `OwnerID`=\\?$").WithArgs(2, "bob").
+					WillReturnRows(newProjectRows())
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ReadProject(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ReadProject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ReadProject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_ListProjects(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+	nextPageToken := encodePageToken(pageToken{Query: queryHash("false"), Values: []string{"1"}})
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListProjectsRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListProjectsResponse
+		wantErr bool
+	}{
+		{
+			name: "Next page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListProjectsRequest{
+					Api:      "v1",
+					PageSize: 1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ArchivedAt` IS NULL ORDER BY `ID` LIMIT \\?").WithArgs(2).
+					WillReturnRows(newProjectRows().
+						AddRow(1, "Home", "", nil, tm, "").
+						AddRow(3, "Release", "", nil, tm, ""))
+			},
+			want: &v1.ListProjectsResponse{
+				Api:           "v1",
+				Projects:      []*v1.Project{{Id: 1, Name: "Home", CreatedAt: ts}},
+				NextPageToken: nextPageToken,
+			},
+		},
+		{
+			name: "Last page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListProjectsRequest{
+					Api:       "v1",
+					PageSize:  1,
+					PageToken: nextPageToken,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ArchivedAt` IS NULL AND `ID`>\\? ORDER BY `ID` LIMIT \\?").WithArgs(1, 2).
+					WillReturnRows(newProjectRows().AddRow(3, "Release", "", nil, tm, ""))
+			},
+			want: &v1.ListProjectsResponse{
+				Api:      "v1",
+				Projects: []*v1.Project{{Id: 3, Name: "Release", CreatedAt: ts}},
+			},
+		},
+		{
+			name: "Show archived",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListProjectsRequest{
+					Api:          "v1",
+					ShowArchived: true,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project ORDER BY `ID` LIMIT \\?").WithArgs(defaultPageSize + 1).
+					WillReturnRows(newProjectRows().AddRow(2, "Old", "", tm, tm, ""))
+			},
+			want: &v1.ListProjectsResponse{
+				Api:      "v1",
+				Projects: []*v1.Project{{Id: 2, Name: "Old", ArchivedAt: ts, CreatedAt: ts}},
+			},
+		},
+		{
+			name: "Own projects",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.ListProjectsRequest{
+					Api: "v1",
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `OwnerID`=\\? AND `ArchivedAt` IS NULL ORDER BY `ID` LIMIT \\?").
+					WithArgs("alice", defaultPageSize+1).
+					WillReturnRows(newProjectRows().AddRow(1, "Home", "", nil, tm, "alice"))
+			},
+			want: &v1.ListProjectsResponse{
+				Api:      "v1",
+				Projects: []*v1.Project{{Id: 1, Name: "Home", CreatedAt: ts, OwnerId: "alice"}},
+			},
+		},
+		{
+			name: "Page token of a different query",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListProjectsRequest{
+					Api:          "v1",
+					ShowArchived: true,
+					PageToken:    nextPageToken,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListProjects(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ListProjects() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ListProjects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_UpdateProject(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.UpdateProjectRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.UpdateProjectResponse
+		wantErr bool
+	}{
+		{
+			name: "Name",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateProjectRequest{
+					Api:        "v1",
+					Project:    &v1.Project{Id: 2, Name: "Release 2"},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? FOR UPDATE").WithArgs(2).
+					WillReturnRows(newProjectRows().AddRow(2, "Release", "tasks of the release", nil, tm, ""))
+				mock.ExpectExec("UPDATE Project SET `Name`=\\?, `Description`=\\? WHERE `ID`=\\?").
+					WithArgs("Release 2", "tasks of the release", 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.UpdateProjectResponse{
+				Api: "v1",
+				Project: &v1.Project{
+					Id:          2,
+					Name:        "Release 2",
+					Description: "tasks of the release",
+					CreatedAt:   ts,
+				},
+			},
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateProjectRequest{
+					Api:     "v1",
+					Project: &v1.Project{Id: 2, Name: "Release 2"},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project").WithArgs(2).
+					WillReturnRows(newProjectRows())
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Project of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.UpdateProjectRequest{
+					Api:     "v1",
+					Project: &v1.Project{Id: 2, Name: "Release 2"},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? AND `OwnerID`=\\? FOR UPDATE").WithArgs(2, "bob").
+					WillReturnRows(newProjectRows())
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown field",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateProjectRequest{
+					Api:        "v1",
+					Project:    &v1.Project{Id: 2, Name: "Release 2"},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"archived_at"}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.UpdateProject(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.UpdateProject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.UpdateProject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_DeleteProject(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+
+	type args struct {
+		ctx context.Context
+		req *v1.DeleteProjectRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.DeleteProjectResponse
+		wantErr bool
+	}{
+		{
+			name: "Archive",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteProjectRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? FOR UPDATE").WithArgs(2).
+					WillReturnRows(newProjectRows().AddRow(2, "Release", "", nil, tm, ""))
+				mock.ExpectExec("UPDATE Project SET `ArchivedAt`=\\? WHERE `ID`=\\?").WithArgs(sqlmock.AnyArg(), 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteProjectResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Already archived",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteProjectRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? FOR UPDATE").WithArgs(2).
+					WillReturnRows(newProjectRows().AddRow(2, "Release", "", tm, tm, ""))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cascade",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteProjectRequest{
+					Api:  "v1",
+					Id:   2,
+					Mode: v1.ProjectDeleteMode_PROJECT_DELETE_MODE_CASCADE,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? FOR UPDATE").WithArgs(2).
+					WillReturnRows(newProjectRows().AddRow(2, "Release", "", tm, tm, ""))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ProjectID`=\\? AND `DeletedAt` IS NULL FOR UPDATE").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1).AddRow(3))
+				expectSnapshot(mock, 1, 1, 3)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`=\\?, `ProjectID`=NULL, `Version`=`Version`\\+1 WHERE `ID` IN \\(\\?,\\?\\)").
+					WithArgs(sqlmock.AnyArg(), 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectSnapshot(mock, 2, 1, 3)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_DELETE, 1, 3)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(3, 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM Project WHERE `ID`=\\?").WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteProjectResponse{
+				Api:          "v1",
+				Deleted:      1,
+				DeletedToDos: 2,
+			},
+		},
+		{
+			name: "Cascade without tasks",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteProjectRequest{
+					Api:  "v1",
+					Id:   2,
+					Mode: v1.ProjectDeleteMode_PROJECT_DELETE_MODE_CASCADE,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? FOR UPDATE").WithArgs(2).
+					WillReturnRows(newProjectRows().AddRow(2, "Release", "", nil, tm, ""))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ProjectID`=\\?").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectExec("DELETE FROM Project WHERE `ID`=\\?").WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteProjectResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Cascade with tasks of other owners",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.DeleteProjectRequest{
+					Api:  "v1",
+					Id:   2,
+					Mode: v1.ProjectDeleteMode_PROJECT_DELETE_MODE_CASCADE,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? AND `OwnerID`=\\? FOR UPDATE").WithArgs(2, "alice").
+					WillReturnRows(newProjectRows().AddRow(2, "Release", "", nil, tm, "alice"))
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ProjectID`=\\? AND `DeletedAt` IS NULL AND `OwnerID`<>\\?").WithArgs(2, "alice").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Project of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.DeleteProjectRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project WHERE `ID`=\\? AND `OwnerID`=\\? FOR UPDATE").WithArgs(2, "bob").
+					WillReturnRows(newProjectRows())
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteProjectRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM Project").WithArgs(2).
+					WillReturnRows(newProjectRows())
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid mode",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteProjectRequest{
+					Api:  "v1",
+					Id:   2,
+					Mode: 7,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.DeleteProject(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.DeleteProject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.DeleteProject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_MoveTask(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+
+	type args struct {
+		ctx context.Context
+		req *v1.MoveTaskRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.MoveTaskResponse
+		wantErr bool
+	}{
+		{
+			name: "Task with subtasks",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.MoveTaskRequest{
+					Api:       "v1",
+					Id:        1,
+					ProjectId: 2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL FOR UPDATE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(nil))
+				mock.ExpectQuery("SELECT `ArchivedAt` FROM Project WHERE `ID`=\\? LOCK IN SHARE MODE").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ArchivedAt"}).AddRow(nil))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN \\(\\?\\) AND TRUE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(3))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN \\(\\?\\) AND TRUE").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1, 3)
+				mock.ExpectExec("UPDATE ToDo SET `ProjectID`=\\?, `Version`=`Version`\\+1 WHERE `ID` IN \\(\\?,\\?\\)").WithArgs(2, 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectSnapshot(mock, 2, 1, 3)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1, 3)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			want: &v1.MoveTaskResponse{
+				Api:   "v1",
+				Moved: 2,
+				Etag:  "2",
+			},
+		},
+		{
+			name: "Out of projects",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.MoveTaskRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(nil))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `ProjectID`=\\?").WithArgs(nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.MoveTaskResponse{
+				Api:   "v1",
+				Moved: 1,
+				Etag:  "2",
+			},
+		},
+		{
+			name: "Subtask",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.MoveTaskRequest{
+					Api:       "v1",
+					Id:        3,
+					ProjectId: 2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Archived project",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.MoveTaskRequest{
+					Api:       "v1",
+					Id:        1,
+					ProjectId: 2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(nil))
+				mock.ExpectQuery("SELECT `ArchivedAt` FROM Project").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ArchivedAt"}).AddRow(tm))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Project of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.MoveTaskRequest{
+					Api:       "v1",
+					Id:        1,
+					ProjectId: 2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "alice", "alice", 0)
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(nil))
+				mock.ExpectQuery("SELECT `ArchivedAt` FROM Project WHERE `ID`=\\? AND `OwnerID`=\\? LOCK IN SHARE MODE").WithArgs(2, "alice").
+					WillReturnRows(sqlmock.NewRows([]string{"ArchivedAt"}))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Task shared with editor access",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.MoveTaskRequest{
+					Api:       "v1",
+					Id:        1,
+					ProjectId: 2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_EDITOR)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.MoveTaskRequest{
+					Api:       "v1",
+					Id:        1,
+					ProjectId: 2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.MoveTask(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.MoveTask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.MoveTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// loadLocation returns the time zone recurrence of a task is evaluated in, UTC if name is empty
+func loadLocation(name string) (*time.Location, error) {
+	if len(name) == 0 {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "time_zone field has unknown value-> "+err.Error())
+	}
+	return loc, nil
+}
+
+// checkRecurrence validates recurrence and time zone of a task
+func checkRecurrence(recurrence, timeZone string) error {
+	if len(recurrence) > 0 {
+		if _, err := parseRecurrence(recurrence); err != nil {
+			return status.Error(codes.InvalidArgument, "recurrence field has invalid format-> "+err.Error())
+		}
+	}
+	_, err := loadLocation(timeZone)
+	return err
+}
+
+// recurrenceOf returns the rule, time zone and series start of a recurring task
+func recurrenceOf(ctx context.Context, q queryer, td *v1.ToDo) (*recurrenceRule, time.Time, error) {
+	rule, err := parseRecurrence(td.Recurrence)
+	if err != nil {
+		return nil, time.Time{}, status.Error(codes.Unknown, "recurrence field has invalid format-> "+err.Error())
+	}
+	loc, err := loadLocation(td.TimeZone)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	// the series starts at the reminder of the task the recurrence was set on,
+	// so that COUNT is not restarted by every occurrence
+	var start sql.NullTime
+	if err := q.QueryRowContext(ctx, "SELECT `RecurrenceStart` FROM ToDo WHERE `ID`=?", td.Id).Scan(&start); err != nil {
+		return nil, time.Time{}, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	if !start.Valid {
+		if start.Time, err = ptypes.Timestamp(td.Reminder); err != nil {
+			return nil, time.Time{}, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+		}
+	}
+	return rule, start.Time.In(loc), nil
+}
+
+// createNextOccurrence creates the task repeating td at its next reminder by the recurrence rule.
+// Due time keeps its distance to the reminder. It returns nil if the recurrence has ended.
+func createNextOccurrence(ctx context.Context, q queryer, td *v1.ToDo) (*v1.ToDo, error) {
+	rule, start, err := recurrenceOf(ctx, q, td)
+	if err != nil {
+		return nil, err
+	}
+	reminder, err := ptypes.Timestamp(td.Reminder)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+	}
+	at, ok := rule.next(start, reminder)
+	if !ok {
+		return nil, nil
+	}
+
+	next := &v1.ToDo{
+		Title:       td.Title,
+		Description: td.Description,
+		Priority:    td.Priority,
+		ParentId:    td.ParentId,
+		ProjectId:   td.ProjectId,
+		Recurrence:  td.Recurrence,
+		TimeZone:    td.TimeZone,
+		Tags:        td.Tags,
+		Etag:        formatEtag(firstVersion),
+		OwnerId:     td.OwnerId,
+	}
+	at = at.UTC()
+	if next.Reminder, err = ptypes.TimestampProto(at); err != nil {
+		return nil, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+	}
+	var due interface{}
+	if td.Due != nil {
+		d, err := ptypes.Timestamp(td.Due)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "due field has invalid format-> "+err.Error())
+		}
+		d = d.Add(at.Sub(reminder))
+		if next.Due, err = ptypes.TimestampProto(d); err != nil {
+			return nil, status.Error(codes.Unknown, "due field has invalid format-> "+err.Error())
+		}
+		due = d
+	}
+
+	// the next occurrence takes the place of the completed task in the custom order
+	neighbour, err := neighbourPosition(ctx, q, td.Position, td.Id, true)
+	if err != nil {
+		return nil, err
+	}
+	if next.Position, err = newPosition(td.Position, neighbour); err != nil {
+		return nil, err
+	}
+
+	res, err := q.ExecContext(ctx, "INSERT INTO ToDo(`Title`, `Description`, `Reminder`, `Due`, `Priority`, `ParentID`, `ProjectID`, `Recurrence`, `TimeZone`, `RecurrenceStart`, `Position`, `OwnerID`) VALUES(?,?,?,?,?,?,?,?,?,?,?,?)",
+		next.Title, next.Description, at, due, int32(next.Priority), nullableID(next.ParentId), nullableID(next.ProjectId), next.Recurrence, next.TimeZone, start.UTC(), next.Position,
+		next.OwnerId)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into ToDO-> "+err.Error())
+	}
+	if next.Id, err = res.LastInsertId(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve id for created ToDo -> "+err.Error())
+	}
+	if err := addTags(ctx, q, next.Id, next.Tags); err != nil {
+		return nil, err
+	}
+
+	ids := []interface{}{next.Id}
+	after, err := snapshotToDos(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordHistory(ctx, q, v1.HistoryAction_HISTORY_ACTION_CREATE, ids, nil, after); err != nil {
+		return nil, err
+	}
+	if err := recordEvents(ctx, q, v1.EventType_EVENT_TYPE_CREATED, "`ID`=?", next.Id); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// ListOccurrences returns reminder times of a recurring task in a time window
+func (s *toDoServiceServer) ListOccurrences(ctx context.Context, req *v1.ListOccurrencesRequest) (*v1.ListOccurrencesResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	from, err := ptypes.Timestamp(req.StartTime)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "start_time field has invalid format->"+err.Error())
+	}
+	to, err := ptypes.Timestamp(req.EndTime)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "end_time field has invalid format->"+err.Error())
+	}
+	if !to.After(from) {
+		return nil, status.Error(codes.InvalidArgument, "end_time must be after start_time")
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// occurrences are ordered by time, the page token holds the last one returned
+	query := queryHash(strconv.FormatInt(req.Id, 10), formatTimestamp(req.StartTime), formatTimestamp(req.EndTime))
+	if len(req.PageToken) > 0 {
+		token, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if token.Query != query || len(token.Values) != 1 {
+			return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different task or time window")
+		}
+		last, err := time.Parse(time.RFC3339Nano, token.Values[0])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "page_token has invalid format")
+		}
+		from = last.Add(time.Nanosecond)
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.Id, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+		return nil, err
+	}
+	td, err := readToDo(ctx, c, req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(td.Recurrence) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("ToDo with ID='%d' does not repeat", req.Id))
+	}
+
+	rule, start, err := recurrenceOf(ctx, c, td)
+	if err != nil {
+		return nil, err
+	}
+
+	list := rule.between(start, from, to, size+1)
+	var nextPageToken string
+	if len(list) > size {
+		list = list[:size]
+		nextPageToken = encodePageToken(pageToken{Query: query, Values: []string{list[size-1].UTC().Format(time.RFC3339Nano)}})
+	}
+
+	occurrences := make([]*timestamp.Timestamp, 0, len(list))
+	for _, t := range list {
+		ts, err := ptypes.TimestampProto(t)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, "occurrence has invalid format-> "+err.Error())
+		}
+		occurrences = append(occurrences, ts)
+	}
+
+	return &v1.ListOccurrencesResponse{
+		Api:           apiVersion,
+		Occurrences:   occurrences,
+		NextPageToken: nextPageToken,
+	}, nil
+}
@@ -0,0 +1,193 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_toDoServiceServer_ListOccurrences(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	// invoices are due at 9:00 in New York on the first of every month
+	invoice := time.Date(2020, time.January, 1, 14, 0, 0, 0, time.UTC)
+	ts := func(tm time.Time) *timestamp.Timestamp {
+		p, _ := ptypes.TimestampProto(tm)
+		return p
+	}
+	windowStart := ts(time.Date(2020, time.February, 15, 0, 0, 0, 0, time.UTC))
+	windowEnd := ts(time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC))
+	query := queryHash("1", formatTimestamp(windowStart), formatTimestamp(windowEnd))
+
+	expectInvoice := func(reminder time.Time, recurrence string) {
+		mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=").WithArgs(1).
+			WillReturnRows(newToDoRows().AddRow(1, "send invoice", "", reminder, false, nil, nil, 0, nil, nil, recurrence, "America/New_York", nil, 1, "", ""))
+		mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+	}
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListOccurrencesRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListOccurrencesResponse
+		wantErr bool
+	}{
+		{
+			name: "First page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListOccurrencesRequest{
+					Api:       "v1",
+					Id:        1,
+					StartTime: windowStart,
+					EndTime:   windowEnd,
+					PageSize:  2,
+				},
+			},
+			mock: func() {
+				expectInvoice(invoice, "FREQ=MONTHLY")
+				mock.ExpectQuery("SELECT `RecurrenceStart` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"RecurrenceStart"}).AddRow(invoice))
+			},
+			want: &v1.ListOccurrencesResponse{
+				Api: "v1",
+				Occurrences: []*timestamp.Timestamp{
+					ts(time.Date(2020, time.March, 1, 14, 0, 0, 0, time.UTC)),
+					ts(time.Date(2020, time.April, 1, 13, 0, 0, 0, time.UTC)),
+				},
+				NextPageToken: encodePageToken(pageToken{Query: query, Values: []string{"2020-04-01T13:00:00Z"}}),
+			},
+		},
+		{
+			name: "Last page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListOccurrencesRequest{
+					Api:       "v1",
+					Id:        1,
+					StartTime: windowStart,
+					EndTime:   windowEnd,
+					PageSize:  2,
+					PageToken: encodePageToken(pageToken{Query: query, Values: []string{"2020-04-01T13:00:00Z"}}),
+				},
+			},
+			mock: func() {
+				expectInvoice(invoice, "FREQ=MONTHLY")
+				mock.ExpectQuery("SELECT `RecurrenceStart` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"RecurrenceStart"}).AddRow(invoice))
+			},
+			want: &v1.ListOccurrencesResponse{
+				Api: "v1",
+				Occurrences: []*timestamp.Timestamp{
+					ts(time.Date(2020, time.May, 1, 13, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "Count is kept across occurrences",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListOccurrencesRequest{
+					Api:       "v1",
+					Id:        1,
+					StartTime: windowStart,
+					EndTime:   windowEnd,
+				},
+			},
+			mock: func() {
+				// the task is the second occurrence of a series of three
+				expectInvoice(invoice.AddDate(0, 1, 0), "FREQ=MONTHLY;COUNT=3")
+				mock.ExpectQuery("SELECT `RecurrenceStart` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"RecurrenceStart"}).AddRow(invoice))
+			},
+			want: &v1.ListOccurrencesResponse{
+				Api: "v1",
+				Occurrences: []*timestamp.Timestamp{
+					ts(time.Date(2020, time.March, 1, 14, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "Not recurring",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListOccurrencesRequest{
+					Api:       "v1",
+					Id:        1,
+					StartTime: windowStart,
+					EndTime:   windowEnd,
+				},
+			},
+			mock: func() {
+				expectInvoice(invoice, "")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty window",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListOccurrencesRequest{
+					Api:       "v1",
+					Id:        1,
+					StartTime: windowEnd,
+					EndTime:   windowStart,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Page token for different window",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListOccurrencesRequest{
+					Api:       "v1",
+					Id:        1,
+					StartTime: windowStart,
+					EndTime:   windowEnd,
+					PageToken: encodePageToken(pageToken{Query: queryHash("2"), Values: []string{"2020-04-01T13:00:00Z"}}),
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListOccurrences(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ListOccurrences() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ListOccurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,31 +5,60 @@ import (
 	"fmt"
 	"context"
 	"database/sql"
+	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/blob"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/search"
 )
 
 const (
 	// apiVersion is the version of API provided by server
 	apiVersion = "v1";
+
+	// snippetWidth is the length of search result snippets in characters
+	snippetWidth = 160
+
+	// toDoColumns are the ToDo table columns read by scanToDo
+	toDoColumns = "`ID`, `Title`, `Description`, `Reminder`, `Completed`, `CompletedAt`, `Due`, `Priority`, `ParentID`, `ProjectID`, `Recurrence`, `TimeZone`, `DeletedAt`, `Version`, `Position`, `OwnerID`"
 )
 
+// dbService is the base of services storing data in the database
+type dbService struct {
+	db *sql.DB
+}
+
 // toDoServiceServer is the implementation of v1.ToDoServiceServer proto interface
 type toDoServiceServer struct {
-	db *sql.DB
+	dbService
+	search search.Index
+
+	// blobs stores contents of attachments, nil if attachments are not configured
+	blobs             blob.Store
+	maxAttachmentSize int64
+	attachmentTypes   []string
+
+	// admins are the subjects allowed to reach tasks of all owners
+	admins map[string]bool
 }
 
 // NewToDoServiceServer creates ToDo Service
-func NewToDoServiceServer(db *sql.DB) v1.ToDoServiceServer {
-	return &toDoServiceServer{db: db}
+func NewToDoServiceServer(db *sql.DB, opts ...Option) v1.ToDoServiceServer {
+	s := &toDoServiceServer{dbService: dbService{db: db}, search: search.NewMySQLIndex(db)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // checkAPI checks if the API version requested by client is supported by server
-func (s *toDoServiceServer) checkAPI(api string) error {
+func (s *dbService) checkAPI(api string) error {
 	// If API version is blank ("") then use current version of the service
 	if len(api) > 0 {
 		if apiVersion != api {
@@ -40,7 +69,7 @@ func (s *toDoServiceServer) checkAPI(api string) error {
 }
 
 // connect returns a databse connection from pool
-func (s *toDoServiceServer) connect(ctx context.Context) (*sql.Conn, error) {
+func (s *dbService) connect(ctx context.Context) (*sql.Conn, error) {
 	c, err := s.db.Conn(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unknown, "failed to connect to database-> "+err.Error())
@@ -48,6 +77,161 @@ func (s *toDoServiceServer) connect(ctx context.Context) (*sql.Conn, error) {
 	return c, nil
 }
 
+// scanToDo reads a task from the current row of a query selecting toDoColumns
+func scanToDo(rows *sql.Rows) (*v1.ToDo, error) {
+	var td v1.ToDo
+	var reminder time.Time
+	var completedAt, due, deletedAt sql.NullTime
+	var priority int32
+	var parent, project sql.NullInt64
+	var version int64
+	if err := rows.Scan(&td.Id, &td.Title, &td.Description, &reminder, &td.Completed, &completedAt, &due, &priority, &parent, &project, &td.Recurrence, &td.TimeZone, &deletedAt, &version, &td.Position, &td.OwnerId); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+	}
+	var err error
+	if td.Reminder, err = ptypes.TimestampProto(reminder); err != nil {
+		return nil, status.Error(codes.Unknown, "reminder field has invalid format-> "+err.Error())
+	}
+	if completedAt.Valid {
+		if td.CompletedAt, err = ptypes.TimestampProto(completedAt.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "completed_at field has invalid format-> "+err.Error())
+		}
+	}
+	if due.Valid {
+		if td.Due, err = ptypes.TimestampProto(due.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "due field has invalid format-> "+err.Error())
+		}
+	}
+	if deletedAt.Valid {
+		if td.DeletedAt, err = ptypes.TimestampProto(deletedAt.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "deleted_at field has invalid format-> "+err.Error())
+		}
+	}
+	td.Priority = v1.Priority(priority)
+	td.ParentId = parent.Int64
+	td.ProjectId = project.Int64
+	td.Etag = formatEtag(version)
+	return &td, nil
+}
+
+// inTx runs fn in a database transaction, committing it if fn succeeds
+func inTx(ctx context.Context, c *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to begin transaction-> "+err.Error())
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return status.Error(codes.Unknown, "failed to commit transaction-> "+err.Error())
+	}
+	return nil
+}
+
+// checkToDoExists returns NotFound error if there is no task with the ID out of trash
+func checkToDoExists(ctx context.Context, q queryer, id int64) error {
+	var count int64
+	if err := q.QueryRowContext(ctx, "SELECT COUNT(*) FROM ToDo WHERE `ID`=? AND `DeletedAt` IS NULL", id).Scan(&count); err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	if count == 0 {
+		return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", id))
+	}
+	return nil
+}
+
+// readToDo selects a task by ID with its tags, a task in trash is not found unless showDeleted is set
+func readToDo(ctx context.Context, q queryer, id int64, showDeleted bool) (*v1.ToDo, error) {
+	query := "SELECT " + toDoColumns + " FROM ToDo WHERE `ID`=?"
+	if !showDeleted {
+		query += " AND `DeletedAt` IS NULL"
+	}
+	rows, err := q.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo -> "+err.Error())
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo->"+err.Error())
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", id))
+	}
+
+	// Retrieve ToDo Data
+	td, err := scanToDo(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if rows.Next() {
+		return nil, status.Error(codes.Unknown, fmt.Sprintf("found multiple ToDo rows with ID='%d'", id))
+	}
+	rows.Close()
+
+	if err := loadTags(ctx, q, []*v1.ToDo{td}); err != nil {
+		return nil, err
+	}
+	return td, nil
+}
+
+// readPage selects a page of tasks matching conds in keys order with their tags.
+// The page starts after the task token points to, query is the hash of the
+// listing parameters the token must have been issued for.
+// It returns the token of the next page, empty if this is the last page.
+func readPage(ctx context.Context, q queryer, conds []condition, keys []orderKey, size int, query uint32, token string) ([]*v1.ToDo, string, error) {
+	where := append([]condition(nil), conds...)
+	if len(token) > 0 {
+		last, err := decodePageToken(token)
+		if err != nil {
+			return nil, "", err
+		}
+		if last.Query != query {
+			return nil, "", status.Error(codes.InvalidArgument, "page_token was issued for a different filter or order_by")
+		}
+		after, err := keysetCondition(keys, last.Values)
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, after)
+	}
+
+	// get a page of todos, one extra row tells if there is a next page
+	sqlWhere, args := whereSQL(where)
+	rows, err := q.QueryContext(ctx, "SELECT "+toDoColumns+" FROM ToDo"+sqlWhere+" ORDER BY "+orderBySQL(keys)+" LIMIT ?", append(args, size+1)...)
+	if err != nil {
+		return nil, "", status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.ToDo{}
+	for rows.Next() {
+		td, err := scanToDo(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		list = append(list, td)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	rows.Close()
+
+	var nextPageToken string
+	if len(list) > size {
+		list = list[:size]
+		nextPageToken = encodePageToken(pageToken{Query: query, Values: keysetValues(keys, list[size-1])})
+	}
+
+	if err := loadTags(ctx, q, list); err != nil {
+		return nil, "", err
+	}
+	return list, nextPageToken, nil
+}
+
 // Create a new task
 func (s *toDoServiceServer) Create(ctx context.Context, req *v1.CreateRequest) (*v1.CreateResponse, error) {
 	// Validate requested API version is supported by server
@@ -62,21 +246,23 @@ func (s *toDoServiceServer) Create(ctx context.Context, req *v1.CreateRequest) (
 	}
 	defer c.Close()
 
-	reminder, err := ptypes.Timestamp(req.ToDo.Reminder)
+	ins, err := newToDoInsert(req.ToDo)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "reminder field has invalid format->"+err.Error())
+		return nil, err
 	}
 
-	// insert ToDo entity data
-	res, err := c.ExecContext(ctx, "INSERT INTO ToDo(`Title`, `Description`, `Reminder`) VALUES(?,?,?)", req.ToDo.Title, req.ToDo.Description, reminder)
+	var id int64
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		id, err = ins.exec(ctx, tx)
+		return err
+	})
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to insert into ToDO-> "+err.Error())
+		return nil, err
 	}
 
-	// get ID of created Task
-	id, err := res.LastInsertId()
-	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve id for created ToDo -> "+err.Error())
+	// update search index
+	if err := s.search.Put(ctx, search.Document{ID: id, Title: req.ToDo.Title, Description: req.ToDo.Description, OwnerID: callerOwner(ctx)}); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to index ToDo-> "+err.Error())
 	}
 
 	return &v1.CreateResponse{
@@ -85,6 +271,94 @@ func (s *toDoServiceServer) Create(ctx context.Context, req *v1.CreateRequest) (
 	}, nil
 }
 
+// toDoInsert is a validated task to create
+type toDoInsert struct {
+	toDo     *v1.ToDo
+	reminder time.Time
+	due      interface{}
+	tags     []string
+}
+
+// newToDoInsert validates a task to create
+func newToDoInsert(td *v1.ToDo) (*toDoInsert, error) {
+	if td == nil {
+		return nil, status.Error(codes.InvalidArgument, "toDo field is required")
+	}
+
+	reminder, err := ptypes.Timestamp(td.Reminder)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "reminder field has invalid format->"+err.Error())
+	}
+
+	due, err := nullableTime(td.Due, "due")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPriority(td.Priority); err != nil {
+		return nil, err
+	}
+
+	if err := checkRecurrence(td.Recurrence, td.TimeZone); err != nil {
+		return nil, err
+	}
+
+	tags, err := normalizeTags(td.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &toDoInsert{toDo: td, reminder: reminder, due: due, tags: tags}, nil
+}
+
+// exec inserts the task with its tags and returns its ID
+func (ins *toDoInsert) exec(ctx context.Context, tx *sql.Tx) (int64, error) {
+	td := ins.toDo
+	if err := callerScope(ctx).requireParent(ctx, tx, td.ParentId); err != nil {
+		return 0, err
+	}
+	if err := checkParent(ctx, tx, 0, td.ParentId); err != nil {
+		return 0, err
+	}
+	project, err := taskProject(ctx, tx, callerScope(ctx), td.ParentId, td.ProjectId)
+	if err != nil {
+		return 0, err
+	}
+	// the end of the order stays locked until commit, see lastPosition
+	position, err := lastPosition(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	// insert ToDo entity data, a recurrence series starts at the first reminder, the task belongs to the caller
+	res, err := tx.ExecContext(ctx, "INSERT INTO ToDo(`Title`, `Description`, `Reminder`, `Due`, `Priority`, `ParentID`, `ProjectID`, `Recurrence`, `TimeZone`, `RecurrenceStart`, `Position`, `OwnerID`) VALUES(?,?,?,?,?,?,?,?,?,?,?,?)",
+		td.Title, td.Description, ins.reminder, ins.due, int32(td.Priority), nullableID(td.ParentId), nullableID(project),
+		td.Recurrence, td.TimeZone, ins.reminder, position, callerOwner(ctx))
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to insert into ToDO-> "+err.Error())
+	}
+
+	// get ID of created Task
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to retrieve id for created ToDo -> "+err.Error())
+	}
+
+	if err := addTags(ctx, tx, id, ins.tags); err != nil {
+		return 0, err
+	}
+
+	ids := []interface{}{id}
+	after, err := snapshotToDos(ctx, tx, ids)
+	if err != nil {
+		return 0, err
+	}
+	if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_CREATE, ids, nil, after); err != nil {
+		return 0, err
+	}
+	return id, recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_CREATED, "`ID`=?", id)
+}
+
 // Read a task
 func (s *toDoServiceServer) Read(ctx context.Context, req *v1.ReadRequest) (*v1.ReadResponse, error) {
 	// Validate requested API version is supported by server
@@ -92,45 +366,42 @@ func (s *toDoServiceServer) Read(ctx context.Context, req *v1.ReadRequest) (*v1.
 		return nil, err
 	}
 
-	// get database connection
-	c, err := s.connect(ctx)
+	if req.Depth < 0 || req.Depth > maxTreeDepth {
+		return nil, status.Errorf(codes.InvalidArgument, "depth must be between 0 and %d, got %d", maxTreeDepth, req.Depth)
+	}
+
+	scope, err := s.requestScope(ctx, req.AllOwners)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
 
-	// Retrieve Todo by ID
-	rows, err := c.QueryContext(ctx, "SELECT `ID`, `Title`, `Description`, `Reminder` FROM ToDo WHERE `ID`=?", req.Id)
+	// get database connection
+	c, err := s.connect(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to select from ToDo -> "+err.Error())
+		return nil, err
 	}
-	defer rows.Close()
+	defer c.Close()
 
-	if !rows.Next() {
-		if err := rows.Err(); err != nil {
-			return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo->"+err.Error())
-		}
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.Id))
+	if err := scope.require(ctx, c, req.Id, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+		return nil, err
 	}
 
-	// Retrieve ToDo Data
-	var td v1.ToDo
-	var reminder time.Time
-	if err := rows.Scan(&td.Id, &td.Title, &td.Description, &reminder); err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+ err.Error())
-	}
-	td.Reminder, err = ptypes.TimestampProto(reminder)
+	// Retrieve Todo by ID
+	td, err := readToDo(ctx, c, req.Id, req.ShowDeleted)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "reminder field has invalid format->"+err.Error())
+		return nil, err
+	}
+	if err := loadAccess(ctx, c, scope, []*v1.ToDo{td}); err != nil {
+		return nil, err
 	}
 
-	if rows.Next() {
-		return nil, status.Error(codes.Unknown, fmt.Sprintf("found multiple ToDo rows with ID='%d'", req.Id))
+	if err := readSubtree(ctx, c, scope, td, int(req.Depth), req.ShowDeleted); err != nil {
+		return nil, err
 	}
 
 	return &v1.ReadResponse {
 		Api: apiVersion,
-		ToDo: &td,
+		ToDo: td,
 	}, nil
 }
 
@@ -141,6 +412,11 @@ func (s *toDoServiceServer) Update(ctx context.Context, req *v1.UpdateRequest) (
 		return nil, err
 	}
 
+	scope, err := s.requestScope(ctx, req.AllOwners)
+	if err != nil {
+		return nil, err
+	}
+
 	// get database connection
 	c, err := s.connect(ctx)
 	if err != nil {
@@ -148,39 +424,145 @@ func (s *toDoServiceServer) Update(ctx context.Context, req *v1.UpdateRequest) (
 	}
 	defer c.Close()
 
-	reminder, err := ptypes.Timestamp(req.ToDo.Reminder)
+	upd, err := newToDoUpdate(req.ToDo, req.UpdateMask, requestEtag(ctx, req.Etag, req.GetToDo().GetEtag()), scope)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "reminder field has invalid format->"+err.Error())
+		return nil, err
 	}
 
-	// update todo
-	res, err := c.ExecContext(ctx, "UPDATE ToDo SET `Title`=?, `Description`=?, `Reminder`=? WHERE `ID`=?", req.ToDo.Title, req.ToDo.Description, reminder, req.ToDo.Id)
+	var rows int64
+	var etag string
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		rows, etag, err = upd.exec(ctx, tx)
+		return err
+	})
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to update ToDo->"+err.Error())
+		return nil, err
+	}
+
+	if err := s.indexUpdate(ctx, c, upd); err != nil {
+		return nil, err
+	}
+
+	return &v1.UpdateResponse {
+		Api: apiVersion,
+		Updated: rows,
+		Etag: etag,
+	}, nil
+}
+
+// toDoUpdate is a validated change of the task fields listed in update mask
+type toDoUpdate struct {
+	toDo   *v1.ToDo
+	set    string
+	args   []interface{}
+	fields map[string]bool
+	etag   string
+	scope  taskScope
+	// owner of the task, set by exec
+	owner string
+}
+
+// newToDoUpdate validates a change of task fields based on etag, by a caller with scope
+func newToDoUpdate(td *v1.ToDo, mask *field_mask.FieldMask, etag string, scope taskScope) (*toDoUpdate, error) {
+	if td == nil {
+		return nil, status.Error(codes.InvalidArgument, "toDo field is required")
+	}
+
+	set, args, fields, err := updateAssignments(td, mask)
+	if err != nil {
+		return nil, err
+	}
+	return &toDoUpdate{toDo: td, set: set, args: args, fields: fields, etag: etag, scope: scope}, nil
+}
+
+// exec updates the task and returns number of updated rows and new etag of the task
+func (upd *toDoUpdate) exec(ctx context.Context, tx *sql.Tx) (int64, string, error) {
+	id := upd.toDo.Id
+	if err := upd.scope.require(ctx, tx, id, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+		return 0, "", err
+	}
+	if err := checkEtag(ctx, tx, id, upd.etag); err != nil {
+		return 0, "", err
+	}
+
+	// moving the task must not create a cycle, move it to another project or under a task the caller can't edit
+	if upd.fields["parent_id"] {
+		if err := upd.scope.requireParent(ctx, tx, upd.toDo.ParentId); err != nil {
+			return 0, "", err
+		}
+		if err := checkParent(ctx, tx, id, upd.toDo.ParentId); err != nil {
+			return 0, "", err
+		}
+		if err := checkSameProject(ctx, tx, id, upd.toDo.ParentId); err != nil {
+			return 0, "", err
+		}
+	}
+
+	ids := []interface{}{id}
+	before, err := snapshotToDos(ctx, tx, ids)
+	if err != nil {
+		return 0, "", err
+	}
+
+	// update todo fields listed in update mask
+	res, err := tx.ExecContext(ctx, "UPDATE ToDo SET "+upd.set+", `Version`=`Version`+1 WHERE `ID`=? AND `DeletedAt` IS NULL", append(upd.args, id)...)
+	if err != nil {
+		return 0, "", status.Error(codes.Unknown, "failed to update ToDo->"+err.Error())
 	}
 
 	rows, err := res.RowsAffected()
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		return 0, "", status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
 	}
 
 	if rows == 0 {
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.ToDo.Id))
+		return 0, "", status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", id))
 	}
 
-	return &v1.UpdateResponse {
-		Api: apiVersion,
-		Updated: rows,
-	}, nil
+	after, err := snapshotToDos(ctx, tx, ids)
+	if err != nil {
+		return 0, "", err
+	}
+	if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids, before, after); err != nil {
+		return 0, "", err
+	}
+	upd.owner = after[id].GetOwnerId()
+
+	if err := recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_UPDATED, "`ID`=?", id); err != nil {
+		return 0, "", err
+	}
+	return rows, after[id].GetEtag(), nil
+}
+
+// indexUpdate updates search index if searchable fields changed, reading the one not in the update from database
+func (s *toDoServiceServer) indexUpdate(ctx context.Context, q queryer, upd *toDoUpdate) error {
+	if !upd.fields["title"] && !upd.fields["description"] {
+		return nil
+	}
+	doc := search.Document{ID: upd.toDo.Id, Title: upd.toDo.Title, Description: upd.toDo.Description, OwnerID: upd.owner}
+	if !upd.fields["title"] || !upd.fields["description"] {
+		if err := q.QueryRowContext(ctx, "SELECT `Title`, `Description` FROM ToDo WHERE `ID`=?", upd.toDo.Id).Scan(&doc.Title, &doc.Description); err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+	}
+	if err := s.search.Put(ctx, doc); err != nil {
+		return status.Error(codes.Unknown, "failed to index ToDo-> "+err.Error())
+	}
+	return nil
 }
 
-// Delete a task
+// Delete moves a task to trash
 func (s *toDoServiceServer) Delete(ctx context.Context, req *v1.DeleteRequest) (*v1.DeleteResponse, error) {
 	// Validate requested API version is supported by server
 	if err := s.checkAPI(req.Api); err != nil {
 		return nil, err
 	}
 
+	scope, err := s.requestScope(ctx, req.AllOwners)
+	if err != nil {
+		return nil, err
+	}
+
 	// get database connection
 	c, err := s.connect(ctx)
 	if err != nil {
@@ -188,19 +570,20 @@ func (s *toDoServiceServer) Delete(ctx context.Context, req *v1.DeleteRequest) (
 	}
 	defer c.Close()
 
-	// delete todo task
-	res, err := c.ExecContext(ctx, "DELETE FROM ToDo WHERE `ID`=?", req.Id)
-	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to delete Todo-> "+err.Error())
-	}
+	etag := requestEtag(ctx, req.Etag)
 
-	rows, err := res.RowsAffected()
+	var rows int64
+	var levels [][]interface{}
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		rows, levels, err = deleteToDo(ctx, tx, req.Id, req.Cascade, etag, scope)
+		return err
+	})
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		return nil, err
 	}
 
-	if rows == 0 {
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.Id))
+	if err := s.unindex(ctx, levels); err != nil {
+		return nil, err
 	}
 
 	return &v1.DeleteResponse {
@@ -209,6 +592,69 @@ func (s *toDoServiceServer) Delete(ctx context.Context, req *v1.DeleteRequest) (
 	}, nil
 }
 
+// deleteToDo moves a task owned by the scope to trash with its subtasks if cascade is set,
+// it returns number of deleted rows and IDs of deleted tasks by subtree level
+func deleteToDo(ctx context.Context, tx *sql.Tx, id int64, cascade bool, etag string, scope taskScope) (int64, [][]interface{}, error) {
+	if err := scope.require(ctx, tx, id, v1.AccessLevel_ACCESS_LEVEL_OWNER); err != nil {
+		return 0, nil, err
+	}
+	if err := checkEtag(ctx, tx, id, etag); err != nil {
+		return 0, nil, err
+	}
+
+	levels, err := subtreeIDs(ctx, tx, id, cascade)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// tombstone todo task with subtasks at the same time, so that Restore brings them back together
+	ids := []interface{}{time.Now().UTC()}
+	for _, level := range levels {
+		ids = append(ids, level...)
+	}
+	before, err := snapshotToDos(ctx, tx, ids[1:])
+	if err != nil {
+		return 0, nil, err
+	}
+	res, err := tx.ExecContext(ctx, "UPDATE ToDo SET `DeletedAt`=?, `Version`=`Version`+1 WHERE `ID` IN ("+placeholders(len(ids)-1)+") AND `DeletedAt` IS NULL", ids...)
+	if err != nil {
+		return 0, nil, status.Error(codes.Unknown, "failed to delete Todo-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+
+	if rows == 0 {
+		return 0, nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", id))
+	}
+
+	after, err := snapshotToDos(ctx, tx, ids[1:])
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_DELETE, ids[1:], before, after); err != nil {
+		return 0, nil, err
+	}
+
+	if err := recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_DELETED, "`ID` IN ("+placeholders(len(ids)-1)+")", ids[1:]...); err != nil {
+		return 0, nil, err
+	}
+	return rows, levels, nil
+}
+
+// unindex removes deleted tasks from search index
+func (s *toDoServiceServer) unindex(ctx context.Context, levels [][]interface{}) error {
+	for _, level := range levels {
+		for _, id := range level {
+			if err := s.search.Remove(ctx, id.(int64)); err != nil {
+				return status.Error(codes.Unknown, "failed to remove ToDo from index-> "+err.Error())
+			}
+		}
+	}
+	return nil
+}
+
 // Read all todo tasks
 func (s *toDoServiceServer) ReadAll(ctx context.Context, req *v1.ReadAllRequest) (*v1.ReadAllResponse, error) {
 	// Validate requested API version is supported by server
@@ -223,33 +669,375 @@ func (s *toDoServiceServer) ReadAll(ctx context.Context, req *v1.ReadAllRequest)
 	}
 	defer c.Close()
 
-	// get all todos as a list
-	rows, err := c.QueryContext(ctx, "SELECT `ID`, `Title`, `Description`, `Reminder` FROM ToDo")
+	size, err := pageSize(req.PageSize)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		return nil, err
 	}
-	defer rows.Close()
 
-	var reminder time.Time
-	list := []*v1.ToDo{}
-	for rows.Next(){
-		td := new (v1.ToDo)
-		if err := rows.Scan(&td.Id, &td.Title, &td.Description, &reminder); err != nil {
-			return nil, status.Error(codes.Unknown, "failed to retrieve field value for Todo row-> "+ err.Error())
-		}
-		td.Reminder, err = ptypes.TimestampProto(reminder)
+	conds, err := parseFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := parseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		cond, err := tagCondition(tags, req.TagMatch)
 		if err != nil {
-			return nil, status.Error(codes.Unknown, "reminder field has an invalid format-> "+err.Error())
+			return nil, err
 		}
-		list = append(list, td)
+		conds = append(conds, cond)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+ err.Error())
+	if !req.ShowDeleted {
+		conds = append(conds, condition{sql: "`DeletedAt` IS NULL"})
+	}
+
+	// tasks shared with the caller are listed with its own ones
+	scope, err := s.requestScope(ctx, req.AllOwners)
+	if err != nil {
+		return nil, err
+	}
+	conds = append(conds, scope.conditions(v1.AccessLevel_ACCESS_LEVEL_VIEWER)...)
+
+	// tasks of an archived project are listed, only a deleted project or the one of another owner is not found
+	if req.ProjectId != 0 {
+		if err := checkProjectExists(ctx, c, scope, req.ProjectId); err != nil {
+			return nil, err
+		}
+		conds = append(conds, condition{sql: "`ProjectID`=?", args: []interface{}{req.ProjectId}})
+	}
+
+	query := queryHash(req.Filter, req.OrderBy, strings.Join(tags, ","), req.TagMatch.String(), strconv.FormatBool(req.ShowDeleted),
+		strconv.FormatInt(req.ProjectId, 10), strconv.FormatBool(req.AllOwners))
+	list, nextPageToken, err := readPage(ctx, c, conds, keys, size, query, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadAccess(ctx, c, scope, list); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if req.IncludeTotalSize {
+		sqlWhere, args := whereSQL(conds)
+		if err := c.QueryRowContext(ctx, "SELECT COUNT(*) FROM ToDo"+sqlWhere, args...).Scan(&total); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to count ToDo rows-> "+err.Error())
+		}
 	}
 
 	return &v1.ReadAllResponse {
 		Api: apiVersion,
 		ToDos: list,
+		NextPageToken: nextPageToken,
+		TotalSize: total,
+	}, nil
+}
+
+// Complete marks a task as done
+func (s *toDoServiceServer) Complete(ctx context.Context, req *v1.CompleteRequest) (*v1.CompleteResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	var td, next *v1.ToDo
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := scope.require(ctx, tx, req.Id, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+			return err
+		}
+
+		ids := []interface{}{req.Id}
+		before, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+
+		// completing a completed task keeps its completion time
+		now := time.Now().UTC()
+		res, err := tx.ExecContext(ctx, "UPDATE ToDo SET `Completed`=TRUE, `CompletedAt`=?, `Version`=`Version`+1 WHERE `ID`=? AND NOT `Completed` AND `DeletedAt` IS NULL", now, req.Id)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+		}
+		completed, err := res.RowsAffected()
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		}
+		if completed > 0 {
+			after, err := snapshotToDos(ctx, tx, ids)
+			if err != nil {
+				return err
+			}
+			if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids, before, after); err != nil {
+				return err
+			}
+			if err := recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_UPDATED, "`ID`=?", req.Id); err != nil {
+				return err
+			}
+		}
+
+		if td, err = readToDo(ctx, tx, req.Id, false); err != nil {
+			return err
+		}
+		if err := loadAccess(ctx, tx, scope, []*v1.ToDo{td}); err != nil {
+			return err
+		}
+
+		// the next occurrence is created once, when the task becomes completed
+		if completed > 0 && len(td.Recurrence) > 0 {
+			if next, err = createNextOccurrence(ctx, tx, td); err != nil {
+				return err
+			}
+		}
+
+		if req.CompleteParent {
+			return completeParents(ctx, tx, req.Id, now)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// update search index
+	if next != nil {
+		if err := s.search.Put(ctx, search.Document{ID: next.Id, Title: next.Title, Description: next.Description, OwnerID: next.OwnerId}); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to index ToDo-> "+err.Error())
+		}
+	}
+
+	return &v1.CompleteResponse{
+		Api:  apiVersion,
+		ToDo: td,
+		Next: next,
+	}, nil
+}
+
+// Reopen marks a completed task as not done
+func (s *toDoServiceServer) Reopen(ctx context.Context, req *v1.ReopenRequest) (*v1.ReopenResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	var td *v1.ToDo
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := scope.require(ctx, tx, req.Id, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+			return err
+		}
+
+		ids := []interface{}{req.Id}
+		before, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		// reopening an open task changes nothing
+		res, err := tx.ExecContext(ctx, "UPDATE ToDo SET `Completed`=FALSE, `CompletedAt`=NULL, `Version`=`Version`+1 WHERE `ID`=? AND `Completed` AND `DeletedAt` IS NULL", req.Id)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+		}
+		reopened, err := res.RowsAffected()
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		}
+		if reopened > 0 {
+			after, err := snapshotToDos(ctx, tx, ids)
+			if err != nil {
+				return err
+			}
+			if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids, before, after); err != nil {
+				return err
+			}
+			if err := recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_UPDATED, "`ID`=?", req.Id); err != nil {
+				return err
+			}
+		}
+
+		if td, err = readToDo(ctx, tx, req.Id, false); err != nil {
+			return err
+		}
+		return loadAccess(ctx, tx, scope, []*v1.ToDo{td})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ReopenResponse{
+		Api:  apiVersion,
+		ToDo: td,
 	}, nil
-}
\ No newline at end of file
+}
+
+// searchFilter limits a search to the tasks the scope reaches
+func searchFilter(ctx context.Context, q queryer, scope taskScope) (search.Filter, error) {
+	if scope.unlimited() {
+		return search.Filter{}, nil
+	}
+	filter := search.Filter{OwnerID: scope.subject}
+	rows, err := q.QueryContext(ctx, "SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=? ORDER BY `ToDoID`", scope.subject)
+	if err != nil {
+		return search.Filter{}, status.Error(codes.Unknown, "failed to select from ToDoShare-> "+err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return search.Filter{}, status.Error(codes.Unknown, "failed to retrieve field values from ToDoShare row-> "+err.Error())
+		}
+		filter.Shared = append(filter.Shared, id)
+	}
+	if err := rows.Err(); err != nil {
+		return search.Filter{}, status.Error(codes.Unknown, "failed to retrieve data from ToDoShare-> "+err.Error())
+	}
+	return filter, nil
+}
+
+// Search tasks by keywords in title and description
+func (s *toDoServiceServer) Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if len(search.Terms(req.Q)) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "q must contain at least one keyword")
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// search results are ranked, so pages are addressed by offset
+	query := queryHash(req.Q, "")
+	offset := 0
+	if len(req.PageToken) > 0 {
+		token, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if token.Query != query || len(token.Values) != 1 {
+			return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different query")
+		}
+		if offset, err = strconv.Atoi(token.Values[0]); err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_token has invalid format")
+		}
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	// the index searches tasks the caller reaches only, so that pages are full
+	scope := callerScope(ctx)
+	filter, err := searchFilter(ctx, c, scope)
+	if err != nil {
+		return nil, err
+	}
+	hits, err := s.search.Search(ctx, req.Q, filter, size+1, offset)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to search ToDo-> "+err.Error())
+	}
+
+	var nextPageToken string
+	if len(hits) > size {
+		hits = hits[:size]
+		nextPageToken = encodePageToken(pageToken{Query: query, Values: []string{strconv.Itoa(offset + size)}})
+	}
+
+	results := []*v1.SearchResult{}
+	if len(hits) == 0 {
+		return &v1.SearchResponse{
+			Api:           apiVersion,
+			Results:       results,
+			NextPageToken: nextPageToken,
+		}, nil
+	}
+
+	// load found tasks in one query, checking access again as sharing may have changed since the search
+	ids := make([]interface{}, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	conds := append([]condition{
+		{sql: "`ID` IN (" + placeholders(len(ids)) + ")", args: ids},
+		{sql: "`DeletedAt` IS NULL"},
+	}, scope.conditions(v1.AccessLevel_ACCESS_LEVEL_VIEWER)...)
+	sqlWhere, args := whereSQL(conds)
+	rows, err := c.QueryContext(ctx, "SELECT "+toDoColumns+" FROM ToDo"+sqlWhere, args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	found := map[int64]*v1.ToDo{}
+	for rows.Next() {
+		td, err := scanToDo(rows)
+		if err != nil {
+			return nil, err
+		}
+		found[td.Id] = td
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	rows.Close()
+
+	// keep ranking order, skipping tasks deleted since they were indexed, in trash or not reached by the caller
+	for _, h := range hits {
+		td, ok := found[h.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, &v1.SearchResult{
+			ToDo:               td,
+			Score:              h.Score,
+			TitleSnippet:       search.Snippet(td.Title, req.Q, snippetWidth),
+			DescriptionSnippet: search.Snippet(td.Description, req.Q, snippetWidth),
+		})
+	}
+
+	list := make([]*v1.ToDo, 0, len(results))
+	for _, r := range results {
+		list = append(list, r.ToDo)
+	}
+	if err := loadTags(ctx, c, list); err != nil {
+		return nil, err
+	}
+	if err := loadAccess(ctx, c, scope, list); err != nil {
+		return nil, err
+	}
+
+	return &v1.SearchResponse{
+		Api:           apiVersion,
+		Results:       results,
+		NextPageToken: nextPageToken,
+	}, nil
+}
@@ -2,18 +2,48 @@ package v1
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
+	"math"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/metadata"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 
 	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/search"
 )
 
+// newToDoRows returns rows of the columns selected by toDoColumns
+func newToDoRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder", "Completed", "CompletedAt", "Due", "Priority", "ParentID", "ProjectID", "Recurrence", "TimeZone", "DeletedAt", "Version", "Position", "OwnerID"})
+}
+
+// toDoRow returns values of a row selected by toDoColumns for an open task
+func toDoRow(id int64, title, description string, reminder time.Time) []driver.Value {
+	return []driver.Value{id, title, description, reminder, false, nil, nil, 0, nil, nil, "", "", nil, 1, "", ""}
+}
+
+// expectLastPosition expects the query of the last position, an empty last position stands for no tasks
+func expectLastPosition(mock sqlmock.Sqlmock, last string) {
+	rows := newPositionRows()
+	if len(last) > 0 {
+		rows = newPositionRows(last)
+	}
+	mock.ExpectQuery("SELECT `Position` FROM ToDo ORDER BY `Position` DESC").WillReturnRows(rows)
+}
+
+// newTagRows returns rows of the query loading tags of tasks
+func newTagRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ToDoID", "Name"})
+}
+
 func Test_toDoServiceServer_Create(t *testing.T) {
 	ctx := context.Background()
 	db, mock, err := sqlmock.New()
@@ -52,14 +82,267 @@ func Test_toDoServiceServer_Create(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm).
+				mock.ExpectBegin()
+				expectLastPosition(mock, "a4")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, nil, 0, nil, nil, "", "", tm, "a5", "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 1, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_CREATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.CreateResponse{
+				Api: "v1",
+				Id:  1,
+			},
+		},
+		{
+			name: "Due and priority",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:       "title",
+						Description: "description",
+						Reminder:    reminder,
+						Due:         reminder,
+						Priority:    v1.Priority_PRIORITY_HIGH,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLastPosition(mock, "")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, tm, 3, nil, nil, "", "", tm, "a0", "").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				expectSnapshot(mock, 1, 2)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_CREATE, 2)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.CreateResponse{
+				Api: "v1",
+				Id:  2,
+			},
+		},
+		{
+			name: "With tags",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:       "title",
+						Description: "description",
+						Reminder:    reminder,
+						Tags:        []string{" backend", "oncall", "backend"},
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLastPosition(mock, "")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, nil, 0, nil, nil, "", "", tm, "a0", "").
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("INSERT IGNORE INTO Tag").WithArgs("backend", "oncall").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT IGNORE INTO ToDoTag").WithArgs(3, "backend", "oncall").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectSnapshot(mock, 1, 3)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_CREATE, 3)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.CreateResponse{
+				Api: "v1",
+				Id:  3,
+			},
+		},
+		{
+			name: "Subtask",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:    "title",
+						Reminder: reminder,
+						ParentId: 1,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(nil))
+				mock.ExpectQuery("SELECT `ProjectID` FROM ToDo WHERE `ID`=\\?").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ProjectID"}).AddRow(2))
+				mock.ExpectQuery("SELECT `ArchivedAt` FROM Project WHERE `ID`=\\? LOCK IN SHARE MODE").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ArchivedAt"}).AddRow(nil))
+				expectLastPosition(mock, "")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "", tm, nil, 0, 1, 2, "", "", tm, "a0", "").
+					WillReturnResult(sqlmock.NewResult(4, 1))
+				expectSnapshot(mock, 1, 4)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_CREATE, 4)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.CreateResponse{
+				Api: "v1",
+				Id:  4,
+			},
+		},
+		{
+			name: "Parent not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:    "title",
+						Reminder: reminder,
+						ParentId: 7,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid recurrence",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:      "title",
+						Reminder:   reminder,
+						Recurrence: "FREQ=SOMETIMES",
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unknown time zone",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:      "title",
+						Reminder:   reminder,
+						Recurrence: "FREQ=DAILY",
+						TimeZone:   "Mars/Olympus_Mons",
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Empty tag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:    "title",
+						Reminder: reminder,
+						Tags:     []string{" "},
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unknown priority",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:    "title",
+						Reminder: reminder,
+						Priority: 42,
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Owned by caller",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:       "title",
+						Description: "description",
+						Reminder:    reminder,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectLastPosition(mock, "")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, nil, 0, nil, nil, "", "", tm, "a0", "alice").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("INSERT INTO ToDoHistory").WithArgs(1, int(v1.HistoryAction_HISTORY_ACTION_CREATE), "alice", sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			want: &v1.CreateResponse{
 				Api: "v1",
 				Id:  1,
 			},
 		},
+		{
+			name: "Parent of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.CreateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Title:    "title",
+						Reminder: reminder,
+						ParentId: 1,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", 0)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
 		{
 			name: "Unsupported API",
 			s:    s,
@@ -115,8 +398,11 @@ func Test_toDoServiceServer_Create(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm).
+				mock.ExpectBegin()
+				expectLastPosition(mock, "")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, nil, 0, nil, nil, "", "", tm, "a0", "").
 					WillReturnError(errors.New("INSERT failed"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -135,8 +421,11 @@ func Test_toDoServiceServer_Create(t *testing.T) {
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm).
+				mock.ExpectBegin()
+				expectLastPosition(mock, "")
+				mock.ExpectExec("INSERT INTO ToDo").WithArgs("title", "description", tm, nil, 0, nil, nil, "", "", tm, "a0", "").
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("LastInsertId failed")))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -190,14 +479,16 @@ func Test_toDoServiceServer_Read(t *testing.T) {
 				},
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder"}).
-					AddRow(1, "title", "description", tm)
+				rows := newToDoRows().
+					AddRow(toDoRow(1, "title", "description", tm)...)
 				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
 			},
 			want: &v1.ReadResponse{
 				Api: "v1",
 				ToDo: &v1.ToDo{
 					Id:          1,
+					Etag:        "1",
 					Title:       "title",
 					Description: "description",
 					Reminder:    reminder,
@@ -205,7 +496,116 @@ func Test_toDoServiceServer_Read(t *testing.T) {
 			},
 		},
 		{
-			name: "Unsupported API",
+			name: "Subtree",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadRequest{
+					Api:   "v1",
+					Id:    1,
+					Depth: 2,
+				},
+			},
+			mock: func() {
+				rows := newToDoRows().
+					AddRow(toDoRow(1, "title", "description", tm)...)
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(newToDoRows().
+						AddRow(2, "child 1", "", tm, false, nil, nil, 0, 1, nil, "", "", nil, 1, "", "").
+						AddRow(3, "child 2", "", tm, true, tm, nil, 0, 1, nil, "", "", nil, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ParentID` IN").WithArgs(2, 3).
+					WillReturnRows(newToDoRows().
+						AddRow(4, "grandchild", "", tm, false, nil, nil, 0, 2, nil, "", "", nil, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(2, 3, 4).
+					WillReturnRows(newTagRows().AddRow(4, "backend"))
+			},
+			want: &v1.ReadResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					Children: []*v1.ToDo{
+						{
+							Id:       2,
+							Etag:     "1",
+							Title:    "child 1",
+							Reminder: reminder,
+							ParentId: 1,
+							Children: []*v1.ToDo{
+								{
+									Id:       4,
+									Etag:     "1",
+									Title:    "grandchild",
+									Reminder: reminder,
+									ParentId: 2,
+									Tags:     []string{"backend"},
+								},
+							},
+						},
+						{
+							Id:          3,
+							Etag:        "1",
+							Title:       "child 2",
+							Reminder:    reminder,
+							Completed:   true,
+							CompletedAt: reminder,
+							ParentId:    1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Show deleted",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadRequest{
+					Api:         "v1",
+					Id:          1,
+					Depth:       1,
+					ShowDeleted: true,
+				},
+			},
+			mock: func() {
+				rows := newToDoRows().
+					AddRow(1, "title", "description", tm, false, nil, nil, 0, nil, nil, "", "", tm, 1, "", "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ParentID` IN \\(\\?\\) ORDER BY").WithArgs(1).
+					WillReturnRows(newToDoRows().
+						AddRow(2, "child", "", tm, false, nil, nil, 0, 1, nil, "", "", tm, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(2).WillReturnRows(newTagRows())
+			},
+			want: &v1.ReadResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					DeletedAt:   reminder,
+					Children: []*v1.ToDo{
+						{
+							Id:        2,
+							Etag:      "1",
+							Title:     "child",
+							Reminder:  reminder,
+							ParentId:  1,
+							DeletedAt: reminder,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Deleted",
 			s:    s,
 			args: args{
 				ctx: ctx,
@@ -214,224 +614,1716 @@ func Test_toDoServiceServer_Read(t *testing.T) {
 					Id:  1,
 				},
 			},
-			mock:    func() {},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL").WithArgs(1).WillReturnRows(newToDoRows())
+			},
 			wantErr: true,
 		},
 		{
-			name: "SELECT failed",
+			name: "Negative depth",
 			s:    s,
 			args: args{
 				ctx: ctx,
+				req: &v1.ReadRequest{
+					Api:   "v1",
+					Id:    1,
+					Depth: -1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Own task",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
 				req: &v1.ReadRequest{
 					Api: "v1",
 					Id:  1,
 				},
 			},
 			mock: func() {
-				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).
-					WillReturnError(errors.New("SELECT failed"))
+				expectAccess(mock, 1, "alice", "alice", 0)
+				row := toDoRow(1, "title", "description", tm)
+				row[15] = "alice"
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).WillReturnRows(newToDoRows().AddRow(row...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+			},
+			want: &v1.ReadResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					OwnerId:     "alice",
+					Access:      v1.AccessLevel_ACCESS_LEVEL_OWNER,
+				},
 			},
-			wantErr: true,
 		},
 		{
-			name: "Not found",
+			name: "Shared task",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.ReadRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_EDITOR)
+				row := toDoRow(1, "title", "description", tm)
+				row[15] = "alice"
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).WillReturnRows(newToDoRows().AddRow(row...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT `ToDoID`, `Access` FROM ToDoShare").WithArgs("bob", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Access"}).AddRow(1, 2))
+			},
+			want: &v1.ReadResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					OwnerId:     "alice",
+					Access:      v1.AccessLevel_ACCESS_LEVEL_EDITOR,
+				},
+			},
+		},
+		{
+			name: "Task of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.ReadRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				expectAccess(mock, 1, "bob", "alice", 0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "All owners by admin",
+			s:    NewToDoServiceServer(db, WithAdmins([]string{"root"})),
+			args: args{
+				ctx: auth.NewContext(ctx, "root"),
+				req: &v1.ReadRequest{
+					Api:       "v1",
+					Id:        1,
+					AllOwners: true,
+				},
+			},
+			mock: func() {
+				row := toDoRow(1, "title", "description", tm)
+				row[15] = "alice"
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).WillReturnRows(newToDoRows().AddRow(row...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT `ToDoID`, `Access` FROM ToDoShare").WithArgs("root", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Access"}))
+			},
+			want: &v1.ReadResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					OwnerId:     "alice",
+				},
+			},
+		},
+		{
+			name: "All owners by non-admin",
+			s:    NewToDoServiceServer(db, WithAdmins([]string{"root"})),
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.ReadRequest{
+					Api:       "v1",
+					Id:        1,
+					AllOwners: true,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "SELECT failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).
+					WillReturnError(errors.New("SELECT failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				rows := newToDoRows()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).WillReturnRows(rows)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.Read(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_Update(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.UpdateRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.UpdateResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:          1,
+						Title:       "new title",
+						Description: "new description",
+						Reminder:    reminder,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, nil, 0, nil, "", "", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.UpdateResponse{
+				Api:     "v1",
+				Updated: 1,
+				Etag:    "2",
+			},
+		},
+		{
+			name: "Partial update",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:    1,
+						Title: "new title",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"id", "title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Title`=\\?, `Version`=`Version`\\+1 WHERE").WithArgs("new title", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("SELECT `Title`, `Description` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"Title", "Description"}).AddRow("new title", "description"))
+			},
+			want: &v1.UpdateResponse{
+				Api:     "v1",
+				Updated: 1,
+				Etag:    "2",
+			},
+		},
+		{
+			name: "Partial update of reminder",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:       1,
+						Reminder: reminder,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"reminder"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Reminder`=\\?, `RecurrenceStart`=`Reminder`, `Version`=`Version`\\+1 WHERE").WithArgs(tm, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.UpdateResponse{
+				Api:     "v1",
+				Updated: 1,
+				Etag:    "2",
+			},
+		},
+		{
+			name: "Move under another task",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:       1,
+						ParentId: 3,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"parent_id"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(2))
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(nil))
+				mock.ExpectQuery("SELECT t.`ProjectID` <=> p.`ProjectID` FROM ToDo t JOIN ToDo p").WithArgs(3, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"Same"}).AddRow(true))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `ParentID`=\\?, `Version`=`Version`\\+1 WHERE").WithArgs(3, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.UpdateResponse{
+				Api:     "v1",
+				Updated: 1,
+				Etag:    "2",
+			},
+		},
+		{
+			name: "Move under a task in another project",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:       1,
+						ParentId: 3,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"parent_id"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(nil))
+				mock.ExpectQuery("SELECT t.`ProjectID` <=> p.`ProjectID` FROM ToDo t JOIN ToDo p").WithArgs(3, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"Same"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Matching etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:    1,
+						Title: "new title",
+						Etag:  "3",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title", "etag"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Version` FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL FOR UPDATE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(3))
+				expectSnapshot(mock, 3, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Title`=\\?, `Version`=`Version`\\+1 WHERE").WithArgs("new title", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 4, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("SELECT `Title`, `Description` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"Title", "Description"}).AddRow("new title", "description"))
+			},
+			want: &v1.UpdateResponse{
+				Api:     "v1",
+				Updated: 1,
+				Etag:    "4",
+			},
+		},
+		{
+			name: "Stale etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:    1,
+						Title: "new title",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+					Etag:       "3",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Version` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(4))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Stale If-Match header",
+			s:    s,
+			args: args{
+				ctx: metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", `"3"`)),
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:    1,
+						Title: "new title",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Version` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(4))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Move under own subtask",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:       1,
+						ParentId: 3,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"parent_id"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(2))
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Own parent",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:       1,
+						ParentId: 1,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"parent_id"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown update_mask field",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id: 1,
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"owner"}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Task of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:    1,
+						Title: "new title",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", 0)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Viewer of task",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:    1,
+						Title: "new title",
+					},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_VIEWER)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:          1,
+						Title:       "new title",
+						Description: "new description",
+						Reminder:    reminder,
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Invalid Reminder field format",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:          1,
+						Title:       "new title",
+						Description: "new description",
+						Reminder: &timestamp.Timestamp{
+							Seconds: 1,
+							Nanos:   -1,
+						},
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "UPDATE failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:          1,
+						Title:       "new title",
+						Description: "new description",
+						Reminder:    reminder,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, nil, 0, nil, "", "", 1).
+					WillReturnError(errors.New("UPDATE failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "RowsAffected failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:          1,
+						Title:       "new title",
+						Description: "new description",
+						Reminder:    reminder,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, nil, 0, nil, "", "", 1).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("RowsAffected failed")))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not Found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.UpdateRequest{
+					Api: "v1",
+					ToDo: &v1.ToDo{
+						Id:          1,
+						Title:       "new title",
+						Description: "new description",
+						Reminder:    reminder,
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, nil, 0, nil, "", "", 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.Update(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_Delete(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.DeleteRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.DeleteResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_DELETE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Cascade",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api:     "v1",
+					Id:      1,
+					Cascade: true,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(2).AddRow(3))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(4))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1, 2, 3, 4)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`=\\?, `Version`=`Version`\\+1 WHERE `ID` IN \\(\\?,\\?,\\?,\\?\\) AND `DeletedAt` IS NULL").
+					WithArgs(sqlmock.AnyArg(), 1, 2, 3, 4).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				expectSnapshot(mock, 2, 1, 2, 3, 4)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_DELETE, 1, 2, 3, 4)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(3, 1, 2, 3, 4).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteResponse{
+				Api:     "v1",
+				Deleted: 4,
+			},
+		},
+		{
+			name: "Stale etag",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api:  "v1",
+					Id:   1,
+					Etag: "1",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `Version` FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL FOR UPDATE").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"Version"}).AddRow(2))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Has subtasks",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(2))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Own task",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "alice", "alice", 0)
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 2, 1)
+				mock.ExpectExec("INSERT INTO ToDoHistory").WithArgs(1, int(v1.HistoryAction_HISTORY_ACTION_DELETE), "alice", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Task of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", 0)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Editor of task",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_EDITOR)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "UPDATE failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("UPDATE failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "RowsAffected failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("RowsAffected failed")))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not Found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.Delete(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.Delete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_ReadAll(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm1 := time.Now().In(time.UTC)
+	reminder1, _ := ptypes.TimestampProto(tm1)
+	tm2 := time.Now().In(time.UTC)
+	reminder2, _ := ptypes.TimestampProto(tm2)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ReadAllRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ReadAllResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api: "v1",
+				},
+			},
+			mock: func() {
+				rows := newToDoRows().
+					AddRow(toDoRow(1, "title 1", "description 1", tm1)...).
+					AddRow(toDoRow(2, "title 2", "description 2", tm2)...)
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1, 2).
+					WillReturnRows(newTagRows().AddRow(2, "oncall").AddRow(1, "backend"))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:          1,
+						Etag:        "1",
+						Title:       "title 1",
+						Description: "description 1",
+						Reminder:    reminder1,
+						Tags:        []string{"backend"},
+					},
+					{
+						Id:          2,
+						Etag:        "1",
+						Title:       "title 2",
+						Description: "description 2",
+						Reminder:    reminder2,
+						Tags:        []string{"oncall"},
+					},
+				},
+			},
+		},
+		{
+			name: "Project",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					ProjectId: 2,
+				},
+			},
+			mock: func() {
+				row := toDoRow(1, "title 1", "description 1", tm1)
+				row[9] = 2
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Project WHERE `ID`=\\?").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `DeletedAt` IS NULL AND `ProjectID`=\\?").WithArgs(2, sqlmock.AnyArg()).
+					WillReturnRows(newToDoRows().AddRow(row...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:          1,
+						Etag:        "1",
+						Title:       "title 1",
+						Description: "description 1",
+						Reminder:    reminder1,
+						ProjectId:   2,
+					},
+				},
+			},
+		},
+		{
+			name: "Project not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					ProjectId: 2,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Project WHERE `ID`=\\?").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api: "v1",
+				},
+			},
+			mock: func() {
+				rows := newToDoRows()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnRows(rows)
+			},
+			want: &v1.ReadAllResponse{
+				Api:   "v1",
+				ToDos: []*v1.ToDo{},
+			},
+		},
+		{
+			name: "Show deleted",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:         "v1",
+					ShowDeleted: true,
+				},
+			},
+			mock: func() {
+				rows := newToDoRows().
+					AddRow(1, "title 1", "description 1", tm1, false, nil, nil, 0, nil, nil, "", "", tm2, 1, "", "")
+				mock.ExpectQuery("SELECT (.+) FROM ToDo ORDER BY `Position`, `ID` LIMIT").WithArgs(defaultPageSize + 1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:          1,
+						Etag:        "1",
+						Title:       "title 1",
+						Description: "description 1",
+						Reminder:    reminder1,
+						DeletedAt:   reminder2,
+					},
+				},
+			},
+		},
+		{
+			name: "Next page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:      "v1",
+					PageSize: 1,
+				},
+			},
+			mock: func() {
+				row1 := toDoRow(1, "title 1", "description 1", tm1)
+				row1[14] = "a0"
+				row2 := toDoRow(2, "title 2", "description 2", tm2)
+				row2[14] = "a1"
+				rows := newToDoRows().AddRow(row1...).AddRow(row2...)
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `DeletedAt` IS NULL ORDER BY `Position`, `ID` LIMIT").WithArgs(2).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:          1,
+						Etag:        "1",
+						Title:       "title 1",
+						Description: "description 1",
+						Reminder:    reminder1,
+						Position:    "a0",
+					},
+				},
+				NextPageToken: encodePageToken(pageToken{Query: queryHash("", "", "", "TAG_MATCH_ANY", "false", "0", "false"), Values: []string{"a0", "1"}}),
+			},
+		},
+		{
+			name: "Last page with total size",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:              "v1",
+					PageSize:         1,
+					PageToken:        encodePageToken(pageToken{Query: queryHash("", "", "", "TAG_MATCH_ANY", "false", "0", "false"), Values: []string{"a0", "1"}}),
+					IncludeTotalSize: true,
+				},
+			},
+			mock: func() {
+				row := toDoRow(2, "title 2", "description 2", tm2)
+				row[14] = "a1"
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `DeletedAt` IS NULL AND \\(\\(`Position`>\\?\\) OR \\(`Position`=\\? AND `ID`>\\?\\)\\)").
+					WithArgs("a0", "a0", 1, 2).WillReturnRows(newToDoRows().AddRow(row...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT COUNT(.+) FROM ToDo").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:          2,
+						Etag:        "1",
+						Title:       "title 2",
+						Description: "description 2",
+						Reminder:    reminder2,
+						Position:    "a1",
+					},
+				},
+				TotalSize: 2,
+			},
+		},
+		{
+			name: "Filter and order",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:      "v1",
+					PageSize: 1,
+					Filter:   `title:"50%" AND id>=2`,
+					OrderBy:  "reminder desc",
+				},
+			},
+			mock: func() {
+				rows := newToDoRows().
+					AddRow(toDoRow(2, "title 2", "description 2", tm2)...).
+					AddRow(toDoRow(3, "title 3", "description 3", tm1)...)
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `Title` LIKE \\? AND `ID`>=\\? AND `DeletedAt` IS NULL ORDER BY `Reminder` DESC, `ID` LIMIT").
+					WithArgs(`%50\%%`, 2, 2).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:          2,
+						Etag:        "1",
+						Title:       "title 2",
+						Description: "description 2",
+						Reminder:    reminder2,
+					},
+				},
+				NextPageToken: encodePageToken(pageToken{
+					Query:  queryHash(`title:"50%" AND id>=2`, "reminder desc", "", "TAG_MATCH_ANY", "false", "0", "false"),
+					Values: []string{tm2.Format(time.RFC3339Nano), "2"},
+				}),
+			},
+		},
+		{
+			name: "Unknown filter field",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:    "v1",
+					Filter: `owner="me"`,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unknown order_by field",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:     "v1",
+					OrderBy: "owner",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Tags all",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:      "v1",
+					Tags:     []string{"backend", "oncall"},
+					TagMatch: v1.TagMatch_TAG_MATCH_ALL,
+				},
+			},
+			mock: func() {
+				rows := newToDoRows().
+					AddRow(toDoRow(2, "title 2", "description 2", tm2)...)
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN \\(SELECT (.+) GROUP BY tt.`ToDoID` HAVING COUNT\\(\\*\\)=\\?\\) AND `DeletedAt` IS NULL").
+					WithArgs("backend", "oncall", 2, defaultPageSize+1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(2).
+					WillReturnRows(newTagRows().AddRow(2, "backend").AddRow(2, "oncall"))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:          2,
+						Etag:        "1",
+						Title:       "title 2",
+						Description: "description 2",
+						Reminder:    reminder2,
+						Tags:        []string{"backend", "oncall"},
+					},
+				},
+			},
+		},
+		{
+			name: "Unknown tag match",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:      "v1",
+					Tags:     []string{"backend"},
+					TagMatch: v1.TagMatch(7),
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Page token for different order",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					OrderBy:   "title",
+					PageToken: encodePageToken(pageToken{Query: queryHash("", "", "", "TAG_MATCH_ANY", "false", "0", "false"), Values: []string{"1"}}),
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Invalid page token",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					PageToken: "not a token",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Negative page size",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api:      "v1",
+					PageSize: -1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Own and shared tasks",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.ReadAllRequest{
+					Api: "v1",
+				},
+			},
+			mock: func() {
+				own := toDoRow(1, "title 1", "description 1", tm1)
+				own[15] = "alice"
+				shared := toDoRow(2, "title 2", "description 2", tm2)
+				shared[15] = "carol"
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `DeletedAt` IS NULL AND \\(`OwnerID`=\\? OR `ID` IN \\(SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=\\? AND `Access`>=\\?\\)\\) ORDER BY").
+					WithArgs("alice", "alice", 1, sqlmock.AnyArg()).
+					WillReturnRows(newToDoRows().AddRow(own...).AddRow(shared...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1, 2).WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT `ToDoID`, `Access` FROM ToDoShare WHERE `Subject`=\\? AND `ToDoID` IN \\(\\?\\)").WithArgs("alice", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Access"}).AddRow(2, 1))
+			},
+			want: &v1.ReadAllResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:          1,
+						Etag:        "1",
+						Title:       "title 1",
+						Description: "description 1",
+						Reminder:    reminder1,
+						OwnerId:     "alice",
+						Access:      v1.AccessLevel_ACCESS_LEVEL_OWNER,
+					},
+					{
+						Id:          2,
+						Etag:        "1",
+						Title:       "title 2",
+						Description: "description 2",
+						Reminder:    reminder2,
+						OwnerId:     "carol",
+						Access:      v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+					},
+				},
+			},
+		},
+		{
+			name: "All owners by non-admin",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.ReadAllRequest{
+					Api:       "v1",
+					AllOwners: true,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadAllRequest{
+					Api: "v1",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ReadAll(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ReadAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ReadAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+func Test_toDoServiceServer_Search(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	index := search.NewMemoryIndex()
+	s := &toDoServiceServer{dbService: dbService{db: db}, search: index}
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	_ = index.Put(ctx, search.Document{ID: 1, Title: "pay invoice", Description: "monthly invoice for hosting"})
+	_ = index.Put(ctx, search.Document{ID: 2, Title: "standup", Description: "send invoice numbers"})
+	_ = index.Put(ctx, search.Document{ID: 3, Title: "lunch", Description: "with team"})
+
+	type args struct {
+		ctx context.Context
+		req *v1.SearchRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.SearchResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.SearchRequest{
+					Api:      "v1",
+					Q:        "Invoice",
+					PageSize: 1,
+				},
+			},
+			mock: func() {
+				rows := newToDoRows().
+					AddRow(toDoRow(1, "pay invoice", "monthly invoice for hosting", tm)...)
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows().AddRow(1, "billing"))
+			},
+			want: &v1.SearchResponse{
+				Api: "v1",
+				Results: []*v1.SearchResult{
+					{
+						ToDo: &v1.ToDo{
+							Id:          1,
+							Etag:        "1",
+							Title:       "pay invoice",
+							Description: "monthly invoice for hosting",
+							Reminder:    reminder,
+							Tags:        []string{"billing"},
+						},
+						Score:              3 * math.Log(1+3.0/2),
+						TitleSnippet:       "pay <em>invoice</em>",
+						DescriptionSnippet: "monthly <em>invoice</em> for hosting",
+					},
+				},
+				NextPageToken: encodePageToken(pageToken{Query: queryHash("Invoice", ""), Values: []string{"1"}}),
+			},
+		},
+		{
+			name: "Next page skips deleted task",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.SearchRequest{
+					Api:       "v1",
+					Q:         "Invoice",
+					PageToken: encodePageToken(pageToken{Query: queryHash("Invoice", ""), Values: []string{"1"}}),
+				},
+			},
+			mock: func() {
+				rows := newToDoRows()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(2).WillReturnRows(rows)
+			},
+			want: &v1.SearchResponse{
+				Api:     "v1",
+				Results: []*v1.SearchResult{},
+			},
+		},
+		{
+			name: "No match",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.SearchRequest{
+					Api: "v1",
+					Q:   "dentist",
+				},
+			},
+			mock: func() {},
+			want: &v1.SearchResponse{
+				Api:     "v1",
+				Results: []*v1.SearchResult{},
+			},
+		},
+		{
+			name: "Empty query",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.SearchRequest{
+					Api: "v1",
+					Q:   " ? ",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Page token for different query",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.ReadRequest{
-					Api: "v1",
-					Id:  1,
+				req: &v1.SearchRequest{
+					Api:       "v1",
+					Q:         "lunch",
+					PageToken: encodePageToken(pageToken{Query: queryHash("Invoice", ""), Values: []string{"1"}}),
 				},
 			},
-			mock: func() {
-				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder"})
-				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).WillReturnRows(rows)
-			},
+			mock:    func() {},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			got, err := tt.s.Read(tt.args.ctx, tt.args.req)
+			got, err := tt.s.Search(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("toDoServiceServer.Read() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("toDoServiceServer.Search() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-
 			if err == nil && !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("toDoServiceServer.Read() = %v, want %v", got, tt.want)
+				t.Errorf("toDoServiceServer.Search() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_toDoServiceServer_Update(t *testing.T) {
-	ctx := context.Background()
+func Test_toDoServiceServer_Search_owners(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), "alice")
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
-	s := NewToDoServiceServer(db)
+	index := search.NewMemoryIndex()
+	s := &toDoServiceServer{dbService: dbService{db: db}, search: index}
 	tm := time.Now().In(time.UTC)
 	reminder, _ := ptypes.TimestampProto(tm)
 
+	// the tasks of bob come first in ranking order, only the last one is shared with alice
+	_ = index.Put(ctx, search.Document{ID: 1, Title: "invoice", OwnerID: "bob"})
+	_ = index.Put(ctx, search.Document{ID: 2, Title: "invoice", OwnerID: "bob"})
+	_ = index.Put(ctx, search.Document{ID: 3, Title: "invoice", OwnerID: "alice"})
+	_ = index.Put(ctx, search.Document{ID: 4, Title: "invoice", OwnerID: "bob"})
+	score := 2 * math.Log(1+4.0/4)
+	nextPageToken := encodePageToken(pageToken{Query: queryHash("invoice", ""), Values: []string{"1"}})
+	shares := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"ToDoID"}).AddRow(4)
+	}
+
 	type args struct {
 		ctx context.Context
-		req *v1.UpdateRequest
+		req *v1.SearchRequest
 	}
 	tests := []struct {
 		name    string
 		s       v1.ToDoServiceServer
 		args    args
 		mock    func()
-		want    *v1.UpdateResponse
+		want    *v1.SearchResponse
 		wantErr bool
 	}{
 		{
-			name: "OK",
+			name: "Full page of own task",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.UpdateRequest{
-					Api: "v1",
-					ToDo: &v1.ToDo{
-						Id:          1,
-						Title:       "new title",
-						Description: "new description",
-						Reminder:    reminder,
-					},
+				req: &v1.SearchRequest{
+					Api:      "v1",
+					Q:        "invoice",
+					PageSize: 1,
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, 1).
-					WillReturnResult(sqlmock.NewResult(1, 1))
-			},
-			want: &v1.UpdateResponse{
-				Api:     "v1",
-				Updated: 1,
+				mock.ExpectQuery("SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=\\?").WithArgs("alice").WillReturnRows(shares())
+				row := toDoRow(3, "invoice", "", tm)
+				row[15] = "alice"
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN \\(\\?\\) AND `DeletedAt` IS NULL AND \\(`OwnerID`=\\? OR").
+					WithArgs(3, "alice", "alice", int32(v1.AccessLevel_ACCESS_LEVEL_VIEWER)).
+					WillReturnRows(newToDoRows().AddRow(row...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(3).WillReturnRows(newTagRows())
 			},
-		},
-		{
-			name: "Unsupported API",
-			s:    s,
-			args: args{
-				ctx: ctx,
-				req: &v1.UpdateRequest{
-					Api: "v1",
-					ToDo: &v1.ToDo{
-						Id:          1,
-						Title:       "new title",
-						Description: "new description",
-						Reminder:    reminder,
-					},
-				},
-			},
-			mock:    func() {},
-			wantErr: true,
-		},
-		{
-			name: "Invalid Reminder field format",
-			s:    s,
-			args: args{
-				ctx: ctx,
-				req: &v1.UpdateRequest{
-					Api: "v1",
-					ToDo: &v1.ToDo{
-						Id:          1,
-						Title:       "new title",
-						Description: "new description",
-						Reminder: &timestamp.Timestamp{
-							Seconds: 1,
-							Nanos:   -1,
+			want: &v1.SearchResponse{
+				Api: "v1",
+				Results: []*v1.SearchResult{
+					{
+						ToDo: &v1.ToDo{
+							Id:       3,
+							Etag:     "1",
+							Title:    "invoice",
+							Reminder: reminder,
+							OwnerId:  "alice",
+							Access:   v1.AccessLevel_ACCESS_LEVEL_OWNER,
 						},
+						Score:        score,
+						TitleSnippet: "<em>invoice</em>",
 					},
 				},
+				NextPageToken: nextPageToken,
 			},
-			mock:    func() {},
-			wantErr: true,
-		},
-		{
-			name: "UPDATE failed",
-			s:    s,
-			args: args{
-				ctx: ctx,
-				req: &v1.UpdateRequest{
-					Api: "v1",
-					ToDo: &v1.ToDo{
-						Id:          1,
-						Title:       "new title",
-						Description: "new description",
-						Reminder:    reminder,
-					},
-				},
-			},
-			mock: func() {
-				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, 1).
-					WillReturnError(errors.New("UPDATE failed"))
-			},
-			wantErr: true,
 		},
 		{
-			name: "RowsAffected failed",
+			name: "Last page of shared task",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.UpdateRequest{
-					Api: "v1",
-					ToDo: &v1.ToDo{
-						Id:          1,
-						Title:       "new title",
-						Description: "new description",
-						Reminder:    reminder,
-					},
+				req: &v1.SearchRequest{
+					Api:       "v1",
+					Q:         "invoice",
+					PageSize:  1,
+					PageToken: nextPageToken,
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, 1).
-					WillReturnResult(sqlmock.NewErrorResult(errors.New("RowsAffected failed")))
+				mock.ExpectQuery("SELECT `ToDoID` FROM ToDoShare WHERE `Subject`=\\?").WithArgs("alice").WillReturnRows(shares())
+				row := toDoRow(4, "invoice", "", tm)
+				row[15] = "bob"
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(4, "alice", "alice", int32(v1.AccessLevel_ACCESS_LEVEL_VIEWER)).
+					WillReturnRows(newToDoRows().AddRow(row...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(4).WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT `ToDoID`, `Access` FROM ToDoShare").WithArgs("alice", 4).
+					WillReturnRows(sqlmock.NewRows([]string{"ToDoID", "Access"}).AddRow(4, int32(v1.AccessLevel_ACCESS_LEVEL_VIEWER)))
 			},
-			wantErr: true,
-		},
-		{
-			name: "Not Found",
-			s:    s,
-			args: args{
-				ctx: ctx,
-				req: &v1.UpdateRequest{
-					Api: "v1",
-					ToDo: &v1.ToDo{
-						Id:          1,
-						Title:       "new title",
-						Description: "new description",
-						Reminder:    reminder,
+			want: &v1.SearchResponse{
+				Api: "v1",
+				Results: []*v1.SearchResult{
+					{
+						ToDo: &v1.ToDo{
+							Id:       4,
+							Etag:     "1",
+							Title:    "invoice",
+							Reminder: reminder,
+							OwnerId:  "bob",
+							Access:   v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+						},
+						Score:        score,
+						TitleSnippet: "<em>invoice</em>",
 					},
 				},
 			},
-			mock: func() {
-				mock.ExpectExec("UPDATE ToDo").WithArgs("new title", "new description", tm, 1).
-					WillReturnResult(sqlmock.NewResult(1, 0))
-			},
-			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			got, err := tt.s.Update(tt.args.ctx, tt.args.req)
+			got, err := tt.s.Search(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("toDoServiceServer.Update() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("toDoServiceServer.Search() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err == nil && !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("toDoServiceServer.Update() = %v, want %v", got, tt.want)
+				t.Errorf("toDoServiceServer.Search() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_toDoServiceServer_Delete(t *testing.T) {
+func Test_toDoServiceServer_Complete(t *testing.T) {
 	ctx := context.Background()
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -439,17 +2331,27 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 	}
 	defer db.Close()
 	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+	completedAt, _ := ptypes.TimestampProto(tm.Add(time.Hour))
+	// 10:00 in Berlin on the Mondays before and after daylight saving time starts
+	standup := time.Date(2020, time.March, 23, 9, 0, 0, 0, time.UTC)
+	nextStandup := time.Date(2020, time.March, 30, 8, 0, 0, 0, time.UTC)
+	standupTs, _ := ptypes.TimestampProto(standup)
+	standupDueTs, _ := ptypes.TimestampProto(standup.Add(time.Hour))
+	nextStandupTs, _ := ptypes.TimestampProto(nextStandup)
+	nextStandupDueTs, _ := ptypes.TimestampProto(nextStandup.Add(time.Hour))
 
 	type args struct {
 		ctx context.Context
-		req *v1.DeleteRequest
+		req *v1.CompleteRequest
 	}
 	tests := []struct {
 		name    string
 		s       v1.ToDoServiceServer
 		args    args
 		mock    func()
-		want    *v1.DeleteResponse
+		want    *v1.CompleteResponse
 		wantErr bool
 	}{
 		{
@@ -457,78 +2359,230 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.DeleteRequest{
+				req: &v1.CompleteRequest{
 					Api: "v1",
 					Id:  1,
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("DELETE FROM ToDo").WithArgs(1).
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=TRUE").WithArgs(sqlmock.AnyArg(), 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(1, "title", "description", tm, true, tm.Add(time.Hour), nil, 0, nil, nil, "", "", nil, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+				mock.ExpectCommit()
 			},
-			want: &v1.DeleteResponse{
-				Api:     "v1",
-				Deleted: 1,
+			want: &v1.CompleteResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					Completed:   true,
+					CompletedAt: completedAt,
+				},
 			},
 		},
 		{
-			name: "Unsupported API",
+			name: "Already completed",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.DeleteRequest{
+				req: &v1.CompleteRequest{
 					Api: "v1",
 					Id:  1,
 				},
 			},
-			mock:    func() {},
-			wantErr: true,
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=TRUE").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(1, "title", "description", tm, true, tm.Add(time.Hour), nil, 0, nil, nil, "", "", nil, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+				mock.ExpectCommit()
+			},
+			want: &v1.CompleteResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					Completed:   true,
+					CompletedAt: completedAt,
+				},
+			},
 		},
 		{
-			name: "DELETE failed",
+			name: "Complete parent",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.DeleteRequest{
+				req: &v1.CompleteRequest{
+					Api:            "v1",
+					Id:             3,
+					CompleteParent: true,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 3)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=TRUE").WithArgs(sqlmock.AnyArg(), 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 3)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 3)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(3).
+					WillReturnRows(newToDoRows().AddRow(3, "title", "description", tm, true, tm.Add(time.Hour), nil, 0, 2, nil, "", "", nil, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(2))
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ParentID`=\\? AND NOT `Completed`").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+				expectSnapshot(mock, 1, 2)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=TRUE").WithArgs(sqlmock.AnyArg(), 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 2)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 2)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `ParentID` FROM ToDo").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ParentID"}).AddRow(1))
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ParentID`=\\? AND NOT `Completed`").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			want: &v1.CompleteResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          3,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					Completed:   true,
+					CompletedAt: completedAt,
+					ParentId:    2,
+				},
+			},
+		},
+		{
+			name: "Recurring",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CompleteRequest{
 					Api: "v1",
-					Id:  1,
+					Id:  5,
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("DELETE FROM ToDo").WithArgs(1).
-					WillReturnError(errors.New("DELETE failed"))
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 5)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=TRUE").WithArgs(sqlmock.AnyArg(), 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 2, 5)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 5)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(5).
+					WillReturnRows(newToDoRows().AddRow(5, "standup notes", "", standup, true, tm.Add(time.Hour), standup.Add(time.Hour), 0, nil, nil,
+						"FREQ=WEEKLY;BYDAY=MO", "Europe/Berlin", nil, 1, "a3", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(5).
+					WillReturnRows(newTagRows().AddRow(5, "standup"))
+				mock.ExpectQuery("SELECT `RecurrenceStart` FROM ToDo").WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"RecurrenceStart"}).AddRow(standup))
+				mock.ExpectQuery("SELECT `Position` FROM ToDo WHERE `Position`>\\? AND `ID`<>\\?").WithArgs("a3", 5).
+					WillReturnRows(newPositionRows("a4"))
+				mock.ExpectExec("INSERT INTO ToDo").
+					WithArgs("standup notes", "", nextStandup, nextStandup.Add(time.Hour), 0, nil, nil, "FREQ=WEEKLY;BYDAY=MO", "Europe/Berlin", standup, "a3V", "").
+					WillReturnResult(sqlmock.NewResult(6, 1))
+				mock.ExpectExec("INSERT IGNORE INTO Tag").WithArgs("standup").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT IGNORE INTO ToDoTag").WithArgs(6, "standup").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSnapshot(mock, 1, 6)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_CREATE, 6)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(1, 6).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.CompleteResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          5,
+					Etag:        "1",
+					Title:       "standup notes",
+					Reminder:    standupTs,
+					Completed:   true,
+					CompletedAt: completedAt,
+					Due:         standupDueTs,
+					Recurrence:  "FREQ=WEEKLY;BYDAY=MO",
+					TimeZone:    "Europe/Berlin",
+					Tags:        []string{"standup"},
+					Position:    "a3",
+				},
+				Next: &v1.ToDo{
+					Id:         6,
+					Etag:       "1",
+					Title:      "standup notes",
+					Reminder:   nextStandupTs,
+					Due:        nextStandupDueTs,
+					Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+					TimeZone:   "Europe/Berlin",
+					Tags:       []string{"standup"},
+					Position:   "a3V",
+				},
 			},
-			wantErr: true,
 		},
 		{
-			name: "RowsAffected failed",
+			name: "Not found",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.DeleteRequest{
+				req: &v1.CompleteRequest{
 					Api: "v1",
 					Id:  1,
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("DELETE FROM ToDo").WithArgs(1).
-					WillReturnResult(sqlmock.NewErrorResult(errors.New("RowsAffected failed")))
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=TRUE").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
 		{
-			name: "Not Found",
+			name: "UPDATE failed",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.DeleteRequest{
+				req: &v1.CompleteRequest{
 					Api: "v1",
 					Id:  1,
 				},
 			},
 			mock: func() {
-				mock.ExpectExec("DELETE FROM ToDo").WithArgs(1).
-					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=TRUE").WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("UPDATE failed"))
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -536,19 +2590,19 @@ func Test_toDoServiceServer_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			got, err := tt.s.Delete(tt.args.ctx, tt.args.req)
+			got, err := tt.s.Complete(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("toDoServiceServer.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("toDoServiceServer.Complete() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err == nil && !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("toDoServiceServer.Delete() = %v, want %v", got, tt.want)
+				t.Errorf("toDoServiceServer.Complete() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_toDoServiceServer_ReadAll(t *testing.T) {
+func Test_toDoServiceServer_Reopen(t *testing.T) {
 	ctx := context.Background()
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -556,21 +2610,19 @@ func Test_toDoServiceServer_ReadAll(t *testing.T) {
 	}
 	defer db.Close()
 	s := NewToDoServiceServer(db)
-	tm1 := time.Now().In(time.UTC)
-	reminder1, _ := ptypes.TimestampProto(tm1)
-	tm2 := time.Now().In(time.UTC)
-	reminder2, _ := ptypes.TimestampProto(tm2)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
 
 	type args struct {
 		ctx context.Context
-		req *v1.ReadAllRequest
+		req *v1.ReopenRequest
 	}
 	tests := []struct {
 		name    string
 		s       v1.ToDoServiceServer
 		args    args
 		mock    func()
-		want    *v1.ReadAllResponse
+		want    *v1.ReopenResponse
 		wantErr bool
 	}{
 		{
@@ -578,76 +2630,99 @@ func Test_toDoServiceServer_ReadAll(t *testing.T) {
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.ReadAllRequest{
+				req: &v1.ReopenRequest{
 					Api: "v1",
+					Id:  1,
 				},
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder"}).
-					AddRow(1, "title 1", "description 1", tm1).
-					AddRow(2, "title 2", "description 2", tm2)
-				mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnRows(rows)
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=FALSE, `CompletedAt`=NULL, `Version`=`Version`\\+1 WHERE `ID`=\\? AND `Completed` AND `DeletedAt` IS NULL").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSnapshot(mock, 2, 1)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 1)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+				mock.ExpectCommit()
 			},
-			want: &v1.ReadAllResponse{
+			want: &v1.ReopenResponse{
 				Api: "v1",
-				ToDos: []*v1.ToDo{
-					{
-						Id:          1,
-						Title:       "title 1",
-						Description: "description 1",
-						Reminder:    reminder1,
-					},
-					{
-						Id:          2,
-						Title:       "title 2",
-						Description: "description 2",
-						Reminder:    reminder2,
-					},
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
 				},
 			},
 		},
 		{
-			name: "Empty",
+			name: "Already open",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.ReadAllRequest{
+				req: &v1.ReopenRequest{
 					Api: "v1",
+					Id:  1,
 				},
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"ID", "Title", "Description", "Reminder"})
-				mock.ExpectQuery("SELECT (.+) FROM ToDo").WillReturnRows(rows)
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=FALSE, `CompletedAt`=NULL").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WillReturnRows(newTagRows())
+				mock.ExpectCommit()
 			},
-			want: &v1.ReadAllResponse{
-				Api:   "v1",
-				ToDos: []*v1.ToDo{},
+			want: &v1.ReopenResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+				},
 			},
 		},
 		{
-			name: "Unsupported API",
+			name: "Not found",
 			s:    s,
 			args: args{
 				ctx: ctx,
-				req: &v1.ReadAllRequest{
+				req: &v1.ReopenRequest{
 					Api: "v1",
+					Id:  1,
 				},
 			},
-			mock:    func() {},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE ToDo SET `Completed`=FALSE, `CompletedAt`=NULL").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectRollback()
+			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			got, err := tt.s.ReadAll(tt.args.ctx, tt.args.req)
+			got, err := tt.s.Reopen(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("toDoServiceServer.ReadAll() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("toDoServiceServer.Reopen() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("toDoServiceServer.ReadAll() = %v, want %v", got, tt.want)
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.Reopen() = %v, want %v", got, tt.want)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
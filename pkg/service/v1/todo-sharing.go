@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// shareSubject validates the subject of a collaborator in request
+func shareSubject(subject string) (string, error) {
+	subject = strings.TrimSpace(subject)
+	if len(subject) == 0 {
+		return "", status.Error(codes.InvalidArgument, "subject field is required")
+	}
+	return subject, nil
+}
+
+// readCollaborators selects collaborators of a task in order of sharing, only the one with subject if it is set
+func readCollaborators(ctx context.Context, q queryer, toDoID int64, subject string) ([]*v1.Collaborator, error) {
+	conds := []condition{{sql: "`ToDoID`=?", args: []interface{}{toDoID}}}
+	if len(subject) > 0 {
+		conds = append(conds, condition{sql: "`Subject`=?", args: []interface{}{subject}})
+	}
+	sqlWhere, args := whereSQL(conds)
+	rows, err := q.QueryContext(ctx, "SELECT `Subject`, `Access`, `CreatedAt` FROM ToDoShare"+sqlWhere+" ORDER BY `CreatedAt`, `Subject`", args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoShare-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Collaborator{}
+	for rows.Next() {
+		var c v1.Collaborator
+		var access int32
+		var createdAt time.Time
+		if err := rows.Scan(&c.Subject, &access, &createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDoShare row-> "+err.Error())
+		}
+		c.Access = v1.AccessLevel(access)
+		if c.CreatedAt, err = ptypes.TimestampProto(createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+		}
+		list = append(list, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoShare-> "+err.Error())
+	}
+	return list, nil
+}
+
+// ShareTask grants a user viewer or editor access to a task of the caller
+func (s *toDoServiceServer) ShareTask(ctx context.Context, req *v1.ShareTaskRequest) (*v1.ShareTaskResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	subject, err := shareSubject(req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if req.Access != v1.AccessLevel_ACCESS_LEVEL_VIEWER && req.Access != v1.AccessLevel_ACCESS_LEVEL_EDITOR {
+		return nil, status.Error(codes.InvalidArgument, "access must be ACCESS_LEVEL_VIEWER or ACCESS_LEVEL_EDITOR")
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var collaborator *v1.Collaborator
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := callerScope(ctx).require(ctx, tx, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_OWNER); err != nil {
+			return err
+		}
+
+		// tasks in trash can't be shared
+		var owner string
+		err := tx.QueryRowContext(ctx, "SELECT `OwnerID` FROM ToDo WHERE `ID`=? AND `DeletedAt` IS NULL", req.ToDoId).Scan(&owner)
+		if err == sql.ErrNoRows {
+			return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.ToDoId))
+		}
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		if owner == subject {
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf("ToDo with ID='%d' is owned by '%s'", req.ToDoId, subject))
+		}
+
+		// sharing again changes the access and keeps the time the task was first shared
+		now := time.Now().UTC().Truncate(time.Second)
+		if _, err := tx.ExecContext(ctx, "INSERT INTO ToDoShare(`ToDoID`, `Subject`, `Access`, `CreatedAt`) VALUES(?,?,?,?) "+
+			"ON DUPLICATE KEY UPDATE `Access`=VALUES(`Access`)", req.ToDoId, subject, int32(req.Access), now); err != nil {
+			return status.Error(codes.Unknown, "failed to insert into ToDoShare-> "+err.Error())
+		}
+
+		list, err := readCollaborators(ctx, tx, req.ToDoId, subject)
+		if err != nil {
+			return err
+		}
+		if len(list) != 1 {
+			return status.Error(codes.Unknown, fmt.Sprintf("failed to read back collaborator '%s' of ToDo with ID='%d'", subject, req.ToDoId))
+		}
+		collaborator = list[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ShareTaskResponse{
+		Api:          apiVersion,
+		Collaborator: collaborator,
+	}, nil
+}
+
+// UnshareTask revokes access of a user to a task of the caller
+func (s *toDoServiceServer) UnshareTask(ctx context.Context, req *v1.UnshareTaskRequest) (*v1.UnshareTaskResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	subject, err := shareSubject(req.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_OWNER); err != nil {
+		return nil, err
+	}
+
+	res, err := c.ExecContext(ctx, "DELETE FROM ToDoShare WHERE `ToDoID`=? AND `Subject`=?", req.ToDoId, subject)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete from ToDoShare-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not shared with '%s'", req.ToDoId, subject))
+	}
+
+	return &v1.UnshareTaskResponse{
+		Api:     apiVersion,
+		Removed: rows,
+	}, nil
+}
+
+// ListCollaborators returns users a task is shared with, in order of sharing
+func (s *toDoServiceServer) ListCollaborators(ctx context.Context, req *v1.ListCollaboratorsRequest) (*v1.ListCollaboratorsResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	if err := callerScope(ctx).require(ctx, c, req.ToDoId, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+		return nil, err
+	}
+	// collaborators of a task in trash are hidden with the task
+	if err := checkToDoExists(ctx, c, req.ToDoId); err != nil {
+		return nil, err
+	}
+
+	list, err := readCollaborators(ctx, c, req.ToDoId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ListCollaboratorsResponse{
+		Api:           apiVersion,
+		Collaborators: list,
+	}, nil
+}
@@ -0,0 +1,435 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+// expectAccess expects the access query of subject to task id, owned by owner and shared with subject at access
+func expectAccess(mock sqlmock.Sqlmock, id int64, subject, owner string, access v1.AccessLevel) {
+	mock.ExpectQuery("SELECT t.`OwnerID`, COALESCE\\(s.`Access`, 0\\) FROM ToDo t LEFT JOIN ToDoShare s").WithArgs(subject, id).
+		WillReturnRows(sqlmock.NewRows([]string{"OwnerID", "Access"}).AddRow(owner, int32(access)))
+}
+
+func newCollaboratorRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"Subject", "Access", "CreatedAt"})
+}
+
+func Test_toDoServiceServer_ShareTask(t *testing.T) {
+	alice := auth.NewContext(context.Background(), "alice")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	tm := time.Now().UTC().Truncate(time.Second)
+	createdAt, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ShareTaskRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ShareTaskResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.ShareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: " bob ",
+					Access:  v1.AccessLevel_ACCESS_LEVEL_EDITOR,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "alice", "alice", 0)
+				mock.ExpectQuery("SELECT `OwnerID` FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"OwnerID"}).AddRow("alice"))
+				mock.ExpectExec("INSERT INTO ToDoShare\\(`ToDoID`, `Subject`, `Access`, `CreatedAt`\\) VALUES\\(\\?,\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE `Access`=VALUES\\(`Access`\\)").
+					WithArgs(1, "bob", 2, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `Subject`, `Access`, `CreatedAt` FROM ToDoShare WHERE `ToDoID`=\\? AND `Subject`=\\?").WithArgs(1, "bob").
+					WillReturnRows(newCollaboratorRows().AddRow("bob", 2, tm))
+				mock.ExpectCommit()
+			},
+			want: &v1.ShareTaskResponse{
+				Api: "v1",
+				Collaborator: &v1.Collaborator{
+					Subject:   "bob",
+					Access:    v1.AccessLevel_ACCESS_LEVEL_EDITOR,
+					CreatedAt: createdAt,
+				},
+			},
+		},
+		{
+			name: "Editor of task",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(context.Background(), "bob"),
+				req: &v1.ShareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: "carol",
+					Access:  v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_EDITOR)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Task not found",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.ShareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: "bob",
+					Access:  v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT t.`OwnerID`").WithArgs("alice", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"OwnerID", "Access"}))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Share with owner",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.ShareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: "alice",
+					Access:  v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectAccess(mock, 1, "alice", "alice", 0)
+				mock.ExpectQuery("SELECT `OwnerID` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"OwnerID"}).AddRow("alice"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Owner access",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.ShareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: "bob",
+					Access:  v1.AccessLevel_ACCESS_LEVEL_OWNER,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Empty subject",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.ShareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: " ",
+					Access:  v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.ShareTaskRequest{
+					Api:     "v1000",
+					ToDoId:  1,
+					Subject: "bob",
+					Access:  v1.AccessLevel_ACCESS_LEVEL_VIEWER,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ShareTask(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ShareTask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ShareTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_UnshareTask(t *testing.T) {
+	alice := auth.NewContext(context.Background(), "alice")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.UnshareTaskRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.UnshareTaskResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.UnshareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: "bob",
+				},
+			},
+			mock: func() {
+				expectAccess(mock, 1, "alice", "alice", 0)
+				mock.ExpectExec("DELETE FROM ToDoShare WHERE `ToDoID`=\\? AND `Subject`=\\?").WithArgs(1, "bob").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: &v1.UnshareTaskResponse{
+				Api:     "v1",
+				Removed: 1,
+			},
+		},
+		{
+			name: "Not shared",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.UnshareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: "bob",
+				},
+			},
+			mock: func() {
+				expectAccess(mock, 1, "alice", "alice", 0)
+				mock.ExpectExec("DELETE FROM ToDoShare").WithArgs(1, "bob").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Viewer of task",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(context.Background(), "bob"),
+				req: &v1.UnshareTaskRequest{
+					Api:     "v1",
+					ToDoId:  1,
+					Subject: "bob",
+				},
+			},
+			mock: func() {
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_VIEWER)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty subject",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.UnshareTaskRequest{
+					Api:    "v1",
+					ToDoId: 1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: alice,
+				req: &v1.UnshareTaskRequest{
+					Api:     "v1000",
+					ToDoId:  1,
+					Subject: "bob",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.UnshareTask(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.UnshareTask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.UnshareTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_ListCollaborators(t *testing.T) {
+	bob := auth.NewContext(context.Background(), "bob")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	tm := time.Now().UTC().Truncate(time.Second)
+	createdAt, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListCollaboratorsRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListCollaboratorsResponse
+		wantErr bool
+	}{
+		{
+			name: "Viewer of task",
+			s:    s,
+			args: args{
+				ctx: bob,
+				req: &v1.ListCollaboratorsRequest{
+					Api:    "v1",
+					ToDoId: 1,
+				},
+			},
+			mock: func() {
+				expectAccess(mock, 1, "bob", "alice", v1.AccessLevel_ACCESS_LEVEL_VIEWER)
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT"}).AddRow(1))
+				mock.ExpectQuery("SELECT `Subject`, `Access`, `CreatedAt` FROM ToDoShare WHERE `ToDoID`=\\? ORDER BY `CreatedAt`, `Subject`").WithArgs(1).
+					WillReturnRows(newCollaboratorRows().AddRow("bob", 1, tm).AddRow("carol", 2, tm))
+			},
+			want: &v1.ListCollaboratorsResponse{
+				Api: "v1",
+				Collaborators: []*v1.Collaborator{
+					{Subject: "bob", Access: v1.AccessLevel_ACCESS_LEVEL_VIEWER, CreatedAt: createdAt},
+					{Subject: "carol", Access: v1.AccessLevel_ACCESS_LEVEL_EDITOR, CreatedAt: createdAt},
+				},
+			},
+		},
+		{
+			name: "Not shared",
+			s:    s,
+			args: args{
+				ctx: context.Background(),
+				req: &v1.ListCollaboratorsRequest{
+					Api:    "v1",
+					ToDoId: 1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoShare").WithArgs(1).
+					WillReturnRows(newCollaboratorRows())
+			},
+			want: &v1.ListCollaboratorsResponse{
+				Api:           "v1",
+				Collaborators: []*v1.Collaborator{},
+			},
+		},
+		{
+			name: "Task of another owner",
+			s:    s,
+			args: args{
+				ctx: bob,
+				req: &v1.ListCollaboratorsRequest{
+					Api:    "v1",
+					ToDoId: 1,
+				},
+			},
+			mock: func() {
+				expectAccess(mock, 1, "bob", "alice", 0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: bob,
+				req: &v1.ListCollaboratorsRequest{
+					Api:    "v1000",
+					ToDoId: 1,
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListCollaborators(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ListCollaborators() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ListCollaborators() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,547 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// maxTagLength is the maximum length of a tag name in characters
+const maxTagLength = 100
+
+// queryer is implemented by both *sql.Conn and *sql.Tx
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// normalizeTags trims tag names and removes duplicates, keeping the order
+func normalizeTags(names []string) ([]string, error) {
+	list := make([]string, 0, len(names))
+	seen := map[string]bool{}
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if len(n) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "tag name must not be empty")
+		}
+		if utf8.RuneCountInString(n) > maxTagLength {
+			return nil, status.Errorf(codes.InvalidArgument, "tag name '%s' is longer than %d characters", n, maxTagLength)
+		}
+		if !seen[n] {
+			seen[n] = true
+			list = append(list, n)
+		}
+	}
+	return list, nil
+}
+
+// placeholders returns n comma separated SQL parameter placeholders
+func placeholders(n int) string {
+	return "?" + strings.Repeat(",?", n-1)
+}
+
+// stringArgs converts strings to query arguments
+func stringArgs(list []string) []interface{} {
+	args := make([]interface{}, 0, len(list))
+	for _, s := range list {
+		args = append(args, s)
+	}
+	return args
+}
+
+// addTags creates missing tags and links them to the task
+func addTags(ctx context.Context, q queryer, id int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if _, err := q.ExecContext(ctx, "INSERT IGNORE INTO Tag(`Name`) VALUES"+strings.TrimPrefix(strings.Repeat(",(?)", len(names)), ","), stringArgs(names)...); err != nil {
+		return status.Error(codes.Unknown, "failed to insert into Tag-> "+err.Error())
+	}
+	args := append([]interface{}{id}, stringArgs(names)...)
+	if _, err := q.ExecContext(ctx, "INSERT IGNORE INTO ToDoTag(`ToDoID`, `TagID`) SELECT ?, `ID` FROM Tag WHERE `Name` IN ("+placeholders(len(names))+")", args...); err != nil {
+		return status.Error(codes.Unknown, "failed to insert into ToDoTag-> "+err.Error())
+	}
+	return nil
+}
+
+// loadTags sets tags of all tasks in list with a single query
+func loadTags(ctx context.Context, q queryer, list []*v1.ToDo) error {
+	if len(list) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*v1.ToDo, len(list))
+	ids := make([]interface{}, 0, len(list))
+	for _, td := range list {
+		byID[td.Id] = td
+		ids = append(ids, td.Id)
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT tt.`ToDoID`, t.`Name` FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` "+
+		"WHERE tt.`ToDoID` IN ("+placeholders(len(ids))+") ORDER BY t.`Name`", ids...)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDoTag-> "+err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve field values from ToDoTag row-> "+err.Error())
+		}
+		if td, ok := byID[id]; ok {
+			td.Tags = append(td.Tags, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve data from ToDoTag-> "+err.Error())
+	}
+	return nil
+}
+
+// tagCondition returns the condition selecting tasks with any or all of the tags
+func tagCondition(names []string, match v1.TagMatch) (condition, error) {
+	switch match {
+	case v1.TagMatch_TAG_MATCH_ANY:
+		return condition{
+			sql: "`ID` IN (SELECT tt.`ToDoID` FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` " +
+				"WHERE t.`Name` IN (" + placeholders(len(names)) + "))",
+			args: stringArgs(names),
+		}, nil
+	case v1.TagMatch_TAG_MATCH_ALL:
+		return condition{
+			sql: "`ID` IN (SELECT tt.`ToDoID` FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` " +
+				"WHERE t.`Name` IN (" + placeholders(len(names)) + ") GROUP BY tt.`ToDoID` HAVING COUNT(*)=?)",
+			args: append(stringArgs(names), len(names)),
+		}, nil
+	}
+	return condition{}, status.Errorf(codes.InvalidArgument, "tag_match has unknown value %d", match)
+}
+
+// taggedConditions selects tasks with the tag the scope reaches with at least the access
+func taggedConditions(scope taskScope, name string, access v1.AccessLevel) []condition {
+	return append([]condition{{
+		sql:  "`ID` IN (SELECT tt.`ToDoID` FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` WHERE t.`Name`=?)",
+		args: []interface{}{name},
+	}}, scope.conditions(access)...)
+}
+
+// touchTagged bumps the version of the tasks with the tag the scope edits and records the change,
+// as their tags are about to change. It returns IDs of the tasks and their snapshots before the change.
+func touchTagged(ctx context.Context, q queryer, scope taskScope, name string) ([]interface{}, map[int64]*v1.ToDo, error) {
+	sqlWhere, args := whereSQL(taggedConditions(scope, name, v1.AccessLevel_ACCESS_LEVEL_EDITOR))
+	rows, err := q.QueryContext(ctx, "SELECT `ID` FROM ToDo"+sqlWhere+" FOR UPDATE", args...)
+	if err != nil {
+		return nil, nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	var ids []interface{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+		}
+		ids = append(ids, id)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+	before, err := snapshotToDos(ctx, q, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := "`ID` IN (" + placeholders(len(ids)) + ")"
+	if _, err := q.ExecContext(ctx, "UPDATE ToDo SET `Version`=`Version`+1 WHERE "+in, ids...); err != nil {
+		return nil, nil, status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+	}
+	if err := recordEvents(ctx, q, v1.EventType_EVENT_TYPE_UPDATED, in, ids...); err != nil {
+		return nil, nil, err
+	}
+	return ids, before, nil
+}
+
+// recordTagged records the change of the tags of the tasks with their snapshots before it
+func recordTagged(ctx context.Context, q queryer, ids []interface{}, before map[int64]*v1.ToDo) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	after, err := snapshotToDos(ctx, q, ids)
+	if err != nil {
+		return err
+	}
+	return recordHistory(ctx, q, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids, before, after)
+}
+
+// countTagged returns the number of tasks with the tag the scope views
+func countTagged(ctx context.Context, q queryer, scope taskScope, name string) (int64, error) {
+	sqlWhere, args := whereSQL(taggedConditions(scope, name, v1.AccessLevel_ACCESS_LEVEL_VIEWER))
+	var count int64
+	if err := q.QueryRowContext(ctx, "SELECT COUNT(*) FROM ToDo"+sqlWhere, args...).Scan(&count); err != nil {
+		return 0, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	return count, nil
+}
+
+// retagToDos replaces the tag with the new one on the tasks, the tag itself is kept for tasks of other owners
+func retagToDos(ctx context.Context, q queryer, ids []interface{}, name, newName string) error {
+	if _, err := q.ExecContext(ctx, "INSERT IGNORE INTO Tag(`Name`) VALUES(?)", newName); err != nil {
+		return status.Error(codes.Unknown, "failed to insert into Tag-> "+err.Error())
+	}
+	args := append([]interface{}{newName}, ids...)
+	if _, err := q.ExecContext(ctx, "INSERT IGNORE INTO ToDoTag(`ToDoID`, `TagID`) SELECT d.`ID`, t.`ID` FROM ToDo d JOIN Tag t ON t.`Name`=? "+
+		"WHERE d.`ID` IN ("+placeholders(len(ids))+")", args...); err != nil {
+		return status.Error(codes.Unknown, "failed to insert into ToDoTag-> "+err.Error())
+	}
+	return untagToDos(ctx, q, ids, name)
+}
+
+// untagToDos removes the tag from the tasks
+func untagToDos(ctx context.Context, q queryer, ids []interface{}, name string) error {
+	args := append([]interface{}{name}, ids...)
+	if _, err := q.ExecContext(ctx, "DELETE tt FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` "+
+		"WHERE t.`Name`=? AND tt.`ToDoID` IN ("+placeholders(len(ids))+")", args...); err != nil {
+		return status.Error(codes.Unknown, "failed to delete from ToDoTag-> "+err.Error())
+	}
+	return nil
+}
+
+// ListTags returns all tags with the number of tasks using them. Callers see the tags of the tasks they view
+// and count these tasks only, unused tags are listed if authentication is disabled.
+func (s *toDoServiceServer) ListTags(ctx context.Context, req *v1.ListTagsRequest) (*v1.ListTagsResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	query := "SELECT t.`Name`, COUNT(tt.`ToDoID`) FROM Tag t LEFT JOIN ToDoTag tt ON tt.`TagID`=t.`ID` "
+	var args []interface{}
+	if conds := callerScope(ctx).conditions(v1.AccessLevel_ACCESS_LEVEL_VIEWER); len(conds) > 0 {
+		var sqlWhere string
+		sqlWhere, args = whereSQL(conds)
+		query = "SELECT t.`Name`, COUNT(tt.`ToDoID`) FROM Tag t JOIN ToDoTag tt ON tt.`TagID`=t.`ID` " +
+			"WHERE tt.`ToDoID` IN (SELECT `ID` FROM ToDo" + sqlWhere + ") "
+	}
+	rows, err := c.QueryContext(ctx, query+"GROUP BY t.`ID`, t.`Name` ORDER BY t.`Name`", args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Tag-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Tag{}
+	for rows.Next() {
+		tag := new(v1.Tag)
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from Tag row-> "+err.Error())
+		}
+		list = append(list, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from Tag-> "+err.Error())
+	}
+
+	return &v1.ListTagsResponse{
+		Api:  apiVersion,
+		Tags: list,
+	}, nil
+}
+
+// RenameTag renames a tag on all tasks. A caller renames it on the tasks it edits only, the tag
+// is kept with its name on tasks of other owners.
+func (s *toDoServiceServer) RenameTag(ctx context.Context, req *v1.RenameTagRequest) (*v1.RenameTagResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	names, err := normalizeTags([]string{req.NewName})
+	if err != nil {
+		return nil, err
+	}
+	newName := names[0]
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	tag := &v1.Tag{Name: newName}
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if !scope.unlimited() {
+			// the tag is renamed on tasks of the caller by moving them to the new one
+			if newName != req.Name {
+				used, err := countTagged(ctx, tx, scope, newName)
+				if err != nil {
+					return err
+				}
+				if used > 0 {
+					return status.Error(codes.AlreadyExists, fmt.Sprintf("Tag '%s' already exists", newName))
+				}
+			}
+			ids, before, err := touchTagged(ctx, tx, scope, req.Name)
+			if err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				return status.Error(codes.NotFound, fmt.Sprintf("Tag '%s' is not found", req.Name))
+			}
+			if newName != req.Name {
+				if err := retagToDos(ctx, tx, ids, req.Name, newName); err != nil {
+					return err
+				}
+			}
+			if err := recordTagged(ctx, tx, ids, before); err != nil {
+				return err
+			}
+			tag.Count, err = countTagged(ctx, tx, scope, newName)
+			return err
+		}
+
+		var used int64
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM Tag WHERE `Name`=?", newName).Scan(&used); err != nil {
+			return status.Error(codes.Unknown, "failed to select from Tag-> "+err.Error())
+		}
+		if used > 0 && newName != req.Name {
+			return status.Error(codes.AlreadyExists, fmt.Sprintf("Tag '%s' already exists", newName))
+		}
+
+		ids, before, err := touchTagged(ctx, tx, scope, req.Name)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, "UPDATE Tag SET `Name`=? WHERE `Name`=?", newName, req.Name)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to update Tag-> "+err.Error())
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		}
+		if rows == 0 && newName != req.Name {
+			return status.Error(codes.NotFound, fmt.Sprintf("Tag '%s' is not found", req.Name))
+		}
+		if err := recordTagged(ctx, tx, ids, before); err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, "SELECT COUNT(tt.`ToDoID`) FROM Tag t LEFT JOIN ToDoTag tt ON tt.`TagID`=t.`ID` WHERE t.`Name`=? GROUP BY t.`ID`", newName).Scan(&tag.Count)
+		if err == sql.ErrNoRows {
+			return status.Error(codes.NotFound, fmt.Sprintf("Tag '%s' is not found", req.Name))
+		}
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to select from Tag-> "+err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.RenameTagResponse{
+		Api: apiVersion,
+		Tag: tag,
+	}, nil
+}
+
+// DeleteTag removes a tag from all tasks and deletes it. A caller removes it from the tasks it edits only,
+// the tag is deleted once no task uses it.
+func (s *toDoServiceServer) DeleteTag(ctx context.Context, req *v1.DeleteTagRequest) (*v1.DeleteTagResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	var rows int64
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		ids, before, err := touchTagged(ctx, tx, scope, req.Name)
+		if err != nil {
+			return err
+		}
+		if !scope.unlimited() {
+			if len(ids) == 0 {
+				return status.Error(codes.NotFound, fmt.Sprintf("Tag '%s' is not found", req.Name))
+			}
+			if err := untagToDos(ctx, tx, ids, req.Name); err != nil {
+				return err
+			}
+			if err := recordTagged(ctx, tx, ids, before); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, "DELETE FROM Tag WHERE `Name`=? AND `ID` NOT IN (SELECT `TagID` FROM ToDoTag)", req.Name); err != nil {
+				return status.Error(codes.Unknown, "failed to delete Tag-> "+err.Error())
+			}
+			rows = 1
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE tt FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` WHERE t.`Name`=?", req.Name); err != nil {
+			return status.Error(codes.Unknown, "failed to delete from ToDoTag-> "+err.Error())
+		}
+		if err := recordTagged(ctx, tx, ids, before); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, "DELETE FROM Tag WHERE `Name`=?", req.Name)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to delete Tag-> "+err.Error())
+		}
+		if rows, err = res.RowsAffected(); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		}
+		if rows == 0 {
+			return status.Error(codes.NotFound, fmt.Sprintf("Tag '%s' is not found", req.Name))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.DeleteTagResponse{
+		Api:     apiVersion,
+		Deleted: rows,
+	}, nil
+}
+
+// AddTags adds tags to a task, creating new tags
+func (s *toDoServiceServer) AddTags(ctx context.Context, req *v1.AddTagsRequest) (*v1.AddTagsResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	names, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	var td *v1.ToDo
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := scope.require(ctx, tx, req.Id, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+			return err
+		}
+		ids := []interface{}{req.Id}
+		before, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		if err := touchToDo(ctx, tx, req.Id); err != nil {
+			return err
+		}
+		if err := addTags(ctx, tx, req.Id, names); err != nil {
+			return err
+		}
+		if err := recordTagged(ctx, tx, ids, before); err != nil {
+			return err
+		}
+		if td, err = readToDo(ctx, tx, req.Id, false); err != nil {
+			return err
+		}
+		return loadAccess(ctx, tx, scope, []*v1.ToDo{td})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.AddTagsResponse{
+		Api:  apiVersion,
+		ToDo: td,
+	}, nil
+}
+
+// RemoveTags removes tags from a task, tags are kept even if no task uses them
+func (s *toDoServiceServer) RemoveTags(ctx context.Context, req *v1.RemoveTagsRequest) (*v1.RemoveTagsResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	names, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	var td *v1.ToDo
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := scope.require(ctx, tx, req.Id, v1.AccessLevel_ACCESS_LEVEL_EDITOR); err != nil {
+			return err
+		}
+		ids := []interface{}{req.Id}
+		before, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		if err := touchToDo(ctx, tx, req.Id); err != nil {
+			return err
+		}
+		if len(names) > 0 {
+			args := append([]interface{}{req.Id}, stringArgs(names)...)
+			if _, err := tx.ExecContext(ctx, "DELETE tt FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` "+
+				"WHERE tt.`ToDoID`=? AND t.`Name` IN ("+placeholders(len(names))+")", args...); err != nil {
+				return status.Error(codes.Unknown, "failed to delete from ToDoTag-> "+err.Error())
+			}
+		}
+		if err := recordTagged(ctx, tx, ids, before); err != nil {
+			return err
+		}
+		if td, err = readToDo(ctx, tx, req.Id, false); err != nil {
+			return err
+		}
+		return loadAccess(ctx, tx, scope, []*v1.ToDo{td})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.RemoveTagsResponse{
+		Api:  apiVersion,
+		ToDo: td,
+	}, nil
+}
@@ -0,0 +1,699 @@
+package v1
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+// expectTouchTagged expects tasks with the tag the subject edits to be selected, bumped to a new version
+// and their changes recorded, the subject is empty if authentication is disabled
+func expectTouchTagged(mock sqlmock.Sqlmock, name, subject string, ids ...int64) {
+	rows := sqlmock.NewRows([]string{"ID"})
+	args := make([]driver.Value, 0, len(ids))
+	for _, id := range ids {
+		rows.AddRow(id)
+		args = append(args, id)
+	}
+	mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ID` IN \\(SELECT tt.`ToDoID` FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` WHERE t.`Name`=\\?\\)(.*) FOR UPDATE").
+		WithArgs(scopeArgs(subject, v1.AccessLevel_ACCESS_LEVEL_EDITOR, name)...).
+		WillReturnRows(rows)
+	if len(ids) == 0 {
+		return
+	}
+	expectSnapshot(mock, 1, ids...)
+	mock.ExpectExec("UPDATE ToDo SET `Version`=`Version`\\+1 WHERE `ID` IN").WithArgs(args...).
+		WillReturnResult(sqlmock.NewResult(0, int64(len(ids))))
+	mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(append([]driver.Value{2}, args...)...).
+		WillReturnResult(sqlmock.NewResult(0, int64(len(ids))))
+}
+
+// expectRetagged expects the tag change of the tasks expectTouchTagged selected to be recorded in history
+func expectRetagged(mock sqlmock.Sqlmock, subject string, ids ...int64) {
+	expectSnapshot(mock, 2, ids...)
+	expectHistoryBy(mock, subject, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids...)
+}
+
+// scopeArgs returns the query arguments followed by the ones of the scope conditions of the subject
+func scopeArgs(subject string, access v1.AccessLevel, args ...driver.Value) []driver.Value {
+	if len(subject) == 0 {
+		return args
+	}
+	return append(args, subject, subject, int32(access))
+}
+
+func Test_toDoServiceServer_ListTags(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListTagsRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListTagsResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListTagsRequest{
+					Api: "v1",
+				},
+			},
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"Name", "Count"}).
+					AddRow("backend", 2).
+					AddRow("unused", 0)
+				mock.ExpectQuery("SELECT (.+) FROM Tag t LEFT JOIN ToDoTag").WillReturnRows(rows)
+			},
+			want: &v1.ListTagsResponse{
+				Api: "v1",
+				Tags: []*v1.Tag{
+					{Name: "backend", Count: 2},
+					{Name: "unused", Count: 0},
+				},
+			},
+		},
+		{
+			name: "Tags of viewed tasks",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.ListTagsRequest{
+					Api: "v1",
+				},
+			},
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"Name", "Count"}).
+					AddRow("backend", 1)
+				mock.ExpectQuery("SELECT (.+) FROM Tag t JOIN ToDoTag tt ON tt.`TagID`=t.`ID` "+
+					"WHERE tt.`ToDoID` IN \\(SELECT `ID` FROM ToDo WHERE \\(`OwnerID`=\\? OR (.+)\\) GROUP BY").
+					WithArgs("alice", "alice", int32(v1.AccessLevel_ACCESS_LEVEL_VIEWER)).
+					WillReturnRows(rows)
+			},
+			want: &v1.ListTagsResponse{
+				Api:  "v1",
+				Tags: []*v1.Tag{{Name: "backend", Count: 1}},
+			},
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListTagsRequest{
+					Api: "v2",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "SELECT failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListTagsRequest{
+					Api: "v1",
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Tag").WillReturnError(errors.New("SELECT failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListTags(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ListTags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ListTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_RenameTag(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.RenameTagRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.RenameTagResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameTagRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: " server ",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Tag").WithArgs("server").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+				expectTouchTagged(mock, "backend", "", 1, 2, 3)
+				mock.ExpectExec("UPDATE Tag SET `Name`=\\? WHERE `Name`=\\?").WithArgs("server", "backend").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRetagged(mock, "", 1, 2, 3)
+				mock.ExpectQuery("SELECT COUNT\\(tt.`ToDoID`\\) FROM Tag").WithArgs("server").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT"}).AddRow(3))
+				mock.ExpectCommit()
+			},
+			want: &v1.RenameTagResponse{
+				Api: "v1",
+				Tag: &v1.Tag{Name: "server", Count: 3},
+			},
+		},
+		{
+			name: "Name taken",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameTagRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "server",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Tag").WithArgs("server").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameTagRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "server",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Tag").WithArgs("server").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+				expectTouchTagged(mock, "backend", "")
+				mock.ExpectExec("UPDATE Tag").WithArgs("server", "backend").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Tasks the caller edits",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.RenameTagRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "server",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ID` IN \\(SELECT tt.`ToDoID`").
+					WithArgs(scopeArgs("alice", v1.AccessLevel_ACCESS_LEVEL_VIEWER, "server")...).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+				expectTouchTagged(mock, "backend", "alice", 1)
+				mock.ExpectExec("INSERT IGNORE INTO Tag\\(`Name`\\) VALUES\\(\\?\\)").WithArgs("server").
+					WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectExec("INSERT IGNORE INTO ToDoTag").WithArgs("server", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE tt FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` WHERE t.`Name`=\\? AND tt.`ToDoID` IN").WithArgs("backend", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRetagged(mock, "alice", 1)
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ID` IN \\(SELECT tt.`ToDoID`").
+					WithArgs(scopeArgs("alice", v1.AccessLevel_ACCESS_LEVEL_VIEWER, "server")...).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			want: &v1.RenameTagResponse{
+				Api: "v1",
+				Tag: &v1.Tag{Name: "server", Count: 1},
+			},
+		},
+		{
+			name: "Name taken on tasks the caller views",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.RenameTagRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "server",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").
+					WithArgs(scopeArgs("alice", v1.AccessLevel_ACCESS_LEVEL_VIEWER, "server")...).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Tag on tasks of other owners only",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.RenameTagRequest{
+					Api:     "v1",
+					Name:    "backend",
+					NewName: "server",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").
+					WithArgs(scopeArgs("alice", v1.AccessLevel_ACCESS_LEVEL_VIEWER, "server")...).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+				expectTouchTagged(mock, "backend", "alice")
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty new name",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RenameTagRequest{
+					Api:  "v1",
+					Name: "backend",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.RenameTag(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.RenameTag() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.RenameTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_DeleteTag(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.DeleteTagRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.DeleteTagResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteTagRequest{
+					Api:  "v1",
+					Name: "backend",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectTouchTagged(mock, "backend", "", 1, 2)
+				mock.ExpectExec("DELETE tt FROM ToDoTag").WithArgs("backend").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectRetagged(mock, "", 1, 2)
+				mock.ExpectExec("DELETE FROM Tag").WithArgs("backend").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteTagResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteTagRequest{
+					Api:  "v1",
+					Name: "backend",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectTouchTagged(mock, "backend", "")
+				mock.ExpectExec("DELETE tt FROM ToDoTag").WithArgs("backend").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM Tag").WithArgs("backend").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Tasks the caller edits",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.DeleteTagRequest{
+					Api:  "v1",
+					Name: "backend",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectTouchTagged(mock, "backend", "alice", 1)
+				mock.ExpectExec("DELETE tt FROM ToDoTag tt JOIN Tag t ON t.`ID`=tt.`TagID` WHERE t.`Name`=\\? AND tt.`ToDoID` IN").WithArgs("backend", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRetagged(mock, "alice", 1)
+				mock.ExpectExec("DELETE FROM Tag WHERE `Name`=\\? AND `ID` NOT IN \\(SELECT `TagID` FROM ToDoTag\\)").WithArgs("backend").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			want: &v1.DeleteTagResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "Tag on tasks of other owners only",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.DeleteTagRequest{
+					Api:  "v1",
+					Name: "backend",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectTouchTagged(mock, "backend", "alice")
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.DeleteTag(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.DeleteTag() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.DeleteTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_AddTags(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.AddTagsRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.AddTagsResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.AddTagsRequest{
+					Api:  "v1",
+					Id:   1,
+					Tags: []string{"oncall"},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Version`=`Version`\\+1 WHERE `ID`=\\?").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO Tag").WithArgs("oncall").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT IGNORE INTO ToDoTag").WithArgs(1, "oncall").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRetagged(mock, "", 1)
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).
+					WillReturnRows(newTagRows().AddRow(1, "backend").AddRow(1, "oncall"))
+				mock.ExpectCommit()
+			},
+			want: &v1.AddTagsResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					Tags:        []string{"backend", "oncall"},
+				},
+			},
+		},
+		{
+			name: "Task not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.AddTagsRequest{
+					Api:  "v1",
+					Id:   1,
+					Tags: []string{"oncall"},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE ToDo SET `Version`=`Version`\\+1 WHERE `ID`=\\?").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "INSERT failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.AddTagsRequest{
+					Api:  "v1",
+					Id:   1,
+					Tags: []string{"oncall"},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Version`=`Version`\\+1 WHERE `ID`=\\?").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT IGNORE INTO Tag").WithArgs("oncall").
+					WillReturnError(errors.New("INSERT failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.AddTags(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.AddTags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.AddTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_RemoveTags(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.RemoveTagsRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.RemoveTagsResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RemoveTagsRequest{
+					Api:  "v1",
+					Id:   1,
+					Tags: []string{"oncall", "missing"},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				expectSnapshot(mock, 1, 1)
+				mock.ExpectExec("UPDATE ToDo SET `Version`=`Version`\\+1 WHERE `ID`=\\?").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE tt FROM ToDoTag").WithArgs(1, "oncall", "missing").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRetagged(mock, "", 1)
+				mock.ExpectQuery("SELECT (.+) FROM ToDo").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).
+					WillReturnRows(newTagRows().AddRow(1, "backend"))
+				mock.ExpectCommit()
+			},
+			want: &v1.RemoveTagsResponse{
+				Api: "v1",
+				ToDo: &v1.ToDo{
+					Id:          1,
+					Etag:        "1",
+					Title:       "title",
+					Description: "description",
+					Reminder:    reminder,
+					Tags:        []string{"backend"},
+				},
+			},
+		},
+		{
+			name: "Task not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RemoveTagsRequest{
+					Api:  "v1",
+					Id:   1,
+					Tags: []string{"oncall"},
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID` IN").WithArgs(1).WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE ToDo SET `Version`=`Version`\\+1 WHERE `ID`=\\?").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.RemoveTags(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.RemoveTags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.RemoveTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/search"
+)
+
+// trashPurgeInterval is how often RunTrashPurger looks for expired tasks in trash
+const trashPurgeInterval = time.Hour
+
+// RunTrashPurger permanently deletes tasks that have been in trash longer than retention,
+// checking every trashPurgeInterval until ctx is done. It does nothing if retention is 0.
+func RunTrashPurger(ctx context.Context, api v1.ToDoServiceServer, retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+	for {
+		before, err := ptypes.TimestampProto(time.Now().Add(-retention))
+		if err == nil {
+			_, err = api.Purge(ctx, &v1.PurgeRequest{Api: apiVersion, DeletedBefore: before})
+		}
+		if err != nil {
+			log.Printf("failed to purge expired tasks from trash: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListDeleted returns tasks in trash, most recently deleted first
+func (s *toDoServiceServer) ListDeleted(ctx context.Context, req *v1.ListDeletedRequest) (*v1.ListDeletedResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := parseOrderBy("deleted_at desc")
+	if err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	conds := append([]condition{{sql: "`DeletedAt` IS NOT NULL"}}, scope.conditions(v1.AccessLevel_ACCESS_LEVEL_VIEWER)...)
+	list, nextPageToken, err := readPage(ctx, c, conds, keys, size, queryHash(), req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadAccess(ctx, c, scope, list); err != nil {
+		return nil, err
+	}
+
+	return &v1.ListDeletedResponse{
+		Api:           apiVersion,
+		ToDos:         list,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// Restore brings a task back from trash with the subtasks deleted together with it
+func (s *toDoServiceServer) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.RestoreResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var rows int64
+	ids := []interface{}{req.Id}
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		if err := callerScope(ctx).require(ctx, tx, req.Id, v1.AccessLevel_ACCESS_LEVEL_OWNER); err != nil {
+			return err
+		}
+
+		var deletedAt sql.NullTime
+		var parent sql.NullInt64
+		err := tx.QueryRowContext(ctx, "SELECT `DeletedAt`, `ParentID` FROM ToDo WHERE `ID`=?", req.Id).Scan(&deletedAt, &parent)
+		if err == sql.ErrNoRows {
+			return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found", req.Id))
+		}
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		if !deletedAt.Valid {
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf("ToDo with ID='%d' is not in trash", req.Id))
+		}
+
+		// a subtask can not be restored under a parent in trash
+		if parent.Valid {
+			err := checkToDoExists(ctx, tx, parent.Int64)
+			if status.Code(err) == codes.NotFound {
+				return status.Error(codes.FailedPrecondition, fmt.Sprintf("parent ToDo with ID='%d' is in trash, restore it first", parent.Int64))
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		// subtasks deleted with the task share its deletion time, the ones deleted before stay in trash
+		seen := map[int64]bool{req.Id: true}
+		for level := ids; len(level) > 0; {
+			if level, err = childIDs(ctx, tx, level, seen, "`DeletedAt`=?", deletedAt.Time); err != nil {
+				return err
+			}
+			ids = append(ids, level...)
+		}
+
+		before, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, "UPDATE ToDo SET `DeletedAt`=NULL, `Version`=`Version`+1 WHERE `ID` IN ("+placeholders(len(ids))+")", ids...)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+		}
+		if rows, err = res.RowsAffected(); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		}
+		after, err := snapshotToDos(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, tx, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids, before, after); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, v1.EventType_EVENT_TYPE_UPDATED, "`ID` IN ("+placeholders(len(ids))+")", ids...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// update search index
+	if err := s.reindex(ctx, c, ids); err != nil {
+		return nil, err
+	}
+
+	return &v1.RestoreResponse{
+		Api:      apiVersion,
+		Restored: rows,
+	}, nil
+}
+
+// reindex puts the tasks back to the search index
+func (s *toDoServiceServer) reindex(ctx context.Context, q queryer, ids []interface{}) error {
+	rows, err := q.QueryContext(ctx, "SELECT `ID`, `Title`, `Description`, `OwnerID` FROM ToDo WHERE `ID` IN ("+placeholders(len(ids))+")", ids...)
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var doc search.Document
+		if err := rows.Scan(&doc.ID, &doc.Title, &doc.Description, &doc.OwnerID); err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+		}
+		if err := s.search.Put(ctx, doc); err != nil {
+			return status.Error(codes.Unknown, "failed to index ToDo-> "+err.Error())
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	return nil
+}
+
+// Purge permanently deletes tasks in trash with their subtasks
+func (s *toDoServiceServer) Purge(ctx context.Context, req *v1.PurgeRequest) (*v1.PurgeResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	conds := []condition{{sql: "`DeletedAt` IS NOT NULL"}}
+	if req.Id != 0 {
+		conds = append(conds, condition{sql: "`ID`=?", args: []interface{}{req.Id}})
+	} else {
+		// subtasks in trash are purged with the top task of the deleted subtree
+		conds = append(conds, condition{sql: "(`ParentID` IS NULL OR `ParentID` NOT IN (SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL))"})
+	}
+	if req.DeletedBefore != nil {
+		before, err := ptypes.Timestamp(req.DeletedBefore)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "deleted_before field has invalid format->"+err.Error())
+		}
+		conds = append(conds, condition{sql: "`DeletedAt`<?", args: []interface{}{before}})
+	}
+	conds = append(conds, callerScope(ctx).conditions(v1.AccessLevel_ACCESS_LEVEL_OWNER)...)
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var purged int64
+	var blobs []int64
+	err = inTx(ctx, c, func(tx *sql.Tx) error {
+		sqlWhere, args := whereSQL(conds)
+		rows, err := tx.QueryContext(ctx, "SELECT `ID` FROM ToDo"+sqlWhere, args...)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		var roots []interface{}
+		seen := map[int64]bool{}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+			}
+			seen[id] = true
+			roots = append(roots, id)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+		}
+
+		if len(roots) == 0 {
+			if req.Id != 0 {
+				return status.Error(codes.NotFound, fmt.Sprintf("ToDo with ID='%d' is not found in trash", req.Id))
+			}
+			return nil
+		}
+
+		levels := [][]interface{}{roots}
+		for level := roots; len(level) > 0; {
+			if level, err = childIDs(ctx, tx, level, seen, "`DeletedAt` IS NOT NULL"); err != nil {
+				return err
+			}
+			if len(level) > 0 {
+				levels = append(levels, level)
+			}
+		}
+
+		// contents of attachments are deleted once their rows are
+		if s.blobs != nil {
+			var all []interface{}
+			for _, level := range levels {
+				all = append(all, level...)
+			}
+			if blobs, err = attachmentIDs(ctx, tx, all); err != nil {
+				return err
+			}
+		}
+
+		// delete deepest level first so no task outlives its parent
+		for i := len(levels) - 1; i >= 0; i-- {
+			res, err := tx.ExecContext(ctx, "DELETE FROM ToDo WHERE `ID` IN ("+placeholders(len(levels[i]))+")", levels[i]...)
+			if err != nil {
+				return status.Error(codes.Unknown, "failed to delete Todo-> "+err.Error())
+			}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+			}
+			purged += n
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(blobs) > 0 {
+		s.deleteBlobs(ctx, blobs)
+	}
+
+	return &v1.PurgeResponse{
+		Api:    apiVersion,
+		Purged: purged,
+	}, nil
+}
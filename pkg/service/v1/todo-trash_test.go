@@ -0,0 +1,465 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/blob"
+)
+
+func Test_toDoServiceServer_ListDeleted(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+	deleted1 := tm.Add(time.Hour)
+	deletedAt1, _ := ptypes.TimestampProto(deleted1)
+	deleted2 := tm.Add(time.Minute)
+	deletedAt2, _ := ptypes.TimestampProto(deleted2)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListDeletedRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListDeletedResponse
+		wantErr bool
+	}{
+		{
+			name: "First page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListDeletedRequest{
+					Api:      "v1",
+					PageSize: 1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `DeletedAt` IS NOT NULL ORDER BY `DeletedAt` DESC, `ID` LIMIT").WithArgs(2).
+					WillReturnRows(newToDoRows().
+						AddRow(2, "title 2", "", tm, false, nil, nil, 0, nil, nil, "", "", deleted1, 1, "", "").
+						AddRow(1, "title 1", "", tm, false, nil, nil, 0, nil, nil, "", "", deleted2, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(2).
+					WillReturnRows(newTagRows().AddRow(2, "backend"))
+			},
+			want: &v1.ListDeletedResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:        2,
+						Etag:      "1",
+						Title:     "title 2",
+						Reminder:  reminder,
+						Tags:      []string{"backend"},
+						DeletedAt: deletedAt1,
+					},
+				},
+				NextPageToken: encodePageToken(pageToken{Query: queryHash(), Values: []string{deleted1.Format(time.RFC3339Nano), "2"}}),
+			},
+		},
+		{
+			name: "Last page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListDeletedRequest{
+					Api:       "v1",
+					PageSize:  1,
+					PageToken: encodePageToken(pageToken{Query: queryHash(), Values: []string{deleted1.Format(time.RFC3339Nano), "2"}}),
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `DeletedAt` IS NOT NULL AND (.+) ORDER BY `DeletedAt` DESC, `ID` LIMIT").
+					WillReturnRows(newToDoRows().
+						AddRow(1, "title 1", "", tm, false, nil, nil, 0, nil, nil, "", "", deleted2, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+			},
+			want: &v1.ListDeletedResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:        1,
+						Etag:      "1",
+						Title:     "title 1",
+						Reminder:  reminder,
+						DeletedAt: deletedAt2,
+					},
+				},
+			},
+		},
+		{
+			name: "Page token for different listing",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListDeletedRequest{
+					Api:       "v1",
+					PageToken: encodePageToken(pageToken{Query: queryHash("", "", "", "TAG_MATCH_ANY", "false", "0"), Values: []string{"1"}}),
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListDeletedRequest{
+					Api: "v1000",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListDeleted(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ListDeleted() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ListDeleted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_Restore(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	deleted := time.Now().In(time.UTC)
+
+	type args struct {
+		ctx context.Context
+		req *v1.RestoreRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.RestoreResponse
+		wantErr bool
+	}{
+		{
+			name: "With subtasks deleted together",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RestoreRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `DeletedAt`, `ParentID` FROM ToDo").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"DeletedAt", "ParentID"}).AddRow(deleted, 1))
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo WHERE `ID`=\\? AND `DeletedAt` IS NULL").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN \\(\\?\\) AND `DeletedAt`=\\?").WithArgs(2, deleted).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(3))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN \\(\\?\\) AND `DeletedAt`=\\?").WithArgs(3, deleted).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				expectSnapshot(mock, 1, 2, 3)
+				mock.ExpectExec("UPDATE ToDo SET `DeletedAt`=NULL, `Version`=`Version`\\+1 WHERE `ID` IN \\(\\?,\\?\\)").WithArgs(2, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectSnapshot(mock, 2, 2, 3)
+				expectHistory(mock, v1.HistoryAction_HISTORY_ACTION_UPDATE, 2, 3)
+				mock.ExpectExec("INSERT INTO ToDoEvent").WithArgs(2, 2, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+				mock.ExpectQuery("SELECT `ID`, `Title`, `Description`, `OwnerID` FROM ToDo").WithArgs(2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"ID", "Title", "Description", "OwnerID"}).
+						AddRow(2, "title 2", "", "").
+						AddRow(3, "title 3", "", ""))
+			},
+			want: &v1.RestoreResponse{
+				Api:      "v1",
+				Restored: 2,
+			},
+		},
+		{
+			name: "Parent in trash",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RestoreRequest{
+					Api: "v1",
+					Id:  2,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `DeletedAt`, `ParentID` FROM ToDo").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"DeletedAt", "ParentID"}).AddRow(deleted, 1))
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not in trash",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RestoreRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `DeletedAt`, `ParentID` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"DeletedAt", "ParentID"}).AddRow(nil, nil))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.RestoreRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `DeletedAt`, `ParentID` FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"DeletedAt", "ParentID"}))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.Restore(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.Restore() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.Restore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toDoServiceServer_Purge(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	store, cleanup := newBlobStore(t)
+	defer cleanup()
+	if err := store.Put(ctx, attachmentKey(7), []byte("notes")); err != nil {
+		t.Fatalf("an error '%s' was not expected when storing a blob", err)
+	}
+	tm := time.Now().In(time.UTC)
+	before, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.PurgeRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.PurgeResponse
+		wantErr bool
+	}{
+		{
+			name: "Task with subtasks",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.PurgeRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL AND `ID`=\\?$").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN \\(\\?\\) AND `DeletedAt` IS NOT NULL").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(2))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN \\(\\?\\) AND `DeletedAt` IS NOT NULL").WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectExec("DELETE FROM ToDo").WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM ToDo").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.PurgeResponse{
+				Api:    "v1",
+				Purged: 2,
+			},
+		},
+		{
+			name: "Task with attachments",
+			s:    NewToDoServiceServer(db, WithAttachments(store, 8, attachmentTypes)),
+			args: args{
+				ctx: ctx,
+				req: &v1.PurgeRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL AND `ID`=\\?$").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN \\(\\?\\) AND `DeletedAt` IS NOT NULL").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectQuery("SELECT `ID` FROM Attachment WHERE `ToDoID` IN \\(\\?\\)").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(7))
+				mock.ExpectExec("DELETE FROM ToDo").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: &v1.PurgeResponse{
+				Api:    "v1",
+				Purged: 1,
+			},
+		},
+		{
+			name: "Expired tasks",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.PurgeRequest{
+					Api:           "v1",
+					DeletedBefore: before,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL AND \\(`ParentID` IS NULL OR (.+)\\) AND `DeletedAt`<\\?").WithArgs(tm).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1).AddRow(3))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN \\(\\?,\\?\\)").WithArgs(1, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectExec("DELETE FROM ToDo").WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			want: &v1.PurgeResponse{
+				Api:    "v1",
+				Purged: 2,
+			},
+		},
+		{
+			name: "Empty trash",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.PurgeRequest{
+					Api: "v1",
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL").
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectCommit()
+			},
+			want: &v1.PurgeResponse{
+				Api: "v1",
+			},
+		},
+		{
+			name: "Not in trash",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.PurgeRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL AND `ID`=\\?").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "DELETE failed",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.PurgeRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `DeletedAt` IS NOT NULL AND `ID`=\\?").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1))
+				mock.ExpectQuery("SELECT `ID` FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}))
+				mock.ExpectExec("DELETE FROM ToDo").WithArgs(1).
+					WillReturnError(errors.New("DELETE failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.Purge(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.Purge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.Purge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := store.Get(ctx, attachmentKey(7)); err != blob.ErrNotFound {
+		t.Errorf("toDoServiceServer.Purge() left contents of purged attachment, error = %v", err)
+	}
+}
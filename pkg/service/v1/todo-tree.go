@@ -0,0 +1,261 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// maxTreeDepth is the deepest nesting of subtasks server walks
+const maxTreeDepth = 32
+
+// nullableID converts an optional task ID to a query argument, nil if not set
+func nullableID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// checkParent verifies that task id can become a subtask of parent.
+// The parent must exist and must not be the task itself or one of its subtasks.
+// id is 0 for a task being created.
+func checkParent(ctx context.Context, q queryer, id int64, parent int64) error {
+	if parent == 0 {
+		return nil
+	}
+	if parent == id {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("ToDo with ID='%d' can not be its own parent", id))
+	}
+
+	// walk up from the new parent, reaching the task means it would become its own ancestor
+	cur := parent
+	for depth := 0; ; depth++ {
+		if depth >= maxTreeDepth {
+			return status.Errorf(codes.FailedPrecondition, "tasks can not be nested deeper than %d levels", maxTreeDepth)
+		}
+		var next sql.NullInt64
+		err := q.QueryRowContext(ctx, "SELECT `ParentID` FROM ToDo WHERE `ID`=? AND `DeletedAt` IS NULL", cur).Scan(&next)
+		if err == sql.ErrNoRows {
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf("parent ToDo with ID='%d' is not found", parent))
+		}
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		if !next.Valid {
+			return nil
+		}
+		if next.Int64 == id {
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf("ToDo with ID='%d' can not be moved under its own subtask", id))
+		}
+		cur = next.Int64
+	}
+}
+
+// readChildren selects direct subtasks of all parents the scope reaches with a single query
+// and appends them to children of their parent. Subtasks in trash are skipped unless showDeleted is set.
+func readChildren(ctx context.Context, q queryer, scope taskScope, parents []*v1.ToDo, showDeleted bool) ([]*v1.ToDo, error) {
+	if len(parents) == 0 {
+		return nil, nil
+	}
+	byID := make(map[int64]*v1.ToDo, len(parents))
+	ids := make([]interface{}, 0, len(parents))
+	for _, td := range parents {
+		byID[td.Id] = td
+		ids = append(ids, td.Id)
+	}
+
+	query := "SELECT " + toDoColumns + " FROM ToDo WHERE `ParentID` IN (" + placeholders(len(ids)) + ")"
+	if !showDeleted {
+		query += " AND `DeletedAt` IS NULL"
+	}
+	for _, cond := range scope.conditions(v1.AccessLevel_ACCESS_LEVEL_VIEWER) {
+		query += " AND " + cond.sql
+		ids = append(ids, cond.args...)
+	}
+	rows, err := q.QueryContext(ctx, query+" ORDER BY `ID`", ids...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	var list []*v1.ToDo
+	for rows.Next() {
+		td, err := scanToDo(rows)
+		if err != nil {
+			return nil, err
+		}
+		if p, ok := byID[td.ParentId]; ok {
+			p.Children = append(p.Children, td)
+		}
+		list = append(list, td)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	return list, nil
+}
+
+// readSubtree loads subtasks of td the scope reaches up to depth levels, with one query per level
+func readSubtree(ctx context.Context, q queryer, scope taskScope, td *v1.ToDo, depth int, showDeleted bool) error {
+	level := []*v1.ToDo{td}
+	var all []*v1.ToDo
+	for d := 0; d < depth && len(level) > 0; d++ {
+		var err error
+		if level, err = readChildren(ctx, q, scope, level, showDeleted); err != nil {
+			return err
+		}
+		all = append(all, level...)
+	}
+	if err := loadTags(ctx, q, all); err != nil {
+		return err
+	}
+	return loadAccess(ctx, q, scope, all)
+}
+
+// childIDs returns IDs of direct subtasks of parents matching cond that are not in seen, and adds them to seen
+func childIDs(ctx context.Context, q queryer, parents []interface{}, seen map[int64]bool, cond string, args ...interface{}) ([]interface{}, error) {
+	rows, err := q.QueryContext(ctx, "SELECT `ID` FROM ToDo WHERE `ParentID` IN ("+placeholders(len(parents))+") AND "+cond,
+		append(append([]interface{}(nil), parents...), args...)...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+	}
+	defer rows.Close()
+
+	var ids []interface{}
+	for rows.Next() {
+		var child int64
+		if err := rows.Scan(&child); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDo row-> "+err.Error())
+		}
+		if !seen[child] {
+			seen[child] = true
+			ids = append(ids, child)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDo-> "+err.Error())
+	}
+	return ids, nil
+}
+
+// subtreeIDs returns IDs of the task and its subtasks out of trash grouped by level, the task first.
+// If cascade is false, a task with subtasks is refused with FailedPrecondition.
+func subtreeIDs(ctx context.Context, q queryer, id int64, cascade bool) ([][]interface{}, error) {
+	levels := [][]interface{}{{id}}
+	seen := map[int64]bool{id: true}
+	for level := levels[0]; len(level) > 0; {
+		next, err := childIDs(ctx, q, level, seen, "`DeletedAt` IS NULL")
+		if err != nil {
+			return nil, err
+		}
+
+		if len(next) > 0 {
+			if !cascade {
+				return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("ToDo with ID='%d' has subtasks, set cascade to delete them", id))
+			}
+			levels = append(levels, next)
+		}
+		level = next
+	}
+	return levels, nil
+}
+
+// completeParents completes ancestors of the task whose subtasks are all completed,
+// stopping at the first ancestor with an open subtask
+func completeParents(ctx context.Context, q queryer, id int64, now time.Time) error {
+	cur := id
+	for depth := 0; depth < maxTreeDepth; depth++ {
+		var parent sql.NullInt64
+		if err := q.QueryRowContext(ctx, "SELECT `ParentID` FROM ToDo WHERE `ID`=?", cur).Scan(&parent); err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		if !parent.Valid {
+			return nil
+		}
+
+		var open int64
+		if err := q.QueryRowContext(ctx, "SELECT COUNT(*) FROM ToDo WHERE `ParentID`=? AND NOT `Completed` AND `DeletedAt` IS NULL", parent.Int64).Scan(&open); err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDo-> "+err.Error())
+		}
+		if open > 0 {
+			return nil
+		}
+
+		ids := []interface{}{parent.Int64}
+		before, err := snapshotToDos(ctx, q, ids)
+		if err != nil {
+			return err
+		}
+		res, err := q.ExecContext(ctx, "UPDATE ToDo SET `Completed`=TRUE, `CompletedAt`=?, `Version`=`Version`+1 WHERE `ID`=? AND NOT `Completed`", now, parent.Int64)
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to update ToDo-> "+err.Error())
+		}
+		completed, err := res.RowsAffected()
+		if err != nil {
+			return status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+		}
+		if completed > 0 {
+			after, err := snapshotToDos(ctx, q, ids)
+			if err != nil {
+				return err
+			}
+			if err := recordHistory(ctx, q, v1.HistoryAction_HISTORY_ACTION_UPDATE, ids, before, after); err != nil {
+				return err
+			}
+			if err := recordEvents(ctx, q, v1.EventType_EVENT_TYPE_UPDATED, "`ID`=?", parent.Int64); err != nil {
+				return err
+			}
+		}
+		cur = parent.Int64
+	}
+	return nil
+}
+
+// ReadChildren returns direct subtasks of a task
+func (s *toDoServiceServer) ReadChildren(ctx context.Context, req *v1.ReadChildrenRequest) (*v1.ReadChildrenResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	scope := callerScope(ctx)
+	if err := scope.require(ctx, c, req.Id, v1.AccessLevel_ACCESS_LEVEL_VIEWER); err != nil {
+		return nil, err
+	}
+	if err := checkToDoExists(ctx, c, req.Id); err != nil {
+		return nil, err
+	}
+
+	list, err := readChildren(ctx, c, scope, []*v1.ToDo{{Id: req.Id}}, false)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = []*v1.ToDo{}
+	}
+	if err := loadTags(ctx, c, list); err != nil {
+		return nil, err
+	}
+	if err := loadAccess(ctx, c, scope, list); err != nil {
+		return nil, err
+	}
+
+	return &v1.ReadChildrenResponse{
+		Api:   apiVersion,
+		ToDos: list,
+	}, nil
+}
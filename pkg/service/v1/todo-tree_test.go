@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+func Test_toDoServiceServer_ReadChildren(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	reminder, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ReadChildrenRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		args    args
+		mock    func()
+		want    *v1.ReadChildrenResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadChildrenRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(newToDoRows().
+						AddRow(2, "child 1", "", tm, false, nil, nil, 0, 1, nil, "", "", nil, 1, "", "").
+						AddRow(3, "child 2", "", tm, false, nil, nil, 0, 1, nil, "", "", nil, 1, "", ""))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(2, 3).
+					WillReturnRows(newTagRows().AddRow(3, "oncall"))
+			},
+			want: &v1.ReadChildrenResponse{
+				Api: "v1",
+				ToDos: []*v1.ToDo{
+					{
+						Id:       2,
+						Etag:     "1",
+						Title:    "child 1",
+						Reminder: reminder,
+						ParentId: 1,
+					},
+					{
+						Id:       3,
+						Etag:     "1",
+						Title:    "child 2",
+						Reminder: reminder,
+						ParentId: 1,
+						Tags:     []string{"oncall"},
+					},
+				},
+			},
+		},
+		{
+			name: "No children",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadChildrenRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ParentID` IN").WithArgs(1).
+					WillReturnRows(newToDoRows())
+			},
+			want: &v1.ReadChildrenResponse{
+				Api:   "v1",
+				ToDos: []*v1.ToDo{},
+			},
+		},
+		{
+			name: "Not found",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ReadChildrenRequest{
+					Api: "v1",
+					Id:  1,
+				},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM ToDo").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ReadChildren(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.ReadChildren() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toDoServiceServer.ReadChildren() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package v1
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// watchPollInterval is how often Watch looks for new events when it has sent all of them
+	watchPollInterval = time.Second
+
+	// watchBatchSize is the largest number of events Watch reads at once
+	watchBatchSize = 100
+
+	// eventCommitWindow is how long after an event is recorded its transaction may commit and still be read
+	eventCommitWindow = time.Minute
+
+	// maxEventGaps is the largest number of missing event IDs a reader waits for
+	maxEventGaps = 100
+)
+
+// event is a row of the ToDoEvent table
+type event struct {
+	id        int64
+	typ       v1.EventType
+	toDo      *v1.ToDo
+	createdAt time.Time
+}
+
+// recordEvents records an event of the type for the tasks matching the condition,
+// it runs in the transaction of the change so that the event is sent only if the change is committed
+func recordEvents(ctx context.Context, q queryer, typ v1.EventType, cond string, args ...interface{}) error {
+	if _, err := q.ExecContext(ctx, "INSERT INTO ToDoEvent(`Type`, `ToDoID`) SELECT ?, `ID` FROM ToDo WHERE "+cond,
+		append([]interface{}{int32(typ)}, args...)...); err != nil {
+		return status.Error(codes.Unknown, "failed to insert into ToDoEvent-> "+err.Error())
+	}
+	return nil
+}
+
+// eventCursor is the position of a reader of ToDoEvent. IDs are assigned when events are recorded,
+// so a transaction committing late adds events below IDs already read. The cursor keeps the IDs missing
+// below its position as gaps and reads them again until they show up or eventCommitWindow passes,
+// IDs of rolled back transactions never show up.
+type eventCursor struct {
+	// after is the greatest ID read
+	after int64
+	// gaps are the missing IDs below after with the time they were found missing
+	gaps map[int64]time.Time
+}
+
+// newEventCursor returns the cursor reading events after the ID
+func newEventCursor(after int64) *eventCursor {
+	return &eventCursor{after: after, gaps: map[int64]time.Time{}}
+}
+
+// advance moves the cursor over the event read at now, IDs skipped over become gaps
+func (c *eventCursor) advance(id int64, now time.Time) {
+	if id <= c.after {
+		delete(c.gaps, id)
+		return
+	}
+	gap := c.after + 1
+	if gap < id-maxEventGaps {
+		gap = id - maxEventGaps
+	}
+	for ; gap < id; gap++ {
+		c.gaps[gap] = now
+	}
+	c.after = id
+	c.expire(now)
+}
+
+// expire stops waiting for gaps found missing before eventCommitWindow, and for the lowest ones over maxEventGaps
+func (c *eventCursor) expire(now time.Time) {
+	for id, found := range c.gaps {
+		if now.Sub(found) > eventCommitWindow {
+			delete(c.gaps, id)
+		}
+	}
+	if over := len(c.gaps) - maxEventGaps; over > 0 {
+		for _, id := range c.gapIDs()[:over] {
+			delete(c.gaps, id)
+		}
+	}
+}
+
+// gapIDs returns the gaps in ascending order
+func (c *eventCursor) gapIDs() []int64 {
+	ids := make([]int64, 0, len(c.gaps))
+	for id := range c.gaps {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// formatResumeToken returns the resume token of the cursor, the greatest ID read followed by the gaps, e.g. "9" or "9-5.7"
+func formatResumeToken(c *eventCursor) string {
+	token := strconv.FormatInt(c.after, 10)
+	if len(c.gaps) == 0 {
+		return token
+	}
+	gaps := c.gapIDs()
+	list := make([]string, 0, len(gaps))
+	for _, id := range gaps {
+		list = append(list, strconv.FormatInt(id, 10))
+	}
+	return token + "-" + strings.Join(list, ".")
+}
+
+// parseResumeToken returns the cursor of the resume token, waiting for its gaps from now
+func parseResumeToken(token string, now time.Time) (*eventCursor, error) {
+	invalid := status.Error(codes.InvalidArgument, "resume_token field is invalid")
+	parts := strings.SplitN(token, "-", 2)
+	after, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || after < 0 {
+		return nil, invalid
+	}
+	c := newEventCursor(after)
+	if len(parts) == 2 {
+		for _, v := range strings.Split(parts[1], ".") {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || id <= 0 || id >= after {
+				return nil, invalid
+			}
+			c.gaps[id] = now
+		}
+		if len(c.gaps) > maxEventGaps {
+			return nil, invalid
+		}
+	}
+	return c, nil
+}
+
+// Watch streams task changes after resume token, or from now if it is empty, until client cancels
+func (s *toDoServiceServer) Watch(req *v1.WatchRequest, stream v1.ToDoService_WatchServer) error {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	var cursor *eventCursor
+	if len(req.ResumeToken) > 0 {
+		var err error
+		if cursor, err = parseResumeToken(req.ResumeToken, time.Now()); err != nil {
+			return err
+		}
+	} else {
+		after, err := s.lastEventID(ctx)
+		if err != nil {
+			return err
+		}
+		cursor = newEventCursor(after)
+	}
+
+	// the caller is sent events of the tasks it reaches when they are read, in the order they are committed
+	scope := callerScope(ctx)
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := s.readEvents(ctx, scope, cursor)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, e := range events {
+			cursor.advance(e.id, now)
+			if e.toDo == nil {
+				continue
+			}
+			if err := sendEvent(stream, e, formatResumeToken(cursor)); err != nil {
+				return err
+			}
+		}
+		cursor.expire(now)
+
+		// read on right away if there may be more events
+		if len(events) == watchBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// lastEventID returns the ID of the latest event, 0 if there are none
+func (s *toDoServiceServer) lastEventID(ctx context.Context) (int64, error) {
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	var id int64
+	if err := c.QueryRowContext(ctx, "SELECT COALESCE(MAX(`ID`), 0) FROM ToDoEvent").Scan(&id); err != nil {
+		return 0, status.Error(codes.Unknown, "failed to select from ToDoEvent-> "+err.Error())
+	}
+	return id, nil
+}
+
+// readEvents returns up to watchBatchSize events after the cursor and in its gaps in ID order, with the current state
+// of their tasks. Events of tasks the scope does not reach have no task, the cursor moves over them too.
+func (s *toDoServiceServer) readEvents(ctx context.Context, scope taskScope, cursor *eventCursor) ([]event, error) {
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	reached := "TRUE"
+	var args []interface{}
+	if conds := scope.conditions(v1.AccessLevel_ACCESS_LEVEL_VIEWER); len(conds) > 0 {
+		var sqlWhere string
+		sqlWhere, args = whereSQL(conds)
+		reached = "`ToDoID` IN (SELECT `ID` FROM ToDo" + sqlWhere + ")"
+	}
+	where := "`ID`>?"
+	args = append(args, cursor.after)
+	if gaps := cursor.gapIDs(); len(gaps) > 0 {
+		where += " OR `ID` IN (" + placeholders(len(gaps)) + ")"
+		for _, id := range gaps {
+			args = append(args, id)
+		}
+	}
+	rows, err := c.QueryContext(ctx, "SELECT `ID`, `Type`, `ToDoID`, `CreatedAt`, "+reached+" FROM ToDoEvent WHERE "+where+" ORDER BY `ID` LIMIT ?", append(args, watchBatchSize)...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from ToDoEvent-> "+err.Error())
+	}
+	defer rows.Close()
+
+	var events []event
+	for rows.Next() {
+		e := event{toDo: &v1.ToDo{}}
+		var typ int32
+		var ok bool
+		if err := rows.Scan(&e.id, &typ, &e.toDo.Id, &e.createdAt, &ok); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from ToDoEvent row-> "+err.Error())
+		}
+		e.typ = v1.EventType(typ)
+		if !ok {
+			e.toDo = nil
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from ToDoEvent-> "+err.Error())
+	}
+	rows.Close()
+
+	// only ID is left of a task purged from trash
+	for i, e := range events {
+		if e.toDo == nil {
+			continue
+		}
+		td, err := readToDo(ctx, c, e.toDo.Id, true)
+		switch {
+		case err == nil:
+			if err := loadAccess(ctx, c, scope, []*v1.ToDo{td}); err != nil {
+				return nil, err
+			}
+			events[i].toDo = td
+		case status.Code(err) != codes.NotFound:
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// sendEvent sends the event to the client with the resume token following it
+func sendEvent(stream v1.ToDoService_WatchServer, e event, resumeToken string) error {
+	t, err := ptypes.TimestampProto(e.createdAt)
+	if err != nil {
+		return status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+
+	return stream.Send(&v1.WatchResponse{
+		Api:         apiVersion,
+		Type:        e.typ,
+		ToDo:        e.toDo,
+		Time:        t,
+		ResumeToken: resumeToken,
+	})
+}
@@ -0,0 +1,263 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+// watchStream collects events sent by Watch and cancels the stream after the expected number of them
+type watchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	want   int
+	sent   []*v1.WatchResponse
+}
+
+func newWatchStream(want int) *watchStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &watchStream{ctx: ctx, cancel: cancel, want: want}
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(resp *v1.WatchResponse) error {
+	w.sent = append(w.sent, resp)
+	if len(w.sent) >= w.want {
+		w.cancel()
+	}
+	return nil
+}
+
+func newEventRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "Type", "ToDoID", "CreatedAt", "Reached"})
+}
+
+// withSubject makes the stream of a caller authenticated as subject
+func (w *watchStream) withSubject(subject string) *watchStream {
+	w.ctx = auth.NewContext(w.ctx, subject)
+	return w
+}
+
+func Test_toDoServiceServer_Watch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewToDoServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+
+	tests := []struct {
+		name    string
+		s       v1.ToDoServiceServer
+		req     *v1.WatchRequest
+		stream  *watchStream
+		mock    func()
+		want    []*v1.WatchResponse
+		wantErr bool
+	}{
+		{
+			name:   "Resume",
+			s:      s,
+			req:    &v1.WatchRequest{Api: "v1", ResumeToken: "5"},
+			stream: newWatchStream(2),
+			mock: func() {
+				mock.ExpectQuery("SELECT `ID`, `Type`, `ToDoID`, `CreatedAt`, TRUE FROM ToDoEvent WHERE `ID`>\\? ORDER BY `ID` LIMIT \\?").WithArgs(5, watchBatchSize).
+					WillReturnRows(newEventRows().AddRow(6, 1, 1, tm, true).AddRow(7, 3, 2, tm, true))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(2).
+					WillReturnRows(newToDoRows())
+			},
+			want: []*v1.WatchResponse{
+				{
+					Api:  "v1",
+					Type: v1.EventType_EVENT_TYPE_CREATED,
+					ToDo: &v1.ToDo{
+						Id:          1,
+						Etag:        "1",
+						Title:       "title",
+						Description: "description",
+						Reminder:    ts,
+					},
+					Time:        ts,
+					ResumeToken: "6",
+				},
+				{
+					Api:         "v1",
+					Type:        v1.EventType_EVENT_TYPE_DELETED,
+					ToDo:        &v1.ToDo{Id: 2},
+					Time:        ts,
+					ResumeToken: "7",
+				},
+			},
+		},
+		{
+			name:   "From now",
+			s:      s,
+			req:    &v1.WatchRequest{Api: "v1"},
+			stream: newWatchStream(1),
+			mock: func() {
+				mock.ExpectQuery("SELECT COALESCE\\(MAX\\(`ID`\\), 0\\) FROM ToDoEvent").
+					WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(7))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoEvent").WithArgs(7, watchBatchSize).
+					WillReturnRows(newEventRows().AddRow(8, 2, 1, tm, true))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+			},
+			want: []*v1.WatchResponse{
+				{
+					Api:  "v1",
+					Type: v1.EventType_EVENT_TYPE_UPDATED,
+					ToDo: &v1.ToDo{
+						Id:          1,
+						Etag:        "1",
+						Title:       "title",
+						Description: "description",
+						Reminder:    ts,
+					},
+					Time:        ts,
+					ResumeToken: "8",
+				},
+			},
+		},
+		{
+			name:   "Late commit",
+			s:      s,
+			req:    &v1.WatchRequest{Api: "v1", ResumeToken: "5"},
+			stream: newWatchStream(3),
+			mock: func() {
+				// event 7 is committed after event 8 has been read
+				mock.ExpectQuery("SELECT (.+) FROM ToDoEvent WHERE `ID`>\\? ORDER BY").WithArgs(5, watchBatchSize).
+					WillReturnRows(newEventRows().AddRow(6, 2, 1, tm, true).AddRow(8, 2, 1, tm, true))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(newToDoRows())
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(newToDoRows())
+				mock.ExpectQuery("SELECT (.+) FROM ToDoEvent WHERE `ID`>\\? OR `ID` IN \\(\\?\\) ORDER BY").WithArgs(8, 7, watchBatchSize).
+					WillReturnRows(newEventRows().AddRow(7, 2, 1, tm, true))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(newToDoRows())
+			},
+			want: []*v1.WatchResponse{
+				{Api: "v1", Type: v1.EventType_EVENT_TYPE_UPDATED, ToDo: &v1.ToDo{Id: 1}, Time: ts, ResumeToken: "6"},
+				{Api: "v1", Type: v1.EventType_EVENT_TYPE_UPDATED, ToDo: &v1.ToDo{Id: 1}, Time: ts, ResumeToken: "8-7"},
+				{Api: "v1", Type: v1.EventType_EVENT_TYPE_UPDATED, ToDo: &v1.ToDo{Id: 1}, Time: ts, ResumeToken: "8"},
+			},
+		},
+		{
+			name:   "Events of tasks of other owners",
+			s:      s,
+			req:    &v1.WatchRequest{Api: "v1", ResumeToken: "5"},
+			stream: newWatchStream(1).withSubject("alice"),
+			mock: func() {
+				mock.ExpectQuery("SELECT `ID`, `Type`, `ToDoID`, `CreatedAt`, `ToDoID` IN \\(SELECT `ID` FROM ToDo WHERE (.+)\\) FROM ToDoEvent WHERE `ID`>\\?").
+					WithArgs("alice", "alice", int32(v1.AccessLevel_ACCESS_LEVEL_VIEWER), 5, watchBatchSize).
+					WillReturnRows(newEventRows().AddRow(6, 2, 1, tm, false).AddRow(7, 2, 2, tm, true))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(2).
+					WillReturnRows(newToDoRows())
+			},
+			want: []*v1.WatchResponse{
+				{Api: "v1", Type: v1.EventType_EVENT_TYPE_UPDATED, ToDo: &v1.ToDo{Id: 2}, Time: ts, ResumeToken: "7"},
+			},
+		},
+		{
+			name:    "Invalid resume token",
+			s:       s,
+			req:     &v1.WatchRequest{Api: "v1", ResumeToken: "abc"},
+			stream:  newWatchStream(1),
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported API",
+			s:       s,
+			req:     &v1.WatchRequest{Api: "v1000"},
+			stream:  newWatchStream(1),
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			err := tt.s.Watch(tt.req, tt.stream)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toDoServiceServer.Watch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(tt.stream.sent, tt.want) {
+				t.Errorf("toDoServiceServer.Watch() sent %v, want %v", tt.stream.sent, tt.want)
+			}
+		})
+	}
+}
+
+func Test_eventCursor(t *testing.T) {
+	now := time.Now()
+	c := newEventCursor(5)
+
+	c.advance(6, now)
+	c.advance(9, now)
+	if got, want := formatResumeToken(c), "9-7.8"; got != want {
+		t.Errorf("formatResumeToken() after skipping IDs = %q, want %q", got, want)
+	}
+
+	c.advance(8, now)
+	if got, want := formatResumeToken(c), "9-7"; got != want {
+		t.Errorf("formatResumeToken() after a late commit = %q, want %q", got, want)
+	}
+
+	c.expire(now.Add(eventCommitWindow + time.Second))
+	if got, want := formatResumeToken(c), "9"; got != want {
+		t.Errorf("formatResumeToken() after the commit window = %q, want %q", got, want)
+	}
+
+	c.advance(9+2*maxEventGaps, now)
+	if got, want := c.gapIDs()[0], int64(9+maxEventGaps); len(c.gaps) != maxEventGaps || got != want {
+		t.Errorf("advance() over a long gap waits for %d IDs from %d, want %d from %d", len(c.gaps), got, maxEventGaps, want)
+	}
+}
+
+func Test_parseResumeToken(t *testing.T) {
+	tests := []struct {
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{token: "9", want: "9"},
+		{token: "9-7.3", want: "9-3.7"},
+		{token: "abc", wantErr: true},
+		{token: "-1", wantErr: true},
+		{token: "9-", wantErr: true},
+		{token: "9-10", wantErr: true},
+		{token: "9-0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			got, err := parseResumeToken(tt.token, time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseResumeToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && formatResumeToken(got) != tt.want {
+				t.Errorf("parseResumeToken() = %q, want %q", formatResumeToken(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+const (
+	// webhookPollInterval is how often RunWebhookDispatcher looks for new events and due deliveries
+	webhookPollInterval = 5 * time.Second
+
+	// webhookBatchSize is the largest number of deliveries attempted at once
+	webhookBatchSize = 100
+
+	// webhookLease is how long a server owns a delivery it is attempting
+	webhookLease = time.Minute
+
+	// webhookTimeout is how long a webhook has to respond
+	webhookTimeout = 10 * time.Second
+
+	// webhookMaxAttempts is the number of attempts after which a delivery fails
+	webhookMaxAttempts = 8
+
+	// webhookRetryBase is the delay before the second attempt, it doubles with every attempt
+	webhookRetryBase = 30 * time.Second
+
+	// webhookRetryMax is the longest delay between attempts
+	webhookRetryMax = time.Hour
+
+	// webhookErrorSize is the longest error message stored for a delivery
+	webhookErrorSize = 1024
+
+	// SignatureHeader is the header holding the HMAC-SHA256 of the payload keyed with the webhook secret
+	SignatureHeader = "X-Todo-Signature"
+
+	// DeliveryHeader is the header holding the ID of the delivery
+	DeliveryHeader = "X-Todo-Delivery"
+)
+
+// webhookPayload is the JSON body posted to webhooks
+type webhookPayload struct {
+	ID   int64           `json:"id,string"`
+	Type string          `json:"type"`
+	Time time.Time       `json:"time"`
+	ToDo json.RawMessage `json:"toDo"`
+}
+
+// delivery is a pending delivery with the webhook and event it is for
+type delivery struct {
+	id       int64
+	attempts int32
+	url      string
+	secret   string
+	event    event
+}
+
+// RunWebhookDispatcher posts task events to the webhooks subscribed to them,
+// checking every webhookPollInterval until ctx is done.
+// Failed deliveries are retried with exponential backoff until webhookMaxAttempts.
+func RunWebhookDispatcher(ctx context.Context, db *sql.DB) {
+	client := &http.Client{Timeout: webhookTimeout}
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := queueDeliveries(ctx, db, time.Now().UTC()); err != nil {
+			log.Printf("failed to queue webhook deliveries: %v", err)
+		}
+		// keep delivering while there may be more due deliveries
+		for {
+			n, err := dispatchDeliveries(ctx, db, client, time.Now().UTC())
+			if err != nil {
+				log.Printf("failed to dispatch webhook deliveries: %v", err)
+			}
+			if err != nil || n < webhookBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// queueDeliveries creates deliveries of the events recorded since the last call for the webhooks subscribed to them,
+// a webhook of an owner gets events of the tasks the owner views when they are queued.
+// IDs are assigned when events are recorded, so events of eventCommitWindow are queued again
+// to catch the ones committed after events with greater IDs, deliveries queued before are kept.
+func queueDeliveries(ctx context.Context, db *sql.DB, now time.Time) error {
+	// get database connection
+	c, err := (&dbService{db: db}).connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return inTx(ctx, c, func(tx *sql.Tx) error {
+		var last int64
+		if err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(`ID`), 0) FROM ToDoEvent").Scan(&last); err != nil {
+			return status.Error(codes.Unknown, "failed to select from ToDoEvent-> "+err.Error())
+		}
+
+		// another server may have queued some of the events already
+		if _, err := tx.ExecContext(ctx, "INSERT IGNORE INTO WebhookDelivery(`WebhookID`, `EventID`, `NextAttemptAt`, `CreatedAt`) "+
+			"SELECT w.`ID`, e.`ID`, ?, ? FROM Webhook w JOIN ToDoEvent e ON e.`ID`<=? "+
+			"AND (e.`ID`>w.`LastEventID` OR (e.`CreatedAt`>=? AND e.`CreatedAt`>w.`CreatedAt`)) "+
+			"WHERE (w.`EventTypes`='' OR FIND_IN_SET(e.`Type`, w.`EventTypes`)) AND (w.`OwnerID`='' "+
+			"OR EXISTS (SELECT 1 FROM ToDo t WHERE t.`ID`=e.`ToDoID` AND t.`OwnerID`=w.`OwnerID`) "+
+			"OR EXISTS (SELECT 1 FROM ToDoShare s WHERE s.`ToDoID`=e.`ToDoID` AND s.`Subject`=w.`OwnerID`))",
+			now, now, last, now.Add(-eventCommitWindow)); err != nil {
+			return status.Error(codes.Unknown, "failed to insert into WebhookDelivery-> "+err.Error())
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE Webhook SET `LastEventID`=? WHERE `LastEventID`<?", last, last); err != nil {
+			return status.Error(codes.Unknown, "failed to update Webhook-> "+err.Error())
+		}
+		return nil
+	})
+}
+
+// dispatchDeliveries attempts deliveries due at now which are not being attempted by another server,
+// it returns the number of deliveries it has attempted
+func dispatchDeliveries(ctx context.Context, q queryer, client *http.Client, now time.Time) (int, error) {
+	rows, err := q.QueryContext(ctx, "SELECT d.`ID`, d.`Attempts`, w.`URL`, w.`Secret`, e.`ID`, e.`Type`, e.`ToDoID`, e.`CreatedAt` "+
+		"FROM WebhookDelivery d JOIN Webhook w ON w.`ID`=d.`WebhookID` JOIN ToDoEvent e ON e.`ID`=d.`EventID` "+
+		"WHERE d.`Status`=? AND d.`NextAttemptAt`<=? ORDER BY d.`NextAttemptAt`, d.`ID` LIMIT ?",
+		int32(v1.DeliveryStatus_DELIVERY_STATUS_PENDING), now, webhookBatchSize)
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to select from WebhookDelivery-> "+err.Error())
+	}
+	var list []delivery
+	for rows.Next() {
+		var d delivery
+		var typ int32
+		var toDoID int64
+		if err := rows.Scan(&d.id, &d.attempts, &d.url, &d.secret, &d.event.id, &typ, &toDoID, &d.event.createdAt); err != nil {
+			rows.Close()
+			return 0, status.Error(codes.Unknown, "failed to retrieve field values from WebhookDelivery row-> "+err.Error())
+		}
+		d.event.typ = v1.EventType(typ)
+		d.event.toDo = &v1.ToDo{Id: toDoID}
+		list = append(list, d)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return 0, status.Error(codes.Unknown, "failed to retrieve data from WebhookDelivery-> "+err.Error())
+	}
+
+	for _, d := range list {
+		claimed, err := claimDelivery(ctx, q, d.id, now)
+		if err != nil {
+			return 0, err
+		}
+		if !claimed {
+			continue
+		}
+
+		// only ID is left of a task purged from trash
+		td, err := readToDo(ctx, q, d.event.toDo.Id, true)
+		switch {
+		case err == nil:
+			d.event.toDo = td
+		case status.Code(err) != codes.NotFound:
+			return 0, err
+		}
+
+		body, err := webhookBody(d.event)
+		if err != nil {
+			return 0, err
+		}
+		code, err := postWebhook(ctx, client, d, body)
+		if err := recordAttempt(ctx, q, d, code, err, now); err != nil {
+			return 0, err
+		}
+	}
+	return len(list), nil
+}
+
+// claimDelivery leases the delivery to this server, it returns false if another server holds the lease
+func claimDelivery(ctx context.Context, q queryer, id int64, now time.Time) (bool, error) {
+	res, err := q.ExecContext(ctx, "UPDATE WebhookDelivery SET `NextAttemptAt`=? WHERE `ID`=? AND `Status`=? AND `NextAttemptAt`<=?",
+		now.Add(webhookLease), id, int32(v1.DeliveryStatus_DELIVERY_STATUS_PENDING), now)
+	if err != nil {
+		return false, status.Error(codes.Unknown, "failed to update WebhookDelivery-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	return rows > 0, nil
+}
+
+// webhookBody returns the JSON payload of the event
+func webhookBody(e event) ([]byte, error) {
+	var m jsonpb.Marshaler
+	toDo, err := m.MarshalToString(e.toDo)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to marshal ToDo-> "+err.Error())
+	}
+	body, err := json.Marshal(webhookPayload{
+		ID:   e.id,
+		Type: e.typ.String(),
+		Time: e.createdAt,
+		ToDo: json.RawMessage(toDo),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to marshal webhook payload-> "+err.Error())
+	}
+	return body, nil
+}
+
+// signPayload returns the SignatureHeader value of the body
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// postWebhook posts the signed body to the webhook, it returns the response status code
+// and an error if the webhook has not responded with 2xx
+func postWebhook(ctx context.Context, client *http.Client, d delivery, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, signPayload(d.secret, body))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(d.id, 10))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryDelay returns the delay before the attempt following the given number of attempts
+func retryDelay(attempts int32) time.Duration {
+	delay := webhookRetryBase
+	for i := int32(1); i < attempts && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMax {
+		delay = webhookRetryMax
+	}
+	return delay
+}
+
+// recordAttempt records the result of a delivery attempt, scheduling a retry if it has failed
+func recordAttempt(ctx context.Context, q queryer, d delivery, code int, postErr error, now time.Time) error {
+	attempts := d.attempts + 1
+	var err error
+	if postErr == nil {
+		_, err = q.ExecContext(ctx, "UPDATE WebhookDelivery SET `Status`=?, `Attempts`=?, `ResponseCode`=?, `Error`='', `DeliveredAt`=? WHERE `ID`=?",
+			int32(v1.DeliveryStatus_DELIVERY_STATUS_SUCCEEDED), attempts, code, now, d.id)
+	} else {
+		msg := postErr.Error()
+		if len(msg) > webhookErrorSize {
+			msg = msg[:webhookErrorSize]
+		}
+		st := v1.DeliveryStatus_DELIVERY_STATUS_PENDING
+		if attempts >= webhookMaxAttempts {
+			st = v1.DeliveryStatus_DELIVERY_STATUS_FAILED
+		}
+		log.Printf("failed to deliver event %d to webhook %s: %v", d.event.id, d.url, postErr)
+		_, err = q.ExecContext(ctx, "UPDATE WebhookDelivery SET `Status`=?, `Attempts`=?, `ResponseCode`=?, `Error`=?, `NextAttemptAt`=? WHERE `ID`=?",
+			int32(st), attempts, code, msg, now.Add(retryDelay(attempts)), d.id)
+	}
+	if err != nil {
+		return status.Error(codes.Unknown, "failed to update WebhookDelivery-> "+err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// webhookRecorder is a webhook endpoint checking signatures of the payloads it is posted
+type webhookRecorder struct {
+	secret   string
+	code     int
+	payloads []webhookPayload
+	invalid  int
+}
+
+func (w *webhookRecorder) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	if r.Header.Get(SignatureHeader) != signPayload(w.secret, body) || len(r.Header.Get(DeliveryHeader)) == 0 {
+		w.invalid++
+	}
+	var p webhookPayload
+	if err := json.Unmarshal(body, &p); err == nil {
+		w.payloads = append(w.payloads, p)
+	}
+	rw.WriteHeader(w.code)
+}
+
+func newPendingRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "Attempts", "URL", "Secret", "EventID", "Type", "ToDoID", "CreatedAt"})
+}
+
+func Test_dispatchDeliveries(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	now := time.Now().In(time.UTC)
+	tm := now.Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		hook        *webhookRecorder
+		mock        func(url string)
+		want        int
+		wantPayload []int64
+		wantErr     bool
+	}{
+		{
+			name: "Delivered",
+			hook: &webhookRecorder{secret: "secret", code: http.StatusNoContent},
+			mock: func(url string) {
+				mock.ExpectQuery("SELECT (.+) FROM WebhookDelivery d JOIN Webhook w").WithArgs(0, now, webhookBatchSize).
+					WillReturnRows(newPendingRows().
+						AddRow(1, 0, url, "secret", 7, 1, 1, tm).
+						AddRow(2, 0, url, "secret", 8, 3, 2, tm))
+				mock.ExpectExec("UPDATE WebhookDelivery SET `NextAttemptAt`=\\?").WithArgs(now.Add(webhookLease), 1, 0, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectExec("UPDATE WebhookDelivery SET `Status`=\\?, `Attempts`=\\?, `ResponseCode`=\\?, `Error`='', `DeliveredAt`=\\?").
+					WithArgs(1, 1, http.StatusNoContent, now, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				// task 2 has been purged from trash
+				mock.ExpectExec("UPDATE WebhookDelivery SET `NextAttemptAt`=\\?").WithArgs(now.Add(webhookLease), 2, 0, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(2).
+					WillReturnRows(newToDoRows())
+				mock.ExpectExec("UPDATE WebhookDelivery SET `Status`=\\?, `Attempts`=\\?, `ResponseCode`=\\?, `Error`='', `DeliveredAt`=\\?").
+					WithArgs(1, 1, http.StatusNoContent, now, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:        2,
+			wantPayload: []int64{7, 8},
+		},
+		{
+			name: "Retry",
+			hook: &webhookRecorder{secret: "secret", code: http.StatusInternalServerError},
+			mock: func(url string) {
+				mock.ExpectQuery("SELECT (.+) FROM WebhookDelivery d JOIN Webhook w").WithArgs(0, now, webhookBatchSize).
+					WillReturnRows(newPendingRows().AddRow(1, 2, url, "secret", 7, 1, 1, tm))
+				mock.ExpectExec("UPDATE WebhookDelivery SET `NextAttemptAt`=\\?").WithArgs(now.Add(webhookLease), 1, 0, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectExec("UPDATE WebhookDelivery SET `Status`=\\?, `Attempts`=\\?, `ResponseCode`=\\?, `Error`=\\?, `NextAttemptAt`=\\?").
+					WithArgs(0, 3, http.StatusInternalServerError, "webhook responded with 500 Internal Server Error", now.Add(4*webhookRetryBase), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:        1,
+			wantPayload: []int64{7},
+		},
+		{
+			name: "Last attempt failed",
+			hook: &webhookRecorder{secret: "secret", code: http.StatusBadRequest},
+			mock: func(url string) {
+				mock.ExpectQuery("SELECT (.+) FROM WebhookDelivery d JOIN Webhook w").WithArgs(0, now, webhookBatchSize).
+					WillReturnRows(newPendingRows().AddRow(1, webhookMaxAttempts-1, url, "secret", 7, 1, 1, tm))
+				mock.ExpectExec("UPDATE WebhookDelivery SET `NextAttemptAt`=\\?").WithArgs(now.Add(webhookLease), 1, 0, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM ToDo WHERE `ID`=\\?$").WithArgs(1).
+					WillReturnRows(newToDoRows().AddRow(toDoRow(1, "title", "description", tm)...))
+				mock.ExpectQuery("SELECT (.+) FROM ToDoTag").WithArgs(1).WillReturnRows(newTagRows())
+				mock.ExpectExec("UPDATE WebhookDelivery SET `Status`=\\?").
+					WithArgs(2, webhookMaxAttempts, http.StatusBadRequest, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:        1,
+			wantPayload: []int64{7},
+		},
+		{
+			name: "Claimed by another server",
+			hook: &webhookRecorder{secret: "secret", code: http.StatusOK},
+			mock: func(url string) {
+				mock.ExpectQuery("SELECT (.+) FROM WebhookDelivery d JOIN Webhook w").WithArgs(0, now, webhookBatchSize).
+					WillReturnRows(newPendingRows().AddRow(1, 0, url, "secret", 7, 1, 1, tm))
+				mock.ExpectExec("UPDATE WebhookDelivery SET `NextAttemptAt`=\\?").WithArgs(now.Add(webhookLease), 1, 0, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.hook)
+			defer srv.Close()
+			tt.mock(srv.URL)
+			got, err := dispatchDeliveries(ctx, db, srv.Client(), now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("dispatchDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("dispatchDeliveries() = %v, want %v", got, tt.want)
+			}
+			if tt.hook.invalid > 0 {
+				t.Errorf("dispatchDeliveries() posted %d payloads with invalid signature", tt.hook.invalid)
+			}
+			var ids []int64
+			for _, p := range tt.hook.payloads {
+				ids = append(ids, p.ID)
+			}
+			if len(ids) != len(tt.wantPayload) {
+				t.Errorf("dispatchDeliveries() posted events %v, want %v", ids, tt.wantPayload)
+				return
+			}
+			for i := range ids {
+				if ids[i] != tt.wantPayload[i] {
+					t.Errorf("dispatchDeliveries() posted events %v, want %v", ids, tt.wantPayload)
+				}
+			}
+		})
+	}
+}
+
+func Test_queueDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	now := time.Now().In(time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(`ID`\\), 0\\) FROM ToDoEvent").
+		WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(9))
+	// events of the commit window are queued again, webhooks of owners get events of the tasks they own or are shared with
+	mock.ExpectExec("INSERT IGNORE INTO WebhookDelivery(.+) JOIN ToDoEvent e ON e.`ID`<=\\? "+
+		"AND \\(e.`ID`>w.`LastEventID` OR \\(e.`CreatedAt`>=\\? AND e.`CreatedAt`>w.`CreatedAt`\\)\\) (.+) AND \\(w.`OwnerID`='' "+
+		"OR EXISTS \\(SELECT 1 FROM ToDo t WHERE t.`ID`=e.`ToDoID` AND t.`OwnerID`=w.`OwnerID`\\) "+
+		"OR EXISTS \\(SELECT 1 FROM ToDoShare s WHERE s.`ToDoID`=e.`ToDoID` AND s.`Subject`=w.`OwnerID`\\)\\)").
+		WithArgs(now, now, 9, now.Add(-eventCommitWindow)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("UPDATE Webhook SET `LastEventID`=\\?").WithArgs(9, 9).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	if err := queueDeliveries(context.Background(), db, now); err != nil {
+		t.Errorf("queueDeliveries() error = %v", err)
+	}
+}
+
+func Test_retryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, webhookRetryBase},
+		{2, 2 * webhookRetryBase},
+		{4, 8 * webhookRetryBase},
+		{20, webhookRetryMax},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+)
+
+// webhookSecretSize is the number of random bytes in a generated webhook secret
+const webhookSecretSize = 32
+
+// webhookServiceServer is the implementation of v1.WebhookServiceServer proto interface
+type webhookServiceServer struct {
+	dbService
+}
+
+// NewWebhookServiceServer creates Webhook Service
+func NewWebhookServiceServer(db *sql.DB) v1.WebhookServiceServer {
+	return &webhookServiceServer{dbService: dbService{db: db}}
+}
+
+// formatEventTypes returns event types as stored in the `EventTypes` column
+func formatEventTypes(types []v1.EventType) (string, error) {
+	seen := map[v1.EventType]bool{}
+	var list []string
+	for _, t := range types {
+		if _, ok := v1.EventType_name[int32(t)]; !ok || t == v1.EventType_EVENT_TYPE_UNSPECIFIED {
+			return "", status.Errorf(codes.InvalidArgument, "event_types has unknown value %d", t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			list = append(list, strconv.Itoa(int(t)))
+		}
+	}
+	return strings.Join(list, ","), nil
+}
+
+// parseEventTypes parses the `EventTypes` column
+func parseEventTypes(s string) []v1.EventType {
+	var types []v1.EventType
+	for _, v := range strings.Split(s, ",") {
+		if t, err := strconv.Atoi(v); err == nil {
+			types = append(types, v1.EventType(t))
+		}
+	}
+	return types
+}
+
+// webhookConditions selects the webhook if the scope owns it
+func webhookConditions(scope taskScope, id int64) []condition {
+	return append([]condition{{sql: "`ID`=?", args: []interface{}{id}}}, scope.conditions(v1.AccessLevel_ACCESS_LEVEL_OWNER)...)
+}
+
+// CreateWebhook subscribes a URL to events of the tasks the caller views
+func (s *webhookServiceServer) CreateWebhook(ctx context.Context, req *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	if req.Webhook == nil {
+		return nil, status.Error(codes.InvalidArgument, "webhook field is required")
+	}
+	u, err := url.Parse(req.Webhook.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "url must be an absolute http or https URL")
+	}
+	types, err := formatEventTypes(req.Webhook.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Webhook.Secret
+	if len(secret) == 0 {
+		b := make([]byte, webhookSecretSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to generate secret-> "+err.Error())
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	// the webhook gets events recorded after it is created
+	now := time.Now().UTC().Truncate(time.Second)
+	owner := callerOwner(ctx)
+	res, err := c.ExecContext(ctx, "INSERT INTO Webhook(`URL`, `Secret`, `EventTypes`, `LastEventID`, `CreatedAt`, `OwnerID`) "+
+		"SELECT ?, ?, ?, COALESCE(MAX(`ID`), 0), ?, ? FROM ToDoEvent", req.Webhook.Url, secret, types, now, owner)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to insert into Webhook-> "+err.Error())
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve id for created Webhook-> "+err.Error())
+	}
+
+	createdAt, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+
+	return &v1.CreateWebhookResponse{
+		Api: apiVersion,
+		Webhook: &v1.Webhook{
+			Id:         id,
+			Url:        req.Webhook.Url,
+			EventTypes: parseEventTypes(types),
+			Secret:     secret,
+			CreatedAt:  createdAt,
+			OwnerId:    owner,
+		},
+	}, nil
+}
+
+// ListWebhooks returns webhooks of the caller without their secrets
+func (s *webhookServiceServer) ListWebhooks(ctx context.Context, req *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	sqlWhere, args := whereSQL(callerScope(ctx).conditions(v1.AccessLevel_ACCESS_LEVEL_OWNER))
+	rows, err := c.QueryContext(ctx, "SELECT `ID`, `URL`, `EventTypes`, `CreatedAt`, `OwnerID` FROM Webhook"+sqlWhere+" ORDER BY `ID`", args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Webhook-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.Webhook{}
+	for rows.Next() {
+		var w v1.Webhook
+		var types string
+		var createdAt time.Time
+		if err := rows.Scan(&w.Id, &w.Url, &types, &createdAt, &w.OwnerId); err != nil {
+			return nil, status.Error(codes.Unknown, "failed to retrieve field values from Webhook row-> "+err.Error())
+		}
+		w.EventTypes = parseEventTypes(types)
+		if w.CreatedAt, err = ptypes.TimestampProto(createdAt); err != nil {
+			return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+		}
+		list = append(list, &w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from Webhook-> "+err.Error())
+	}
+
+	return &v1.ListWebhooksResponse{
+		Api:      apiVersion,
+		Webhooks: list,
+	}, nil
+}
+
+// DeleteWebhook deletes a webhook of the caller with its deliveries
+func (s *webhookServiceServer) DeleteWebhook(ctx context.Context, req *v1.DeleteWebhookRequest) (*v1.DeleteWebhookResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	sqlWhere, args := whereSQL(webhookConditions(callerScope(ctx), req.Id))
+	res, err := c.ExecContext(ctx, "DELETE FROM Webhook"+sqlWhere, args...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to delete Webhook-> "+err.Error())
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve rows affected value-> "+err.Error())
+	}
+	if rows == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Webhook with ID='%d' is not found", req.Id))
+	}
+
+	return &v1.DeleteWebhookResponse{
+		Api:     apiVersion,
+		Deleted: rows,
+	}, nil
+}
+
+// ListWebhookDeliveries returns deliveries of a webhook of the caller, latest first
+func (s *webhookServiceServer) ListWebhookDeliveries(ctx context.Context, req *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error) {
+	// Validate requested API version is supported by server
+	if err := s.checkAPI(req.Api); err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// page token holds ID of the last delivery in the previous page
+	query := queryHash(strconv.FormatInt(req.WebhookId, 10))
+	conds := []condition{{sql: "d.`WebhookID`=?", args: []interface{}{req.WebhookId}}}
+	if len(req.PageToken) > 0 {
+		last, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if last.Query != query || len(last.Values) != 1 {
+			return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different webhook")
+		}
+		id, err := strconv.ParseInt(last.Values[0], 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "page_token has invalid format-> "+err.Error())
+		}
+		conds = append(conds, condition{sql: "d.`ID`<?", args: []interface{}{id}})
+	}
+
+	// get database connection
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var count int64
+	sqlWhere, args := whereSQL(webhookConditions(callerScope(ctx), req.WebhookId))
+	if err := c.QueryRowContext(ctx, "SELECT COUNT(*) FROM Webhook"+sqlWhere, args...).Scan(&count); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from Webhook-> "+err.Error())
+	}
+	if count == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Webhook with ID='%d' is not found", req.WebhookId))
+	}
+
+	// one extra row tells if there is a next page
+	sqlWhere, args = whereSQL(conds)
+	rows, err := c.QueryContext(ctx, "SELECT d.`ID`, d.`WebhookID`, d.`EventID`, e.`Type`, e.`ToDoID`, d.`Status`, d.`Attempts`, d.`ResponseCode`, d.`Error`, "+
+		"d.`NextAttemptAt`, d.`DeliveredAt`, d.`CreatedAt` FROM WebhookDelivery d JOIN ToDoEvent e ON e.`ID`=d.`EventID`"+sqlWhere+
+		" ORDER BY d.`ID` DESC LIMIT ?", append(args, size+1)...)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, "failed to select from WebhookDelivery-> "+err.Error())
+	}
+	defer rows.Close()
+
+	list := []*v1.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve data from WebhookDelivery-> "+err.Error())
+	}
+
+	var nextPageToken string
+	if len(list) > size {
+		list = list[:size]
+		nextPageToken = encodePageToken(pageToken{Query: query, Values: []string{strconv.FormatInt(list[size-1].Id, 10)}})
+	}
+
+	return &v1.ListWebhookDeliveriesResponse{
+		Api:           apiVersion,
+		Deliveries:    list,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// scanDelivery reads a WebhookDelivery row joined with its event
+func scanDelivery(rows *sql.Rows) (*v1.WebhookDelivery, error) {
+	var d v1.WebhookDelivery
+	var typ, st int32
+	var nextAttemptAt, createdAt time.Time
+	var deliveredAt sql.NullTime
+	if err := rows.Scan(&d.Id, &d.WebhookId, &d.EventId, &typ, &d.ToDoId, &st, &d.Attempts, &d.ResponseCode, &d.Error,
+		&nextAttemptAt, &deliveredAt, &createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "failed to retrieve field values from WebhookDelivery row-> "+err.Error())
+	}
+	d.EventType = v1.EventType(typ)
+	d.Status = v1.DeliveryStatus(st)
+
+	var err error
+	if d.Status == v1.DeliveryStatus_DELIVERY_STATUS_PENDING {
+		if d.NextAttemptAt, err = ptypes.TimestampProto(nextAttemptAt); err != nil {
+			return nil, status.Error(codes.Unknown, "nextAttemptAt field has invalid format-> "+err.Error())
+		}
+	}
+	if deliveredAt.Valid {
+		if d.DeliveredAt, err = ptypes.TimestampProto(deliveredAt.Time); err != nil {
+			return nil, status.Error(codes.Unknown, "deliveredAt field has invalid format-> "+err.Error())
+		}
+	}
+	if d.CreatedAt, err = ptypes.TimestampProto(createdAt); err != nil {
+		return nil, status.Error(codes.Unknown, "createdAt field has invalid format-> "+err.Error())
+	}
+	return &d, nil
+}
@@ -0,0 +1,537 @@
+package v1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/api/v1"
+	"github.com/eyo-omat/go-grpc-http-rest-microservice/pkg/auth"
+)
+
+func newDeliveryRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "WebhookID", "EventID", "Type", "ToDoID", "Status", "Attempts", "ResponseCode", "Error",
+		"NextAttemptAt", "DeliveredAt", "CreatedAt"})
+}
+
+func newWebhookRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ID", "URL", "EventTypes", "CreatedAt", "OwnerID"})
+}
+
+func Test_webhookServiceServer_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.CreateWebhookRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.WebhookServiceServer
+		args    args
+		mock    func()
+		want    *v1.Webhook
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateWebhookRequest{
+					Api: "v1",
+					Webhook: &v1.Webhook{
+						Url:        "https://example.com/hook",
+						EventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_CREATED, v1.EventType_EVENT_TYPE_DELETED, v1.EventType_EVENT_TYPE_CREATED},
+						Secret:     "secret",
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO Webhook").WithArgs("https://example.com/hook", "secret", "1,3", sqlmock.AnyArg(), "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			want: &v1.Webhook{
+				Id:         1,
+				Url:        "https://example.com/hook",
+				EventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_CREATED, v1.EventType_EVENT_TYPE_DELETED},
+				Secret:     "secret",
+			},
+		},
+		{
+			name: "Owned by caller",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.CreateWebhookRequest{
+					Api: "v1",
+					Webhook: &v1.Webhook{
+						Url:     "https://example.com/hook",
+						Secret:  "secret",
+						OwnerId: "bob",
+					},
+				},
+			},
+			mock: func() {
+				mock.ExpectExec("INSERT INTO Webhook").WithArgs("https://example.com/hook", "secret", "", sqlmock.AnyArg(), "alice").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			want: &v1.Webhook{
+				Id:      1,
+				Url:     "https://example.com/hook",
+				Secret:  "secret",
+				OwnerId: "alice",
+			},
+		},
+		{
+			name: "Invalid URL",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateWebhookRequest{
+					Api:     "v1",
+					Webhook: &v1.Webhook{Url: "ftp://example.com"},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unspecified event type",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateWebhookRequest{
+					Api: "v1",
+					Webhook: &v1.Webhook{
+						Url:        "https://example.com/hook",
+						EventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_UNSPECIFIED},
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.CreateWebhookRequest{
+					Api:     "v1000",
+					Webhook: &v1.Webhook{Url: "https://example.com/hook"},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.CreateWebhook(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookServiceServer.CreateWebhook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				got.Webhook.CreatedAt = nil
+				if !reflect.DeepEqual(got.Webhook, tt.want) {
+					t.Errorf("webhookServiceServer.CreateWebhook() = %v, want %v", got.Webhook, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_webhookServiceServer_CreateWebhook_secret(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+
+	mock.ExpectExec("INSERT INTO Webhook").WithArgs("http://example.com", sqlmock.AnyArg(), "", sqlmock.AnyArg(), "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	got, err := s.CreateWebhook(context.Background(), &v1.CreateWebhookRequest{
+		Api:     "v1",
+		Webhook: &v1.Webhook{Url: "http://example.com"},
+	})
+	if err != nil {
+		t.Fatalf("webhookServiceServer.CreateWebhook() error = %v", err)
+	}
+	if len(got.Webhook.Secret) != 2*webhookSecretSize {
+		t.Errorf("webhookServiceServer.CreateWebhook() secret = %q, want %d hex digits", got.Webhook.Secret, 2*webhookSecretSize)
+	}
+}
+
+func Test_webhookServiceServer_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListWebhooksRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.WebhookServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListWebhooksResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListWebhooksRequest{Api: "v1"},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT `ID`, `URL`, `EventTypes`, `CreatedAt`, `OwnerID` FROM Webhook ORDER BY `ID`").
+					WillReturnRows(newWebhookRows().
+						AddRow(1, "https://example.com/1", "", tm, "").
+						AddRow(2, "https://example.com/2", "2,4", tm, ""))
+			},
+			want: &v1.ListWebhooksResponse{
+				Api: "v1",
+				Webhooks: []*v1.Webhook{
+					{Id: 1, Url: "https://example.com/1", CreatedAt: ts},
+					{Id: 2, Url: "https://example.com/2", EventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_UPDATED, v1.EventType_EVENT_TYPE_REMINDER}, CreatedAt: ts},
+				},
+			},
+		},
+		{
+			name: "Empty",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListWebhooksRequest{Api: "v1"},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Webhook").
+					WillReturnRows(newWebhookRows())
+			},
+			want: &v1.ListWebhooksResponse{
+				Api:      "v1",
+				Webhooks: []*v1.Webhook{},
+			},
+		},
+		{
+			name: "Webhooks of caller",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "alice"),
+				req: &v1.ListWebhooksRequest{Api: "v1"},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM Webhook WHERE `OwnerID`=\\? ORDER BY `ID`").WithArgs("alice").
+					WillReturnRows(newWebhookRows().AddRow(3, "https://example.com/3", "", tm, "alice"))
+			},
+			want: &v1.ListWebhooksResponse{
+				Api:      "v1",
+				Webhooks: []*v1.Webhook{{Id: 3, Url: "https://example.com/3", CreatedAt: ts, OwnerId: "alice"}},
+			},
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListWebhooksRequest{Api: "v1000"},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListWebhooks(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookServiceServer.ListWebhooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("webhookServiceServer.ListWebhooks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookServiceServer_DeleteWebhook(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+
+	type args struct {
+		ctx context.Context
+		req *v1.DeleteWebhookRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.WebhookServiceServer
+		args    args
+		mock    func()
+		want    *v1.DeleteWebhookResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteWebhookRequest{Api: "v1", Id: 1},
+			},
+			mock: func() {
+				mock.ExpectExec("DELETE FROM Webhook").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			want: &v1.DeleteWebhookResponse{
+				Api:     "v1",
+				Deleted: 1,
+			},
+		},
+		{
+			name: "NOT FOUND",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteWebhookRequest{Api: "v1", Id: 1},
+			},
+			mock: func() {
+				mock.ExpectExec("DELETE FROM Webhook").WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Webhook of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.DeleteWebhookRequest{Api: "v1", Id: 1},
+			},
+			mock: func() {
+				mock.ExpectExec("DELETE FROM Webhook WHERE `ID`=\\? AND `OwnerID`=\\?").WithArgs(1, "bob").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.DeleteWebhookRequest{Api: "v1000", Id: 1},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.DeleteWebhook(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookServiceServer.DeleteWebhook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("webhookServiceServer.DeleteWebhook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookServiceServer_ListWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	s := NewWebhookServiceServer(db)
+	tm := time.Now().In(time.UTC)
+	ts, _ := ptypes.TimestampProto(tm)
+	nextPageToken := encodePageToken(pageToken{Query: queryHash("1"), Values: []string{"2"}})
+
+	type args struct {
+		ctx context.Context
+		req *v1.ListWebhookDeliveriesRequest
+	}
+	tests := []struct {
+		name    string
+		s       v1.WebhookServiceServer
+		args    args
+		mock    func()
+		want    *v1.ListWebhookDeliveriesResponse
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListWebhookDeliveriesRequest{Api: "v1", WebhookId: 1, PageSize: 2},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Webhook").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM WebhookDelivery d JOIN ToDoEvent e ON e.`ID`=d.`EventID` WHERE d.`WebhookID`=\\? ORDER BY d.`ID` DESC LIMIT \\?").
+					WithArgs(1, 3).
+					WillReturnRows(newDeliveryRows().
+						AddRow(3, 1, 7, 1, 5, 0, 2, 500, "webhook responded with 500 Internal Server Error", tm, nil, tm).
+						AddRow(2, 1, 6, 3, 4, 1, 1, 200, "", tm, tm, tm).
+						AddRow(1, 1, 5, 2, 4, 2, 8, 0, "connection refused", tm, nil, tm))
+			},
+			want: &v1.ListWebhookDeliveriesResponse{
+				Api: "v1",
+				Deliveries: []*v1.WebhookDelivery{
+					{
+						Id:            3,
+						WebhookId:     1,
+						EventId:       7,
+						EventType:     v1.EventType_EVENT_TYPE_CREATED,
+						ToDoId:        5,
+						Status:        v1.DeliveryStatus_DELIVERY_STATUS_PENDING,
+						Attempts:      2,
+						ResponseCode:  500,
+						Error:         "webhook responded with 500 Internal Server Error",
+						NextAttemptAt: ts,
+						CreatedAt:     ts,
+					},
+					{
+						Id:           2,
+						WebhookId:    1,
+						EventId:      6,
+						EventType:    v1.EventType_EVENT_TYPE_DELETED,
+						ToDoId:       4,
+						Status:       v1.DeliveryStatus_DELIVERY_STATUS_SUCCEEDED,
+						Attempts:     1,
+						ResponseCode: 200,
+						DeliveredAt:  ts,
+						CreatedAt:    ts,
+					},
+				},
+				NextPageToken: nextPageToken,
+			},
+		},
+		{
+			name: "Next page",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListWebhookDeliveriesRequest{Api: "v1", WebhookId: 1, PageSize: 2, PageToken: nextPageToken},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Webhook").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+				mock.ExpectQuery("SELECT (.+) FROM WebhookDelivery d JOIN ToDoEvent e ON e.`ID`=d.`EventID` WHERE d.`WebhookID`=\\? AND d.`ID`<\\?").
+					WithArgs(1, 2, 3).
+					WillReturnRows(newDeliveryRows())
+			},
+			want: &v1.ListWebhookDeliveriesResponse{
+				Api:        "v1",
+				Deliveries: []*v1.WebhookDelivery{},
+			},
+		},
+		{
+			name: "Page token of another webhook",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListWebhookDeliveriesRequest{Api: "v1", WebhookId: 2, PageToken: nextPageToken},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "NOT FOUND",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListWebhookDeliveriesRequest{Api: "v1", WebhookId: 1},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Webhook").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Webhook of another owner",
+			s:    s,
+			args: args{
+				ctx: auth.NewContext(ctx, "bob"),
+				req: &v1.ListWebhookDeliveriesRequest{Api: "v1", WebhookId: 1},
+			},
+			mock: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM Webhook WHERE `ID`=\\? AND `OwnerID`=\\?").WithArgs(1, "bob").
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported API",
+			s:    s,
+			args: args{
+				ctx: ctx,
+				req: &v1.ListWebhookDeliveriesRequest{Api: "v1000", WebhookId: 1},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := tt.s.ListWebhookDeliveries(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookServiceServer.ListWebhookDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("webhookServiceServer.ListWebhookDeliveries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseEventTypes(t *testing.T) {
+	for _, types := range [][]v1.EventType{nil, {v1.EventType_EVENT_TYPE_CREATED}, {v1.EventType_EVENT_TYPE_UPDATED, v1.EventType_EVENT_TYPE_REMINDER}} {
+		s, err := formatEventTypes(types)
+		if err != nil {
+			t.Fatalf("formatEventTypes(%v) error = %v", types, err)
+		}
+		if got := parseEventTypes(s); !reflect.DeepEqual(got, types) {
+			t.Errorf("parseEventTypes(%q) = %v, want %v", s, got, types)
+		}
+	}
+	if _, err := formatEventTypes([]v1.EventType{v1.EventType(100)}); err == nil {
+		t.Errorf("formatEventTypes() accepted unknown event type")
+	}
+}
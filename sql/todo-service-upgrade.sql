@@ -0,0 +1,91 @@
+-- Upgrade of a ToDo service MySQL database created by an earlier version to todo-service.sql
+--
+-- Run todo-service.sql first so that missing tables are created, then this script adds the columns
+-- and keys missing from the tables that already existed. It only adds what is missing and can be run again.
+-- Existing tasks, projects and webhooks have no owner, assign them with UPDATE ... SET `OwnerID`=...
+-- before enabling authentication.
+
+DELIMITER //
+
+-- UpgradeAddColumn adds the column with the definition to the table unless it has the column
+CREATE PROCEDURE `UpgradeAddColumn`(IN tbl VARCHAR(64), IN col VARCHAR(64), IN def VARCHAR(1024))
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM information_schema.COLUMNS
+                 WHERE `TABLE_SCHEMA`=DATABASE() AND `TABLE_NAME`=tbl AND `COLUMN_NAME`=col) THEN
+    SET @upgrade = CONCAT('ALTER TABLE `', tbl, '` ADD COLUMN `', col, '` ', def);
+    PREPARE upgrade FROM @upgrade;
+    EXECUTE upgrade;
+    DEALLOCATE PREPARE upgrade;
+  END IF;
+END //
+
+-- UpgradeAddKey adds the key or constraint with the definition to the table unless it has one with the name
+CREATE PROCEDURE `UpgradeAddKey`(IN tbl VARCHAR(64), IN name VARCHAR(64), IN def VARCHAR(1024))
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM information_schema.STATISTICS
+                 WHERE `TABLE_SCHEMA`=DATABASE() AND `TABLE_NAME`=tbl AND `INDEX_NAME`=name)
+     AND NOT EXISTS (SELECT 1 FROM information_schema.TABLE_CONSTRAINTS
+                     WHERE `CONSTRAINT_SCHEMA`=DATABASE() AND `TABLE_NAME`=tbl AND `CONSTRAINT_NAME`=name) THEN
+    SET @upgrade = CONCAT('ALTER TABLE `', tbl, '` ADD ', def);
+    PREPARE upgrade FROM @upgrade;
+    EXECUTE upgrade;
+    DEALLOCATE PREPARE upgrade;
+  END IF;
+END //
+
+DELIMITER ;
+
+CALL UpgradeAddColumn('Project', 'OwnerID', 'varchar(255) NOT NULL DEFAULT '''' AFTER `CreatedAt`');
+CALL UpgradeAddKey('Project', 'Project_OwnerID', 'KEY `Project_OwnerID` (`OwnerID`)');
+
+CALL UpgradeAddColumn('ToDo', 'Completed', 'tinyint(1) NOT NULL DEFAULT 0 AFTER `Reminder`');
+CALL UpgradeAddColumn('ToDo', 'CompletedAt', 'timestamp NULL DEFAULT NULL AFTER `Completed`');
+CALL UpgradeAddColumn('ToDo', 'Due', 'timestamp NULL DEFAULT NULL AFTER `CompletedAt`');
+CALL UpgradeAddColumn('ToDo', 'Priority', 'tinyint NOT NULL DEFAULT 0 AFTER `Due`');
+CALL UpgradeAddColumn('ToDo', 'ParentID', 'bigint(20) NULL DEFAULT NULL AFTER `Priority`');
+CALL UpgradeAddColumn('ToDo', 'ProjectID', 'bigint(20) NULL DEFAULT NULL AFTER `ParentID`');
+CALL UpgradeAddColumn('ToDo', 'Recurrence', 'varchar(255) NOT NULL DEFAULT '''' AFTER `ProjectID`');
+CALL UpgradeAddColumn('ToDo', 'TimeZone', 'varchar(64) NOT NULL DEFAULT '''' AFTER `Recurrence`');
+CALL UpgradeAddColumn('ToDo', 'RecurrenceStart', 'timestamp NULL DEFAULT NULL AFTER `TimeZone`');
+CALL UpgradeAddColumn('ToDo', 'DeletedAt', 'timestamp NULL DEFAULT NULL AFTER `RecurrenceStart`');
+CALL UpgradeAddColumn('ToDo', 'Version', 'bigint(20) NOT NULL DEFAULT 1 AFTER `DeletedAt`');
+-- Reminders already due when ReminderSent is added were handled by the earlier version, they are marked
+-- as sent so that the scheduler does not fire every old reminder at once. Running the script again keeps
+-- reminders the scheduler has yet to deliver.
+SET @reminderSent = (SELECT COUNT(*) FROM information_schema.COLUMNS
+                     WHERE `TABLE_SCHEMA`=DATABASE() AND `TABLE_NAME`='ToDo' AND `COLUMN_NAME`='ReminderSent');
+CALL UpgradeAddColumn('ToDo', 'ReminderSent', 'timestamp NULL DEFAULT NULL AFTER `Version`');
+UPDATE ToDo SET `ReminderSent`=`Reminder` WHERE @reminderSent=0 AND `Reminder` IS NOT NULL AND `Reminder`<=NOW();
+CALL UpgradeAddColumn('ToDo', 'ReminderLease', 'timestamp NULL DEFAULT NULL AFTER `ReminderSent`');
+CALL UpgradeAddColumn('ToDo', 'Position', 'varchar(255) CHARACTER SET ascii COLLATE ascii_bin NULL DEFAULT NULL AFTER `ReminderLease`');
+CALL UpgradeAddColumn('ToDo', 'OwnerID', 'varchar(255) NOT NULL DEFAULT '''' AFTER `Position`');
+
+-- Tasks without a position are placed in the order they were created. Their positions are integer keys
+-- with the head 'e' and the ID as five base 62 digits, so they stay unique and sort by ID below 62^5 tasks.
+SET @digits = '0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz';
+UPDATE ToDo SET `Position`=CONCAT('e',
+  SUBSTRING(@digits, `ID` DIV 14776336 % 62 + 1, 1),
+  SUBSTRING(@digits, `ID` DIV 238328 % 62 + 1, 1),
+  SUBSTRING(@digits, `ID` DIV 3844 % 62 + 1, 1),
+  SUBSTRING(@digits, `ID` DIV 62 % 62 + 1, 1),
+  SUBSTRING(@digits, `ID` % 62 + 1, 1))
+WHERE `Position` IS NULL;
+ALTER TABLE ToDo MODIFY COLUMN `Position` varchar(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL;
+
+CALL UpgradeAddKey('ToDo', 'ToDo_Position', 'UNIQUE KEY `ToDo_Position` (`Position`)');
+CALL UpgradeAddKey('ToDo', 'ToDo_Reminder', 'KEY `ToDo_Reminder` (`Reminder`)');
+CALL UpgradeAddKey('ToDo', 'ToDo_ParentID', 'KEY `ToDo_ParentID` (`ParentID`)');
+CALL UpgradeAddKey('ToDo', 'ToDo_ProjectID', 'KEY `ToDo_ProjectID` (`ProjectID`)');
+CALL UpgradeAddKey('ToDo', 'ToDo_DeletedAt', 'KEY `ToDo_DeletedAt` (`DeletedAt`)');
+CALL UpgradeAddKey('ToDo', 'ToDo_OwnerID', 'KEY `ToDo_OwnerID` (`OwnerID`)');
+CALL UpgradeAddKey('ToDo', 'ToDo_Search', 'FULLTEXT KEY `ToDo_Search` (`Title`, `Description`)');
+CALL UpgradeAddKey('ToDo', 'ToDo_Parent', 'CONSTRAINT `ToDo_Parent` FOREIGN KEY (`ParentID`) REFERENCES `ToDo` (`ID`)');
+CALL UpgradeAddKey('ToDo', 'ToDo_Project', 'CONSTRAINT `ToDo_Project` FOREIGN KEY (`ProjectID`) REFERENCES `Project` (`ID`) ON DELETE SET NULL');
+
+CALL UpgradeAddKey('ToDoEvent', 'ToDoEvent_CreatedAt', 'KEY `ToDoEvent_CreatedAt` (`CreatedAt`)');
+
+CALL UpgradeAddColumn('Webhook', 'OwnerID', 'varchar(255) NOT NULL DEFAULT '''' AFTER `CreatedAt`');
+CALL UpgradeAddKey('Webhook', 'Webhook_OwnerID', 'KEY `Webhook_OwnerID` (`OwnerID`)');
+
+DROP PROCEDURE `UpgradeAddColumn`;
+DROP PROCEDURE `UpgradeAddKey`;
@@ -0,0 +1,168 @@
+-- Schema of the ToDo service MySQL database
+
+CREATE TABLE IF NOT EXISTS `Project` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `Name` varchar(200) NOT NULL,
+  `Description` varchar(1024) NOT NULL DEFAULT '',
+  `ArchivedAt` timestamp NULL DEFAULT NULL,
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `OwnerID` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`ID`),
+  KEY `Project_ArchivedAt` (`ArchivedAt`),
+  KEY `Project_OwnerID` (`OwnerID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `ToDo` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `Title` varchar(200) DEFAULT NULL,
+  `Description` varchar(1024) DEFAULT NULL,
+  `Reminder` timestamp NULL DEFAULT NULL,
+  `Completed` tinyint(1) NOT NULL DEFAULT 0,
+  `CompletedAt` timestamp NULL DEFAULT NULL,
+  `Due` timestamp NULL DEFAULT NULL,
+  `Priority` tinyint NOT NULL DEFAULT 0,
+  `ParentID` bigint(20) NULL DEFAULT NULL,
+  `ProjectID` bigint(20) NULL DEFAULT NULL,
+  `Recurrence` varchar(255) NOT NULL DEFAULT '',
+  `TimeZone` varchar(64) NOT NULL DEFAULT '',
+  `RecurrenceStart` timestamp NULL DEFAULT NULL,
+  `DeletedAt` timestamp NULL DEFAULT NULL,
+  `Version` bigint(20) NOT NULL DEFAULT 1,
+  `ReminderSent` timestamp NULL DEFAULT NULL,
+  `ReminderLease` timestamp NULL DEFAULT NULL,
+  `Position` varchar(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL,
+  `OwnerID` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`ID`),
+  UNIQUE KEY `ToDo_Position` (`Position`),
+  KEY `ToDo_Reminder` (`Reminder`),
+  KEY `ToDo_ParentID` (`ParentID`),
+  KEY `ToDo_ProjectID` (`ProjectID`),
+  KEY `ToDo_DeletedAt` (`DeletedAt`),
+  KEY `ToDo_OwnerID` (`OwnerID`),
+  FULLTEXT KEY `ToDo_Search` (`Title`, `Description`),
+  CONSTRAINT `ToDo_Parent` FOREIGN KEY (`ParentID`) REFERENCES `ToDo` (`ID`),
+  CONSTRAINT `ToDo_Project` FOREIGN KEY (`ProjectID`) REFERENCES `Project` (`ID`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `ReminderDelivery` (
+  `ToDoID` bigint(20) NOT NULL,
+  `Notifier` varchar(64) NOT NULL,
+  `Reminder` timestamp NOT NULL,
+  PRIMARY KEY (`ToDoID`, `Notifier`),
+  CONSTRAINT `ReminderDelivery_ToDo` FOREIGN KEY (`ToDoID`) REFERENCES `ToDo` (`ID`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `Tag` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `Name` varchar(100) NOT NULL,
+  PRIMARY KEY (`ID`),
+  UNIQUE KEY `Tag_Name` (`Name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `ToDoTag` (
+  `ToDoID` bigint(20) NOT NULL,
+  `TagID` bigint(20) NOT NULL,
+  PRIMARY KEY (`ToDoID`, `TagID`),
+  KEY `ToDoTag_TagID` (`TagID`),
+  CONSTRAINT `ToDoTag_ToDo` FOREIGN KEY (`ToDoID`) REFERENCES `ToDo` (`ID`) ON DELETE CASCADE,
+  CONSTRAINT `ToDoTag_Tag` FOREIGN KEY (`TagID`) REFERENCES `Tag` (`ID`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `ToDoEvent` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `Type` tinyint NOT NULL,
+  `ToDoID` bigint(20) NOT NULL,
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ID`),
+  KEY `ToDoEvent_CreatedAt` (`CreatedAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `ToDoHistory` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `ToDoID` bigint(20) NOT NULL,
+  `Action` tinyint NOT NULL,
+  `Actor` varchar(255) NOT NULL DEFAULT '',
+  `Before` text NULL DEFAULT NULL,
+  `After` text NULL DEFAULT NULL,
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ID`),
+  KEY `ToDoHistory_ToDoID` (`ToDoID`, `ID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `Comment` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `ToDoID` bigint(20) NOT NULL,
+  `Author` varchar(255) NOT NULL DEFAULT '',
+  `Body` text NOT NULL,
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `UpdatedAt` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`ID`),
+  KEY `Comment_ToDoID` (`ToDoID`, `ID`),
+  CONSTRAINT `Comment_ToDo` FOREIGN KEY (`ToDoID`) REFERENCES `ToDo` (`ID`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `Attachment` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `ToDoID` bigint(20) NOT NULL,
+  `FileName` varchar(255) NOT NULL,
+  `ContentType` varchar(255) NOT NULL,
+  `Size` bigint(20) NOT NULL,
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ID`),
+  KEY `Attachment_ToDoID` (`ToDoID`, `ID`),
+  CONSTRAINT `Attachment_ToDo` FOREIGN KEY (`ToDoID`) REFERENCES `ToDo` (`ID`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `ToDoShare` (
+  `ToDoID` bigint(20) NOT NULL,
+  `Subject` varchar(255) NOT NULL,
+  `Access` tinyint NOT NULL,
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ToDoID`, `Subject`),
+  KEY `ToDoShare_Subject` (`Subject`),
+  CONSTRAINT `ToDoShare_ToDo` FOREIGN KEY (`ToDoID`) REFERENCES `ToDo` (`ID`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `Webhook` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `URL` varchar(2048) NOT NULL,
+  `Secret` varchar(128) NOT NULL,
+  `EventTypes` varchar(64) NOT NULL DEFAULT '',
+  `LastEventID` bigint(20) NOT NULL DEFAULT 0,
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `OwnerID` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`ID`),
+  KEY `Webhook_OwnerID` (`OwnerID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `WebhookDelivery` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `WebhookID` bigint(20) NOT NULL,
+  `EventID` bigint(20) NOT NULL,
+  `Status` tinyint NOT NULL DEFAULT 0,
+  `Attempts` int NOT NULL DEFAULT 0,
+  `ResponseCode` int NOT NULL DEFAULT 0,
+  `Error` varchar(1024) NOT NULL DEFAULT '',
+  `NextAttemptAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `DeliveredAt` timestamp NULL DEFAULT NULL,
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ID`),
+  UNIQUE KEY `WebhookDelivery_Event` (`WebhookID`, `EventID`),
+  KEY `WebhookDelivery_Pending` (`Status`, `NextAttemptAt`),
+  CONSTRAINT `WebhookDelivery_Webhook` FOREIGN KEY (`WebhookID`) REFERENCES `Webhook` (`ID`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `ApiKey` (
+  `ID` bigint(20) NOT NULL AUTO_INCREMENT,
+  `Name` varchar(200) NOT NULL DEFAULT '',
+  `Hash` char(64) CHARACTER SET ascii NOT NULL,
+  `Prefix` varchar(16) NOT NULL,
+  `Scope` tinyint NOT NULL,
+  `Owner` varchar(255) NOT NULL DEFAULT '',
+  `CreatedAt` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `LastUsedAt` timestamp NULL DEFAULT NULL,
+  `RevokedAt` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`ID`),
+  UNIQUE KEY `ApiKey_Hash` (`Hash`),
+  KEY `ApiKey_Owner` (`Owner`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;